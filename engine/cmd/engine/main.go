@@ -5,79 +5,46 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
-	"sync"
-	"time"
-
 	"sync/atomic"
+	"time"
 
+	"jobhunt-engine/internal/classify"
 	"jobhunt-engine/internal/config"
+	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/events"
+	"jobhunt-engine/internal/httpapi"
+	"jobhunt-engine/internal/ingest/ats"
+	atsashby "jobhunt-engine/internal/ingest/ats/ashby"
+	atsgreenhouse "jobhunt-engine/internal/ingest/ats/greenhouse"
+	atslever "jobhunt-engine/internal/ingest/ats/lever"
+	atsworkday "jobhunt-engine/internal/ingest/ats/workday"
+	"jobhunt-engine/internal/jobs"
+	"jobhunt-engine/internal/lifecycle"
+	applog "jobhunt-engine/internal/log"
+	"jobhunt-engine/internal/metrics"
+	"jobhunt-engine/internal/rank"
 	"jobhunt-engine/internal/scrape"
+	"jobhunt-engine/internal/scrape/ashby"
+	"jobhunt-engine/internal/scrape/dedupe"
+	"jobhunt-engine/internal/scrape/greenhouse"
+	"jobhunt-engine/internal/scrape/smartrecruiters"
+	"jobhunt-engine/internal/scrape/types"
+	"jobhunt-engine/internal/scrape/util"
+	"jobhunt-engine/internal/scrape/workday"
+	"jobhunt-engine/internal/store"
 
 	_ "modernc.org/sqlite"
 )
 
-type Job struct {
-	ID        int64     `json:"id"`
-	Company   string    `json:"company"`
-	Title     string    `json:"title"`
-	Location  string    `json:"location"`
-	WorkMode  string    `json:"workMode"`
-	URL       string    `json:"url"`
-	Score     int       `json:"score"`
-	Tags      []string  `json:"tags"`
-	FirstSeen time.Time `json:"firstSeen"`
-}
-
-type ScrapeStatus struct {
-	LastRunAt string `json:"last_run_at"`
-	LastOkAt  string `json:"last_ok_at"`
-	LastError string `json:"last_error"`
-	LastAdded int    `json:"last_added"`
-	Running   bool   `json:"running"`
-}
-
-type eventHub struct {
-	mu      sync.Mutex
-	clients map[chan string]struct{}
-}
-
-func newHub() *eventHub {
-	return &eventHub{clients: make(map[chan string]struct{})}
-}
-
-func (h *eventHub) subscribe() chan string {
-	ch := make(chan string, 10)
-	h.mu.Lock()
-	h.clients[ch] = struct{}{}
-	h.mu.Unlock()
-	return ch
-}
-
-func (h *eventHub) unsubscribe(ch chan string) {
-	h.mu.Lock()
-	delete(h.clients, ch)
-	h.mu.Unlock()
-	close(ch)
-}
-
-func (h *eventHub) publish(evt string) {
-	h.mu.Lock()
-	defer h.mu.Unlock()
-	for ch := range h.clients {
-		select {
-		case ch <- evt:
-		default:
-			// drop if slow
-		}
-	}
-}
+// bayesRetrainInterval is how often httpapi.StartBayesRetrainLoop
+// refreshes the in-memory BayesScorer from bayes_tokens.
+const bayesRetrainInterval = 10 * time.Minute
 
 func main() {
 	dataDir := os.Getenv("JOBHUNT_DATA_DIR")
@@ -85,13 +52,13 @@ func main() {
 		dataDir = "."
 	}
 	if err := os.MkdirAll(dataDir, 0o755); err != nil {
-		log.Fatal(err)
+		applog.Fatal("create data dir failed", "error", err)
 	}
 
 	lockPath := filepath.Join(dataDir, "engine.lock")
 	lockFile, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
 	if err != nil {
-		log.Fatalf("engine is already running (lock exists): %s", lockPath)
+		applog.Fatal("engine is already running (lock exists)", "lock_path", lockPath)
 	}
 	defer func() {
 		lockFile.Close()
@@ -100,7 +67,7 @@ func main() {
 
 	userCfgPath, err := config.EnsureUserConfig(dataDir)
 	if err != nil {
-		log.Fatalf("config bootstrap failed: %v", err)
+		applog.Fatal("config bootstrap failed", "error", err)
 	}
 	// Load config and keep it reloadable
 	var cfgVal atomic.Value // stores config.Config
@@ -109,217 +76,350 @@ func main() {
 	}
 	cfg, err := loadCfg()
 	if err != nil {
-		log.Fatalf("config load failed (%s): %v", userCfgPath, err)
+		applog.Fatal("config load failed", "path", userCfgPath, "error", err)
 	}
 	cfgVal.Store(cfg)
 
-	// Load scrape status
-	var scrapeStatus atomic.Value // stores ScrapeStatus
-	scrapeStatus.Store(ScrapeStatus{})
-
-	// scorer := func(job domain.JobLead) (int, []string) {
-	// 	c := cfgVal.Load().(config.Config)
-	// 	return rank.YAMLScorer{Cfg: c}.Score(job)
-	// }
+	if _, err := applog.Init(cfg); err != nil {
+		applog.Fatal("logger init failed", "error", err)
+	}
 
 	dbPath := filepath.Join(dataDir, "jobhunt.db")
 	db, err := sql.Open("sqlite", dbPath)
 	if _, err := db.Exec(`PRAGMA journal_mode=WAL;`); err != nil {
-		log.Printf("WARN: set WAL: %v", err)
+		applog.Warn("set WAL failed", "error", err)
 	}
 	if _, err := db.Exec(`PRAGMA busy_timeout=5000;`); err != nil {
-		log.Printf("WARN: set busy_timeout: %v", err)
+		applog.Warn("set busy_timeout failed", "error", err)
 	}
 	if _, err := db.Exec(`PRAGMA synchronous=NORMAL;`); err != nil {
-		log.Printf("WARN: set synchronous: %v", err)
+		applog.Warn("set synchronous failed", "error", err)
 	}
 	db.SetMaxOpenConns(1)
 	db.SetMaxIdleConns(1)
 	db.SetConnMaxLifetime(0)
 	if err != nil {
-		log.Fatal(err)
+		applog.Fatal("open db failed", "error", err)
 	}
 	defer db.Close()
 
-	if err := migrate(db); err != nil {
-		log.Fatal(err)
+	if err := store.Migrate(db); err != nil {
+		applog.Fatal("migrate failed", "error", err)
+	}
+	if err := jobs.Migrate(db); err != nil {
+		applog.Fatal("jobs migrate failed", "error", err)
+	}
+	if err := classify.Migrate(db); err != nil {
+		applog.Fatal("classify migrate failed", "error", err)
 	}
 
-	hub := newHub()
-
-	startEmailPoller(db, &cfgVal, &scrapeStatus, hub)
-
-	mux := http.NewServeMux()
-	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		writeJSON(w, map[string]any{"ok": true, "time": time.Now().Format(time.RFC3339)})
-	})
-	mux.HandleFunc("/jobs", func(w http.ResponseWriter, r *http.Request) {
-		jobs, err := listJobs(r.Context(), db)
-		if err != nil {
-			http.Error(w, err.Error(), 500)
-			return
+	dedupeFilter, err := dedupe.Open(dataDir, 200_000, 0.01)
+	if err != nil {
+		applog.Fatal("dedupe filter open failed", "error", err)
+	}
+	if n, perr := dedupe.PopulateFromDB(db, dedupeFilter); perr != nil {
+		applog.Warn("dedupe populate from db failed", "error", perr)
+	} else {
+		applog.Info("dedupe filter populated", "existing_source_ids", n)
+	}
+	scrape.SetDedupeFilter(dedupeFilter)
+	scrape.SetDB(db)
+	scrape.SetURLScope(cfg.Sources.URLScope.Rules)
+	scrape.SetLimiter(util.NewHostLimiter(1.0, 2))
+	go func() {
+		t := time.NewTicker(5 * time.Minute)
+		defer t.Stop()
+		for range t.C {
+			if err := dedupeFilter.Flush(); err != nil {
+				applog.Warn("dedupe flush failed", "error", err)
+			}
 		}
-		writeJSON(w, jobs)
+	}()
+	defer dedupeFilter.Flush()
+
+	registerATSSources(context.Background(), db, cfg, dataDir)
+	registerATSConnectors(cfg, db)
+
+	// hub is the engine's SSE fan-out - see internal/events.Hub.
+	hub := events.NewHub(0)
+
+	// scrapeStatusVal backs GET/POST /scrape/status/run (see
+	// httpapi.ScrapeHandler); the "email" Spec job below keeps it in
+	// sync so the periodic background poll is visible there too, not
+	// just runs triggered through the HTTP endpoint.
+	var scrapeStatusVal atomic.Value // stores types.ScrapeStatus
+	scrapeStatusVal.Store(types.ScrapeStatus{})
+
+	lc := lifecycle.New(time.Duration(cfg.App.ShutdownTimeoutSeconds)*time.Second, hub)
+
+	bayesScorer := rank.NewBayesScorer()
+	bayesCtx, bayesCancel := context.WithCancel(context.Background())
+	defer bayesCancel()
+	httpapi.StartBayesRetrainLoop(bayesCtx, db, bayesScorer, bayesRetrainInterval)
+
+	sched := jobs.NewScheduler(db, hub.Publish)
+	// "email" already doubles as the IMAP polling fallback: it runs
+	// unconditionally on its own Interval regardless of whether
+	// Email.IdleEnabled's push-based IdleWatcher is also running, so a
+	// dropped/stalled IDLE connection is never more than one tick away
+	// from being caught by a plain SEARCH.
+	sched.Register(jobs.Spec{
+		Name: "email",
+		Job: jobs.JobFunc(func(ctx context.Context) (int, error) {
+			cfg := cfgVal.Load().(config.Config)
+			start := time.Now().Format(time.RFC3339)
+			added, err := scrape.RunScrapeOnce(db, cfg, func(source string) {
+				hub.Publish(events.MakeEvent("", "job_created", 1, map[string]any{"source": source}))
+			})
+			prev, _ := scrapeStatusVal.Load().(types.ScrapeStatus)
+			next := types.ScrapeStatus{LastRunAt: start, LastOkAt: prev.LastOkAt, LastAdded: added}
+			if err != nil {
+				next.LastError = err.Error()
+			} else {
+				next.LastOkAt = start
+			}
+			scrapeStatusVal.Store(next)
+			return added, err
+		}),
+		Interval:      30 * time.Second,
+		MaxConcurrent: 1,
 	})
-	mux.HandleFunc("/jobs/", func(w http.ResponseWriter, r *http.Request) {
-		// expects /jobs/{id}
-		if r.Method != http.MethodDelete {
-			http.Error(w, "DELETE only", 405)
-			return
-		}
-
-		idStr := strings.TrimPrefix(r.URL.Path, "/jobs/")
-		id, err := strconv.ParseInt(idStr, 10, 64)
-		if err != nil || id <= 0 {
-			http.Error(w, "invalid id", 400)
-			return
-		}
-
-		if err := deleteJob(r.Context(), db, id); err != nil {
-			http.Error(w, err.Error(), 500)
-			return
-		}
-
-		// Optional: notify UI via SSE so it refreshes
-		hub.publish(`{"type":"job_deleted","id":` + fmt.Sprint(id) + `}`)
-
-		writeJSON(w, map[string]any{"ok": true, "id": id})
+	sched.Register(jobs.Spec{
+		Name: "jobs-cleanup",
+		Job: jobs.JobFunc(func(ctx context.Context) (int, error) {
+			cfg := cfgVal.Load().(config.Config)
+			n, err := store.CleanupOldJobs(db, cfg.Maintenance.JobsRetentionDays)
+			return int(n), err
+		}),
+		Interval:      6 * time.Hour,
+		MaxConcurrent: 1,
 	})
-
-	mux.HandleFunc("/config", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			cur := cfgVal.Load().(config.Config)
-			writeJSON(w, cur)
-			return
-		case http.MethodPut:
-			// Temporary debug block
-			// b, _ := io.ReadAll(r.Body)
-			// log.Printf("PUT /config raw : %s", string(b))
-
-			dec := json.NewDecoder(r.Body)
-			dec.DisallowUnknownFields()
-
-			var incoming config.Config
-			if err := dec.Decode(&incoming); err != nil {
-				http.Error(w, "invalid JSON: "+err.Error(), 400)
-				return
+	sched.Register(jobs.Spec{
+		Name: "logo-refresh",
+		Job: jobs.JobFunc(func(ctx context.Context) (int, error) {
+			cfg := cfgVal.Load().(config.Config)
+			keys, err := store.StaleLogoKeys(ctx, db, cfg.Maintenance.LogoRefreshDays)
+			if err != nil {
+				return 0, err
 			}
-			if dec.More() {
-				http.Error(w, "invalid JSON: trailing data", 400)
-				return
+			refreshed := 0
+			for _, key := range keys {
+				if err := store.RevalidateLogo(ctx, db, dataDir, key); err != nil {
+					return refreshed, err
+				}
+				refreshed++
 			}
-
-			// log.Printf("decoded incoming app=%+v", incoming.App)
-			// log.Printf("decoded incoming port=%d data_dir=%q", incoming.App.Port, incoming.App.DataDir)
-
-			if incoming.App.Port == 0 {
-				http.Error(w, "invalid config: app.port missing", 400)
-				return
+			return refreshed, nil
+		}),
+		Interval:      6 * time.Hour,
+		MaxConcurrent: 1,
+	})
+	sched.Register(jobs.Spec{
+		Name: "domain-refresh",
+		Job: jobs.JobFunc(func(ctx context.Context) (int, error) {
+			cfg := cfgVal.Load().(config.Config)
+			companies, err := store.StaleCompanyDomains(ctx, db, cfg.Maintenance.DomainRefreshDays)
+			if err != nil {
+				return 0, err
 			}
-			if incoming.Email.Enabled {
-				if incoming.Email.IMAPHost == "" || incoming.Email.Username == "" {
-					http.Error(w, "invalid config: email enabled but missing host/username", 400)
-					return
+			refreshed := 0
+			for _, company := range companies {
+				if _, err := scrape.RefreshCompanyDomain(ctx, db, company); err != nil {
+					return refreshed, err
 				}
+				refreshed++
 			}
-
-			if err := config.SaveAtomic(userCfgPath, incoming); err != nil {
-				http.Error(w, err.Error(), 400)
-				return
+			return refreshed, nil
+		}),
+		Interval:      24 * time.Hour,
+		MaxConcurrent: 1,
+	})
+	sched.Register(jobs.Spec{
+		Name: "ats-health",
+		Job: jobs.JobFunc(func(ctx context.Context) (int, error) {
+			healthy := 0
+			var firstErr error
+			for _, c := range ats.Connectors() {
+				if err := c.HealthCheck(ctx); err != nil {
+					applog.Warn("ats connector health check failed", "connector", c.Type(), "error", err)
+					if firstErr == nil {
+						firstErr = fmt.Errorf("%s: %w", c.Type(), err)
+					}
+					continue
+				}
+				healthy++
 			}
-
-			saved, err := loadCfg()
+			return healthy, firstErr
+		}),
+		Interval:      30 * time.Minute,
+		MaxConcurrent: 1,
+	})
+	// "ats-ingest" is internal/ingest/ats's real caller: for every
+	// registered Connector (registerATSConnectors above) it lists every
+	// configured company's board via ListJobs, fills in any lead whose
+	// Description came back empty via FetchJobDetail, and hands the
+	// whole batch to scrape.ProcessLeads - the same scoring/dedupe/
+	// logo-enrichment/insert pipeline internal/jobs' worker path and
+	// internal/poll already feed from the types.Fetcher side. This runs
+	// independently of, and in addition to, the "email" job's
+	// HTML-scraping registerATSSources pipeline; InsertJobIfNew's
+	// source_id uniqueness means a posting both pipelines see is only
+	// ever inserted once.
+	sched.Register(jobs.Spec{
+		Name: "ats-ingest",
+		Job: jobs.JobFunc(func(ctx context.Context) (int, error) {
+			cfg := cfgVal.Load().(config.Config)
+			var leads []domain.JobLead
+			var firstErr error
+			for _, c := range ats.Connectors() {
+				for _, company := range atsCompaniesForType(cfg, c.Type()) {
+					companyLeads, err := c.ListJobs(ctx, company)
+					if err != nil {
+						applog.Warn("ats connector list jobs failed", "connector", c.Type(), "company", company.Name, "error", err)
+						if firstErr == nil {
+							firstErr = fmt.Errorf("%s/%s: %w", c.Type(), company.Name, err)
+						}
+						continue
+					}
+					for i, lead := range companyLeads {
+						if lead.Description != "" {
+							continue
+						}
+						if full, derr := c.FetchJobDetail(ctx, company, lead); derr == nil {
+							companyLeads[i] = full
+						}
+					}
+					leads = append(leads, companyLeads...)
+				}
+			}
+			added := scrape.ProcessLeads(ctx, db, cfg, leads, func() {
+				hub.Publish(events.MakeEvent("", "job_created", 1, map[string]any{"source": "ats"}))
+			})
+			return added, firstErr
+		}),
+		Interval:      15 * time.Minute,
+		MaxConcurrent: 1,
+	})
+	// "backup" has no Interval (ticker disabled) - it only ever runs via
+	// RunNow, either the generic POST /jobs/{name}/run jobs.RegisterRoutes
+	// wires up below or a future scheduled trigger, so a large export
+	// doesn't have to block a single HTTP request the way GET /jobs/backup
+	// does.
+	sched.Register(jobs.Spec{
+		Name: "backup",
+		Job: jobs.JobFunc(func(ctx context.Context) (int, error) {
+			backupDir := filepath.Join(dataDir, "backups")
+			if err := os.MkdirAll(backupDir, 0o755); err != nil {
+				return 0, fmt.Errorf("mkdir backups dir: %w", err)
+			}
+			path := filepath.Join(backupDir, fmt.Sprintf("jobs-%s.ndjson", time.Now().UTC().Format("20060102T150405Z")))
+			f, err := os.Create(path)
 			if err != nil {
-				http.Error(w, "saved but reload failed: "+err.Error(), 500)
-				return
+				return 0, fmt.Errorf("create backup file: %w", err)
 			}
-			cfgVal.Store(saved)
-			writeJSON(w, saved)
-			return
+			defer f.Close()
 
-		default:
-			http.Error(w, "GET or PUT only", 405)
-			return
-		}
-	})
-	mux.HandleFunc("/config/path", func(w http.ResponseWriter, r *http.Request) {
-		abs, _ := filepath.Abs(userCfgPath)
-		writeJSON(w, map[string]any{"path": abs})
+			hub.Publish(events.MakeEvent("", events.KindBackupStarted, 1, events.BackupStarted{Mode: "export"}))
+			enc := json.NewEncoder(f)
+			n, err := store.ExportJobs(ctx, db, func(j store.BackupJob) error {
+				return enc.Encode(j)
+			})
+			if err != nil {
+				hub.Publish(events.MakeEvent("", events.KindBackupCompleted, 1, events.BackupCompleted{Mode: "export", Seen: n, Err: err.Error()}))
+				return n, err
+			}
+			hub.Publish(events.MakeEvent("", events.KindBackupCompleted, 1, events.BackupCompleted{Mode: "export", Seen: n, Inserted: n}))
+			return n, nil
+		}),
+		Interval:      0,
+		MaxConcurrent: 1,
 	})
-	mux.HandleFunc("/scrape/status", func(w http.ResponseWriter, r *http.Request) {
+	sched.Start(context.Background())
+
+	// runEmailScrape adapts scrape.RunScrapeOnce - the same entrypoint
+	// the scheduled "email" Spec job above drives - to the simpler
+	// func() callback shape ScrapeHandler.Run expects. internal/poll's
+	// own PollOnce has the matching signature but doesn't currently
+	// compile against this tree, so route through the working
+	// entrypoint instead of a broken one.
+	runEmailScrape := func(db *sql.DB, cfg config.Config, onNewJob func()) (int, error) {
+		return scrape.RunScrapeOnce(db, cfg, func(source string) { onNewJob() })
+	}
+
+	deps := httpapi.Deps{
+		DB:             db,
+		DataDir:        dataDir,
+		Hub:            hub,
+		CfgVal:         &cfgVal,
+		ScrapeStatus:   &scrapeStatusVal,
+		UserCfgPath:    userCfgPath,
+		LoadCfg:        loadCfg,
+		DeleteJob:      httpapi.DeleteJob,
+		RunEmailScrape: runEmailScrape,
+		BayesScorer:    bayesScorer,
+		Lifecycle:      lc,
+	}
+	mux := httpapi.NewMux(deps)
+
+	mux.HandleFunc("/debug/scrape", metrics.DebugScrapeHandler())
+	mux.HandleFunc("/dedupe/stats", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
 			http.Error(w, "GET only", 405)
 			return
 		}
-		st := scrapeStatus.Load().(ScrapeStatus)
-		writeJSON(w, st)
+		writeJSON(w, dedupeFilter.Stats())
 	})
 
-	mux.HandleFunc("/scrape/run", func(w http.ResponseWriter, r *http.Request) {
+	// /scrape/{source}/deadline and /scrape/{source}/cancel reach into
+	// internal/scrape's package-level deadline registry (see
+	// deadlines.go) to adjust or kill an in-flight ATS fetch by name,
+	// without waiting for its fixed 5-minute timeout. Doesn't shadow
+	// the exact "/scrape/status"/"/scrape/run" routes httpapi.NewMux
+	// already registered - net/http prefers the longer, exact match
+	// over this "/scrape/" subtree one.
+	mux.HandleFunc("/scrape/", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			http.Error(w, "POST only", 405)
 			return
 		}
-
-		// prevent concurrent runs
-		st := scrapeStatus.Load().(ScrapeStatus)
-		if st.Running {
-			writeJSON(w, map[string]any{"ok": false, "msg": "already running"})
+		rest := strings.TrimPrefix(r.URL.Path, "/scrape/")
+		source, action, ok := strings.Cut(rest, "/")
+		if !ok || source == "" || action == "" {
+			http.Error(w, "expects /scrape/{source}/deadline or /scrape/{source}/cancel", 404)
 			return
 		}
 
-		// run async so request returns quickly
-		scrapeStatus.Store(ScrapeStatus{
-			LastRunAt: time.Now().Format(time.RFC3339),
-			Running:   true,
-			LastError: "",
-			LastAdded: 0,
-			LastOkAt:  st.LastOkAt,
-		})
-
-		go func() {
-			added, err := scrape.RunEmailScrapeOnce(db, cfgVal.Load().(config.Config), func() {
-				hub.publish(`{"type":"job_created"}`)
-			})
-			now := time.Now().Format(time.RFC3339)
-
-			next := scrapeStatus.Load().(ScrapeStatus)
-			next.Running = false
-			next.LastRunAt = now
-			next.LastAdded = added
-			if err != nil {
-				next.LastError = err.Error()
-			} else {
-				next.LastError = ""
-				next.LastOkAt = now
+		switch action {
+		case "deadline":
+			seconds, err := strconv.Atoi(r.URL.Query().Get("seconds"))
+			if err != nil || seconds <= 0 {
+				http.Error(w, "seconds must be a positive integer", 400)
+				return
 			}
-			scrapeStatus.Store(next)
-		}()
-
-		writeJSON(w, map[string]any{"ok": true})
-	})
-
-	mux.HandleFunc("/seed", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			http.Error(w, "POST only", 405)
-			return
-		}
-		job, err := seedJob(r.Context(), db)
-		if err != nil {
-			http.Error(w, err.Error(), 500)
-			return
+			if !scrape.SetDeadline(source, time.Now().Add(time.Duration(seconds)*time.Second)) {
+				http.Error(w, fmt.Sprintf("source %q has no fetch in flight", source), 404)
+				return
+			}
+			writeJSON(w, map[string]any{"ok": true, "source": source, "seconds": seconds})
+		case "cancel":
+			if !scrape.CancelFetch(source) {
+				http.Error(w, fmt.Sprintf("source %q has no fetch in flight", source), 404)
+				return
+			}
+			writeJSON(w, map[string]any{"ok": true, "source": source})
+		default:
+			http.Error(w, fmt.Sprintf("unknown action %q", action), 404)
 		}
-		// Emit an SSE event so the UI refreshes instantly.
-		hub.publish(`{"type":"job_created","id":` + fmt.Sprint(job.ID) + `}`)
-		writeJSON(w, job)
 	})
-	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
-		// Server-Sent Events
+
+	jobs.RegisterRoutes(mux, db, sched)
+
+	mux.HandleFunc("/logs/tail", func(w http.ResponseWriter, r *http.Request) {
+		// Server-Sent Events: recent log lines, then every new one as it's written.
 		w.Header().Set("Content-Type", "text/event-stream")
 		w.Header().Set("Cache-Control", "no-cache")
 		w.Header().Set("Connection", "keep-alive")
-		w.Header().Set("Access-Control-Allow-Origin", "*") // safe for localhost UI
+		w.Header().Set("Access-Control-Allow-Origin", "*")
 
 		flusher, ok := w.(http.Flusher)
 		if !ok {
@@ -327,19 +427,20 @@ func main() {
 			return
 		}
 
-		ch := hub.subscribe()
-		defer hub.unsubscribe(ch)
-
-		// initial ping
-		fmt.Fprintf(w, "event: ping\ndata: %s\n\n", `{"type":"ping"}`)
+		for _, line := range applog.Tail() {
+			fmt.Fprintf(w, "event: line\ndata: %s\n\n", line)
+		}
 		flusher.Flush()
 
+		ch := make(chan string, 100)
+		defer applog.Subscribe(ch)()
+
 		for {
 			select {
 			case <-r.Context().Done():
 				return
-			case msg := <-ch:
-				fmt.Fprintf(w, "event: message\ndata: %s\n\n", msg)
+			case line := <-ch:
+				fmt.Fprintf(w, "event: line\ndata: %s\n\n", line)
 				flusher.Flush()
 			}
 		}
@@ -349,207 +450,210 @@ func main() {
 	addr := "127.0.0.1:38471"
 	ln, err := net.Listen("tcp", addr)
 	if err != nil {
-		log.Fatal(err)
+		applog.Fatal("listen failed", "addr", addr, "error", err)
 	}
-	log.Printf("engine listening on http://%s (db=%s)", addr, dbPath)
 
-	srv := &http.Server{
-		Handler:           cors(mux),
-		ReadHeaderTimeout: 5 * time.Second,
+	srv := &http.Server{ReadHeaderTimeout: 5 * time.Second}
+
+	shutdownToken, err := randomToken(32)
+	if err != nil {
+		applog.Fatal("generate shutdown token failed", "error", err)
 	}
-	log.Fatal(srv.Serve(ln))
+	mux.HandleFunc("/shutdown", httpapi.ShutdownHandler(&shutdownToken, srv, lc, db))
+
+	// Build the same middleware chain httpapi.NewHandler wraps NewMux
+	// with - can't use NewHandler directly since /shutdown (needs srv
+	// itself) and the routes above have to be added to the mux first.
+	srv.Handler = httpapi.Chain(mux,
+		httpapi.AccessLog,
+		httpapi.Compress,
+		httpapi.Recover,
+		httpapi.RequestID,
+		httpapi.Cors,
+		httpapi.RequireAuth(&cfgVal),
+	)
+
+	applog.Info("engine listening", "addr", "http://"+addr, "db", dbPath, "shutdown_token", shutdownToken)
+	applog.Fatal("server stopped", "error", srv.Serve(ln))
 }
 
-func cors(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Tauri fetch requests come from "tauri://localhost" origin.
-		origin := r.Header.Get("Origin")
-		if origin != "" {
-			w.Header().Set("Access-Control-Allow-Origin", origin)
-			w.Header().Set("Vary", "Origin")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
-			w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,PATCH,DELETE,OPTIONS")
-		}
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(204)
-			return
+// registerATSSources builds and registers every ATS board scraper
+// enabled in cfg.Sources so RunScrapeOnce picks them up alongside the
+// email inbox scan, plus any board scrape.DiscoverATS has found for a
+// company whose slug isn't in config yet (see discovered_ats). Sources
+// are registered once at startup; restart the engine to pick up
+// newly-enabled boards or newly-discovered ones.
+func registerATSSources(ctx context.Context, db *sql.DB, cfg config.Config, dataDir string) {
+	discovered, err := store.ListDiscoveredATS(ctx, db)
+	if err != nil {
+		applog.Warn("list discovered ATS boards failed", "error", err)
+	}
+	discoveredByType := func(atsType string) []store.DiscoveredATS {
+		var out []store.DiscoveredATS
+		for _, d := range discovered {
+			if d.ATSType == atsType {
+				out = append(out, d)
+			}
 		}
-		next.ServeHTTP(w, r)
-	})
-}
+		return out
+	}
 
-func migrate(db *sql.DB) error {
-	if _, err := db.Exec(`
-CREATE TABLE IF NOT EXISTS jobs (
-  id INTEGER PRIMARY KEY AUTOINCREMENT,
-  company TEXT NOT NULL,
-  title TEXT NOT NULL,
-  location TEXT NOT NULL,
-  work_mode TEXT NOT NULL,
-  url TEXT NOT NULL,
-  score INTEGER NOT NULL DEFAULT 0,
-  tags TEXT NOT NULL DEFAULT '[]',
-  first_seen TEXT NOT NULL
-);`); err != nil {
-		return err
-	}
-
-	{
-		var has bool
-		rows, err := db.Query(`PRAGMA table_info(jobs);`)
-		if err != nil {
-			return err
+	if cfg.Sources.Greenhouse.Enabled {
+		companies := make([]greenhouse.Company, 0, len(cfg.Sources.Greenhouse.Companies))
+		seenSlug := map[string]bool{}
+		for _, c := range cfg.Sources.Greenhouse.Companies {
+			companies = append(companies, greenhouse.Company{Slug: c.Slug, Name: c.Name})
+			seenSlug[c.Slug] = true
 		}
-		defer rows.Close()
-
-		for rows.Next() {
-			var cid int
-			var name, typ string
-			var notnull, pk int
-			var dflt sql.NullString
-			if err := rows.Scan(&cid, &name, &typ, &notnull, &dflt, &pk); err != nil {
-				return err
+		for _, d := range discoveredByType("greenhouse") {
+			if !seenSlug[d.Slug] {
+				companies = append(companies, greenhouse.Company{Slug: d.Slug, Name: d.Company})
+				seenSlug[d.Slug] = true
 			}
-			if name == "source_id" {
-				has = true
-				break
+		}
+		if len(companies) > 0 {
+			scrape.Register(greenhouse.New(greenhouse.Config{Companies: companies}))
+		}
+	}
+	// Lever isn't registered here: lever.Scraper.Fetch returns
+	// types.ScrapeResult, not the []domain.JobLead scrape.Source wants,
+	// so it's only wired through the newer types.Fetcher registry (see
+	// fetchers_register.go's init) rather than this old
+	// scrape.Register/RunScrapeOnce path.
+	if cfg.Sources.Ashby.Enabled {
+		companies := make([]ashby.Company, 0, len(cfg.Sources.Ashby.Companies))
+		seenSlug := map[string]bool{}
+		for _, c := range cfg.Sources.Ashby.Companies {
+			companies = append(companies, ashby.Company{Slug: c.Slug, Name: c.Name})
+			seenSlug[c.Slug] = true
+		}
+		for _, d := range discoveredByType("ashby") {
+			if !seenSlug[d.Slug] {
+				companies = append(companies, ashby.Company{Slug: d.Slug, Name: d.Company})
+				seenSlug[d.Slug] = true
 			}
 		}
-		if err := rows.Err(); err != nil {
-			return err
+		if len(companies) > 0 {
+			scrape.Register(ashby.New(ashby.Config{Companies: companies}))
 		}
-
-		if !has {
-			if _, err := db.Exec(`ALTER TABLE jobs ADD COLUMN source_id TEXT NOT NULL DEFAULT '';`); err != nil {
-				return err
+	}
+	if cfg.Sources.SmartRecruiters.Enabled {
+		companies := make([]smartrecruiters.Company, 0, len(cfg.Sources.SmartRecruiters.Companies))
+		seenSlug := map[string]bool{}
+		for _, c := range cfg.Sources.SmartRecruiters.Companies {
+			companies = append(companies, smartrecruiters.Company{Slug: c.Slug, Name: c.Name})
+			seenSlug[c.Slug] = true
+		}
+		for _, d := range discoveredByType("smartrecruiters") {
+			if !seenSlug[d.Slug] {
+				companies = append(companies, smartrecruiters.Company{Slug: d.Slug, Name: d.Company})
+				seenSlug[d.Slug] = true
 			}
 		}
+		if len(companies) > 0 {
+			scrape.Register(smartrecruiters.New(smartrecruiters.Config{Companies: companies}, nil, db))
+		}
 	}
-
-	if _, err := db.Exec(`
-CREATE UNIQUE INDEX IF NOT EXISTS idx_jobs_source_id
-ON jobs(source_id)
-WHERE source_id != '';
-`); err != nil {
-		return err
+	if cfg.Sources.Workday.Enabled {
+		companies := make([]workday.Company, 0, len(cfg.Sources.Workday.Companies))
+		seenSlug := map[string]bool{}
+		for _, c := range cfg.Sources.Workday.Companies {
+			companies = append(companies, workday.Company{Slug: c.Slug, Name: c.Name})
+			seenSlug[c.Slug] = true
+		}
+		for _, d := range discoveredByType("workday") {
+			if !seenSlug[d.Slug] {
+				companies = append(companies, workday.Company{Slug: d.Slug, Name: d.Company})
+				seenSlug[d.Slug] = true
+			}
+		}
+		if len(companies) > 0 {
+			scrape.Register(workday.New(workday.Config{
+				Companies:               companies,
+				StateDir:                filepath.Join(dataDir, "workday"),
+				Proxies:                 cfg.Sources.Workday.Proxies,
+				ChallengeSolverEndpoint: cfg.Sources.Workday.ChallengeSolverEndpoint,
+			}, nil))
+		}
 	}
-
-	return nil
 }
 
-func listJobs(ctx context.Context, db *sql.DB) ([]Job, error) {
-	rows, err := db.QueryContext(ctx, `
-SELECT id, company, title, location, work_mode, url, score, tags, first_seen
-FROM jobs
-ORDER BY first_seen DESC
-LIMIT 200;`)
-	if err != nil {
-		return nil, err
+// atsConnectorLimiter rate-limits the internal/ingest/ats connectors
+// registered below; separate from scrape.Limiter since these hit each
+// vendor's JSON API host (boards-api.greenhouse.io, api.ashbyhq.com,
+// ...), not the HTML board hosts scrape's own fetchers poll.
+var atsConnectorLimiter = util.NewHostLimiter(1.0, 2)
+
+// registerATSConnectors registers the internal/ingest/ats.Connector for
+// each enabled source, gated by the same cfg.Sources.*.Enabled flags as
+// registerATSSources, so the "ats-health" and "ats-ingest" Spec jobs
+// (see main) have a real, config-driven registry instead of an
+// always-empty one. This is a separate, JSON-API-based integration
+// from the HTML-scraping registerATSSources above - see ats.Connector's
+// doc comment - so registering a source here doesn't also register it
+// there, or vice versa.
+func registerATSConnectors(cfg config.Config, db *sql.DB) {
+	cache := store.ConnectorCache{DB: db}
+
+	if cfg.Sources.Greenhouse.Enabled {
+		ats.Register(atsgreenhouse.New(atsgreenhouse.Config{Limiter: atsConnectorLimiter, Cache: cache}))
 	}
-	defer rows.Close()
-
-	var out []Job
-	for rows.Next() {
-		var j Job
-		var tagsJSON string
-		var firstSeenStr string
-		if err := rows.Scan(&j.ID, &j.Company, &j.Title, &j.Location, &j.WorkMode, &j.URL, &j.Score, &tagsJSON, &firstSeenStr); err != nil {
-			return nil, err
-		}
-		_ = json.Unmarshal([]byte(tagsJSON), &j.Tags)
-		j.FirstSeen, _ = time.Parse(time.RFC3339, firstSeenStr)
-		out = append(out, j)
+	if cfg.Sources.Lever.Enabled {
+		ats.Register(atslever.New(atslever.Config{Limiter: atsConnectorLimiter, Cache: cache}))
 	}
-	return out, rows.Err()
-}
-
-func seedJob(ctx context.Context, db *sql.DB) (Job, error) {
-	j := Job{
-		Company:   "SeedCo",
-		Title:     "SRE / Platform Engineer (DFW or Remote)",
-		Location:  "Dallas-Fort Worth, TX",
-		WorkMode:  "remote",
-		URL:       "https://example.com/apply",
-		Score:     88,
-		Tags:      []string{"SRE", "Kubernetes", "Terraform", "AWS", "Go"},
-		FirstSeen: time.Now().UTC(),
-	}
-	tagsB, _ := json.Marshal(j.Tags)
-	res, err := db.ExecContext(ctx, `
-INSERT INTO jobs(company, title, location, work_mode, url, score, tags, first_seen)
-VALUES(?,?,?,?,?,?,?,?);`,
-		j.Company, j.Title, j.Location, j.WorkMode, j.URL, j.Score, string(tagsB), j.FirstSeen.Format(time.RFC3339))
-	if err != nil {
-		return Job{}, err
+	if cfg.Sources.Ashby.Enabled {
+		ats.Register(atsashby.New(atsashby.Config{Limiter: atsConnectorLimiter, Cache: cache}))
+	}
+	if cfg.Sources.Workday.Enabled {
+		ats.Register(atsworkday.New(atsworkday.Config{Limiter: atsConnectorLimiter, Cache: cache}))
 	}
-	j.ID, _ = res.LastInsertId()
-	return j, nil
-}
-
-func writeJSON(w http.ResponseWriter, v any) {
-	w.Header().Set("Content-Type", "application/json")
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-	_ = enc.Encode(v)
 }
 
-func deleteJob(ctx context.Context, db *sql.DB, id int64) error {
-	_, err := db.ExecContext(ctx, `DELETE FROM jobs WHERE id = ?;`, id)
-	return err
+// atsCareerURL builds the CareerURL an ats.Connector's ListJobs/
+// FetchJobDetail parses its board identity from. Greenhouse, Lever and
+// Ashby configs only store a bare board slug (ats.SlugFromCareerURL
+// pulls it back out of whatever URL we hand it), matching the HTML
+// scrapers' own Company.Slug convention; Workday's Slug is already a
+// full board URL (see internal/scrape/workday.Company's doc comment),
+// so it passes straight through.
+func atsCareerURL(atsType, slug string) string {
+	switch atsType {
+	case "greenhouse":
+		return "https://boards.greenhouse.io/" + slug
+	case "ashby":
+		return "https://jobs.ashbyhq.com/" + slug
+	case "lever":
+		return "https://jobs.lever.co/" + slug
+	default: // workday
+		return slug
+	}
 }
 
-func startEmailPoller(db *sql.DB, cfgVal *atomic.Value, scrapeStatus *atomic.Value, hub *eventHub) {
-	go func() {
-		// run forever; interval is read from cfg on each loop so config updates apply live
-		var lastTick time.Time
-
-		for {
-			cfg := cfgVal.Load().(config.Config)
-			sec := cfg.Polling.EmailSeconds
-			if sec <= 0 {
-				sec = 60
-			}
-
-			// sleep until next tick (dynamic interval)
-			if !lastTick.IsZero() {
-				time.Sleep(time.Duration(sec) * time.Second)
-			}
-			lastTick = time.Now()
-
-			if !cfg.Email.Enabled {
-				continue
-			}
-
-			// Prevent concurrent runs (shares the same status guard)
-			st := scrapeStatus.Load().(ScrapeStatus)
-			if st.Running {
-				continue
-			}
-
-			scrapeStatus.Store(ScrapeStatus{
-				LastRunAt: time.Now().Format(time.RFC3339),
-				Running:   true,
-				LastError: "",
-				LastAdded: 0,
-				LastOkAt:  st.LastOkAt,
-			})
-
-			added, err := scrape.RunEmailScrapeOnce(db, cfg, func() {
-				hub.publish(`{"type":"job_created"}`)
-			})
-			now := time.Now().Format(time.RFC3339)
+// atsCompaniesForType builds the []domain.Company the "ats-ingest" Spec
+// job hands to a registered Connector's ListJobs/FetchJobDetail, from
+// the same cfg.Sources.*.Companies list registerATSSources reads for
+// the HTML-scraping path.
+func atsCompaniesForType(cfg config.Config, atsType string) []domain.Company {
+	var cfgCompanies []config.Company
+	switch atsType {
+	case "greenhouse":
+		cfgCompanies = cfg.Sources.Greenhouse.Companies
+	case "lever":
+		cfgCompanies = cfg.Sources.Lever.Companies
+	case "ashby":
+		cfgCompanies = cfg.Sources.Ashby.Companies
+	case "workday":
+		cfgCompanies = cfg.Sources.Workday.Companies
+	}
 
-			next := scrapeStatus.Load().(ScrapeStatus)
-			next.Running = false
-			next.LastRunAt = now
-			next.LastAdded = added
-			if err != nil {
-				next.LastError = err.Error()
-			} else {
-				next.LastError = ""
-				next.LastOkAt = now
-			}
-			scrapeStatus.Store(next)
-		}
-	}()
+	out := make([]domain.Company, 0, len(cfgCompanies))
+	for _, c := range cfgCompanies {
+		out = append(out, domain.Company{
+			Name:      c.Name,
+			CareerURL: atsCareerURL(atsType, c.Slug),
+			ATSType:   atsType,
+			Active:    true,
+		})
+	}
+	return out
 }
@@ -0,0 +1,85 @@
+// Command jobhunt-lambda is the same job board pipeline as cmd/jobhunt,
+// exposed as an AWS Lambda behind an API Gateway HTTP API instead of a
+// CLI: GET /canonical?url=... and GET /search?q=...&source=... . The
+// in-memory page cache is enabled at init so a warm container reuses
+// pages fetched by a previous invocation instead of refetching them.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"jobhunt-engine/internal/jobboard"
+)
+
+func main() {
+	registerSources()
+	jobboard.EnableMemoryCache(0, jobboard.DefaultCacheTTL)
+
+	lambda.Start(handle)
+}
+
+func registerSources() {
+	jobboard.Register(jobboard.NewLinkedIn())
+	jobboard.Register(jobboard.NewIndeed("kr.indeed.com"))
+	jobboard.Register(jobboard.NewSaramin())
+	jobboard.Register(jobboard.NewITJobBank())
+	jobboard.Register(jobboard.NewTheHub())
+}
+
+func handle(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	switch req.Path {
+	case "/canonical":
+		return handleCanonical(req)
+	case "/search":
+		return handleSearch(ctx, req)
+	default:
+		return jsonResponse(404, map[string]string{"error": "not found"}), nil
+	}
+}
+
+func handleCanonical(req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	raw := req.QueryStringParameters["url"]
+	if raw == "" {
+		return jsonResponse(400, map[string]string{"error": "missing url"}), nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return jsonResponse(400, map[string]string{"error": "invalid url"}), nil
+	}
+	return jsonResponse(200, map[string]string{"canonical": jobboard.CanonicalizeURL(u)}), nil
+}
+
+func handleSearch(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	q := req.QueryStringParameters["q"]
+	if q == "" {
+		return jsonResponse(400, map[string]string{"error": "missing q"}), nil
+	}
+	source := req.QueryStringParameters["source"]
+	if source == "" {
+		source = "all"
+	}
+
+	leads, err := jobboard.Search(ctx, strings.Split(source, ","), q)
+	if err != nil {
+		return jsonResponse(502, map[string]string{"error": err.Error()}), nil
+	}
+	return jsonResponse(200, leads), nil
+}
+
+func jsonResponse(status int, body any) events.APIGatewayProxyResponse {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return events.APIGatewayProxyResponse{StatusCode: 500, Body: `{"error":"encode response"}`}
+	}
+	return events.APIGatewayProxyResponse{
+		StatusCode: status,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(b),
+	}
+}
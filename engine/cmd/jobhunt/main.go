@@ -0,0 +1,97 @@
+// Command jobhunt is a CLI companion to the engine server: one-off
+// queries against the job board scrapers in internal/jobboard without
+// running the full HTTP service.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"jobhunt-engine/internal/jobboard"
+)
+
+func main() {
+	registerSources()
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "search":
+		runSearch(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: jobhunt search [--source=all|name[,name...]] [--rewrites=path] [--cache=off|memory|disk] [--cache-ttl=5m] <query>")
+}
+
+func registerSources() {
+	jobboard.Register(jobboard.NewLinkedIn())
+	jobboard.Register(jobboard.NewIndeed("kr.indeed.com"))
+	jobboard.Register(jobboard.NewSaramin())
+	jobboard.Register(jobboard.NewITJobBank())
+	jobboard.Register(jobboard.NewTheHub())
+}
+
+func runSearch(args []string) {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	source := fs.String("source", "all", `comma-separated source names ("linkedin,indeed"), or "all"`)
+	rewrites := fs.String("rewrites", "", "path to a rewrites.conf of extra URL rewrite rules (see internal/rewrite); built-in defaults still apply")
+	cache := fs.String("cache", "memory", `fetched-page cache: "off", "memory" (default), or "disk" (persists under ~/.cache/jobhunt)`)
+	cacheTTL := fs.Duration("cache-ttl", jobboard.DefaultCacheTTL, "how long a cached page stays fresh")
+	fs.Parse(args)
+
+	if *rewrites != "" {
+		if err := jobboard.LoadRewritesFile(*rewrites); err != nil {
+			fmt.Fprintln(os.Stderr, "jobhunt search: load rewrites:", err)
+			os.Exit(1)
+		}
+	}
+
+	switch *cache {
+	case "off":
+	case "memory":
+		jobboard.EnableMemoryCache(0, *cacheTTL)
+	case "disk":
+		if err := jobboard.EnableDiskCache("", 0, *cacheTTL); err != nil {
+			fmt.Fprintln(os.Stderr, "jobhunt search: enable disk cache:", err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "jobhunt search: unknown --cache %q (want off, memory, or disk)\n", *cache)
+		os.Exit(2)
+	}
+
+	query := strings.Join(fs.Args(), " ")
+	if query == "" {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	leads, err := jobboard.Search(ctx, strings.Split(*source, ","), query)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "jobhunt search:", err)
+		os.Exit(1)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(leads); err != nil {
+		fmt.Fprintln(os.Stderr, "jobhunt search: encode results:", err)
+		os.Exit(1)
+	}
+}
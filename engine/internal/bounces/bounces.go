@@ -0,0 +1,95 @@
+// Package bounces tracks delivery failures for outbound job-related
+// email (applications and "watch this job" notifications) back to the
+// jobs row that bounced. It supports two ingestion paths: a generic
+// POST /webhooks/bounce (see httpapi.BounceHandler) and an optional
+// POP3 poller (see RunBouncePollOnce) against a dead-letter mailbox
+// that collects DSN bounce reports. Either path funnels through
+// Record, which also auto-blocks a company once its hard bounces cross
+// config.Filters.HardBounceBlockThreshold.
+package bounces
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"jobhunt-engine/internal/config"
+	"jobhunt-engine/internal/store"
+)
+
+// Bounce is one delivery-failure report, from either the webhook or
+// the POP3 DSN poller.
+type Bounce struct {
+	SourceID   string // jobs.source_id of the application/notification that bounced
+	Reason     string // free-text diagnostic, e.g. an SMTP status line
+	Type       string // "hard" or "soft"
+	ReceivedAt time.Time
+}
+
+// Validate checks the fields Record needs before inserting b.
+func (b Bounce) Validate() error {
+	if strings.TrimSpace(b.SourceID) == "" {
+		return fmt.Errorf("bounce: source_id is required")
+	}
+	switch b.Type {
+	case "hard", "soft":
+	default:
+		return fmt.Errorf("bounce: type must be \"hard\" or \"soft\", got %q", b.Type)
+	}
+	return nil
+}
+
+// ShouldBlock reports whether hardCount (the number of hard bounces
+// now on record for a company, including this one) has crossed
+// threshold. threshold <= 0 disables auto-blocking.
+func ShouldBlock(hardCount, threshold int) bool {
+	return threshold > 0 && hardCount >= threshold
+}
+
+// Record validates and inserts b. If b is a hard bounce whose job
+// resolves to a company not already in cfg.Filters.CompaniesBlock, and
+// that company's hard-bounce count now crosses
+// cfg.Filters.HardBounceBlockThreshold, Record returns that company
+// name so the caller can add it to CompaniesBlock and save the config;
+// otherwise blockCompany is "".
+func Record(ctx context.Context, db *sql.DB, cfg config.Config, b Bounce) (blockCompany string, err error) {
+	if err := b.Validate(); err != nil {
+		return "", err
+	}
+	if b.ReceivedAt.IsZero() {
+		b.ReceivedAt = time.Now().UTC()
+	}
+
+	if err := store.InsertBounce(ctx, db, store.Bounce{
+		SourceID:   b.SourceID,
+		Reason:     b.Reason,
+		Type:       b.Type,
+		ReceivedAt: b.ReceivedAt,
+	}); err != nil {
+		return "", err
+	}
+	if b.Type != "hard" {
+		return "", nil
+	}
+
+	company, err := store.CompanyForSourceID(ctx, db, b.SourceID)
+	if err != nil || company == "" {
+		return "", err
+	}
+	for _, blocked := range cfg.Filters.CompaniesBlock {
+		if strings.EqualFold(blocked, company) {
+			return "", nil
+		}
+	}
+
+	hardCount, err := store.CountHardBounces(ctx, db, company)
+	if err != nil {
+		return "", err
+	}
+	if !ShouldBlock(hardCount, cfg.Filters.HardBounceBlockThreshold) {
+		return "", nil
+	}
+	return company, nil
+}
@@ -0,0 +1,270 @@
+package bounces
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"jobhunt-engine/internal/config"
+	"jobhunt-engine/internal/secrets"
+)
+
+// defaultMaxMessages caps a poll when config.POP3Config.MaxMessages is
+// left at 0, mirroring jmap_scrape.defaultMaxMessages.
+const defaultMaxMessages = 50
+
+// pop3Conn is a minimal POP3 (RFC 1939) client: just enough to log in,
+// count, fetch and delete messages in a dead-letter mailbox. JobHunt
+// doesn't need UIDL, pipelining or APOP, so this isn't a
+// general-purpose POP3 library -- the same "hand-roll the simple
+// protocol instead of a dependency" call as the robots.txt parser in
+// internal/scrape.
+type pop3Conn struct {
+	*textproto.Conn
+}
+
+func dialPOP3(ctx context.Context, addr string, useTLS bool) (*pop3Conn, error) {
+	d := net.Dialer{Timeout: 15 * time.Second}
+	var conn net.Conn
+	var err error
+	if useTLS {
+		conn, err = tls.DialWithDialer(&d, "tcp", addr, &tls.Config{MinVersion: tls.VersionTLS12})
+	} else {
+		conn, err = d.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &pop3Conn{Conn: textproto.NewConn(conn)}
+	if _, err := c.readStatus(); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("pop3 greeting: %w", err)
+	}
+	return c, nil
+}
+
+// readStatus reads one +OK/-ERR status line and returns the text after it.
+func (c *pop3Conn) readStatus() (string, error) {
+	line, err := c.ReadLine()
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(line, "-ERR") {
+		return "", fmt.Errorf("%s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+	}
+	if !strings.HasPrefix(line, "+OK") {
+		return "", fmt.Errorf("unexpected response %q", line)
+	}
+	return strings.TrimSpace(strings.TrimPrefix(line, "+OK")), nil
+}
+
+func (c *pop3Conn) cmd(format string, args ...any) (string, error) {
+	if err := c.PrintfLine(format, args...); err != nil {
+		return "", err
+	}
+	return c.readStatus()
+}
+
+func (c *pop3Conn) login(user, pass string) error {
+	if _, err := c.cmd("USER %s", user); err != nil {
+		return err
+	}
+	_, err := c.cmd("PASS %s", pass)
+	return err
+}
+
+// stat returns the number of messages currently in the mailbox.
+func (c *pop3Conn) stat() (int, error) {
+	status, err := c.cmd("STAT")
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(status)
+	if len(fields) == 0 {
+		return 0, nil
+	}
+	n, convErr := strconv.Atoi(fields[0])
+	if convErr != nil {
+		return 0, fmt.Errorf("bad STAT response %q", status)
+	}
+	return n, nil
+}
+
+// retr downloads message n's full raw bytes (headers + body).
+func (c *pop3Conn) retr(n int) ([]byte, error) {
+	if _, err := c.cmd("RETR %d", n); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(c.DotReader())
+}
+
+func (c *pop3Conn) dele(n int) error {
+	_, err := c.cmd("DELE %d", n)
+	return err
+}
+
+func (c *pop3Conn) quit() {
+	_, _ = c.cmd("QUIT")
+	_ = c.Close()
+}
+
+// RunBouncePollOnce connects to cfg.Email.BounceMailbox over POP3,
+// downloads every message (up to BounceMailbox.MaxMessages), records a
+// bounces row for each one that parses as a DSN (RFC 3464)
+// multipart/report carrying an X-Job-Source-Id header, and deletes it.
+// Messages that aren't DSNs, or that don't carry a recognizable job
+// source, are left in the mailbox for a human to triage rather than
+// silently discarded. Returns the number of bounces recorded and, if
+// one of them pushed a company over
+// cfg.Filters.HardBounceBlockThreshold, that company's name so the
+// caller can persist it to cfg.Filters.CompaniesBlock.
+func RunBouncePollOnce(db *sql.DB, cfg config.Config) (processed int, blockCompany string, err error) {
+	pcfg := cfg.Email.BounceMailbox
+	if pcfg.Host == "" {
+		return 0, "", nil
+	}
+	if db == nil {
+		return 0, "", fmt.Errorf("bounces: db is nil")
+	}
+
+	password, err := secrets.Resolve(cfg)
+	if err != nil {
+		return 0, "", fmt.Errorf("resolve bounce mailbox password: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	addr := fmt.Sprintf("%s:%d", pcfg.Host, pcfg.Port)
+	conn, err := dialPOP3(ctx, addr, pcfg.Port == 995)
+	if err != nil {
+		return 0, "", fmt.Errorf("pop3 dial: %w", err)
+	}
+	defer conn.quit()
+
+	if err := conn.login(pcfg.Username, password); err != nil {
+		return 0, "", fmt.Errorf("pop3 login: %w", err)
+	}
+
+	count, err := conn.stat()
+	if err != nil {
+		return 0, "", fmt.Errorf("pop3 stat: %w", err)
+	}
+
+	limit := pcfg.MaxMessages
+	if limit <= 0 {
+		limit = defaultMaxMessages
+	}
+	if count < limit {
+		limit = count
+	}
+
+	now := time.Now().UTC()
+	for i := 1; i <= limit; i++ {
+		raw, err := conn.retr(i)
+		if err != nil {
+			return processed, blockCompany, fmt.Errorf("pop3 retr %d: %w", i, err)
+		}
+
+		bounceType, sourceID, reason, perr := parseDSN(raw)
+		if perr != nil || sourceID == "" {
+			continue
+		}
+
+		company, rerr := Record(ctx, db, cfg, Bounce{
+			SourceID:   sourceID,
+			Reason:     reason,
+			Type:       bounceType,
+			ReceivedAt: now,
+		})
+		if rerr != nil {
+			return processed, blockCompany, fmt.Errorf("record bounce: %w", rerr)
+		}
+		processed++
+		if company != "" {
+			blockCompany = company
+		}
+
+		if err := conn.dele(i); err != nil {
+			return processed, blockCompany, fmt.Errorf("pop3 dele %d: %w", i, err)
+		}
+	}
+
+	return processed, blockCompany, nil
+}
+
+// parseDSN extracts what Record needs from a DSN (RFC 3464)
+// multipart/report message: the per-recipient Action field (mapped to
+// "hard" for "failed", "soft" for anything else), the
+// X-Job-Source-Id header JobHunt stamps on its own outgoing mail (read
+// back from the original message/headers part), and a short diagnostic
+// string for Bounce.Reason. Returns an error if raw isn't a
+// multipart/report at all.
+func parseDSN(raw []byte) (bounceType, sourceID, reason string, err error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return "", "", "", fmt.Errorf("parse message: %w", err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || !strings.EqualFold(mediaType, "multipart/report") {
+		return "", "", "", fmt.Errorf("not a multipart/report DSN")
+	}
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, perr := mr.NextPart()
+		if perr == io.EOF {
+			break
+		}
+		if perr != nil {
+			return "", "", "", fmt.Errorf("read dsn part: %w", perr)
+		}
+
+		body, rerr := io.ReadAll(part)
+		if rerr != nil {
+			return "", "", "", fmt.Errorf("read dsn part body: %w", rerr)
+		}
+
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch strings.ToLower(partType) {
+		case "message/delivery-status":
+			fields, _ := textproto.NewReader(bufio.NewReader(bytes.NewReader(body))).ReadMIMEHeader()
+			if strings.EqualFold(fields.Get("Action"), "failed") {
+				bounceType = "hard"
+			} else {
+				bounceType = "soft"
+			}
+			if d := fields.Get("Diagnostic-Code"); d != "" {
+				reason = d
+			} else {
+				reason = fields.Get("Status")
+			}
+		case "message/rfc822-headers", "text/rfc822-headers":
+			hdr, _ := textproto.NewReader(bufio.NewReader(bytes.NewReader(body))).ReadMIMEHeader()
+			sourceID = hdr.Get("X-Job-Source-Id")
+		case "message/rfc822":
+			if sub, serr := mail.ReadMessage(bytes.NewReader(body)); serr == nil {
+				sourceID = sub.Header.Get("X-Job-Source-Id")
+			}
+		}
+	}
+
+	if bounceType == "" {
+		bounceType = "soft"
+	}
+	return bounceType, sourceID, reason, nil
+}
@@ -0,0 +1,256 @@
+// Package classify is a small Bayesian good/junk classifier for
+// scraped job leads, modeled on the token-bucket approach simple mail
+// classifiers use (e.g. DSPAM/bogofilter): every doc is reduced to a
+// bag of tokens, and each token nudges a running log-odds of
+// "interested" vs. "discarded" based on how often it's shown up in
+// each bucket historically. It exists to replace the hard-coded
+// denySubstrings/allowHints lists in the email scraper with something
+// that learns from Train feedback instead of needing a person to keep
+// editing string lists.
+package classify
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"net/url"
+	"strings"
+)
+
+// Bucket names a training bucket a doc's tokens are counted into.
+type Bucket string
+
+const (
+	Interested Bucket = "interested"
+	Discarded  Bucket = "discarded"
+)
+
+// Doc is the subset of a candidate job lead the classifier looks at.
+// Context is the anchor text captured around the link in the source
+// email (see extractLinksFromBody) and is only available at scrape
+// time; feedback trained later from a stored job row will have an
+// empty Context.
+type Doc struct {
+	Title    string
+	Company  string
+	Location string
+	URL      string
+	Context  string
+}
+
+// Migrate creates the classify_tokens/classify_docs tables if they
+// don't already exist. Safe to call on every startup.
+func Migrate(db *sql.DB) error {
+	if _, err := db.Exec(`
+CREATE TABLE IF NOT EXISTS classify_tokens (
+  bucket TEXT NOT NULL,
+  token TEXT NOT NULL,
+  count INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY (bucket, token)
+);`); err != nil {
+		return err
+	}
+	if _, err := db.Exec(`
+CREATE TABLE IF NOT EXISTS classify_docs (
+  bucket TEXT PRIMARY KEY,
+  count INTEGER NOT NULL DEFAULT 0
+);`); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Train increments the token and document counts for bucket from
+// doc's tokens. Called once per user decision (interested/discard),
+// either from live feedback (POST /jobs/{id}/feedback) or from a
+// one-time backfill of historical labels.
+func Train(ctx context.Context, db *sql.DB, doc Doc, bucket Bucket) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `
+INSERT INTO classify_docs(bucket, count) VALUES(?, 1)
+ON CONFLICT(bucket) DO UPDATE SET count = count + 1;`, string(bucket)); err != nil {
+		return err
+	}
+
+	for _, tok := range Tokens(doc) {
+		if _, err := tx.ExecContext(ctx, `
+INSERT INTO classify_tokens(bucket, token, count) VALUES(?, ?, 1)
+ON CONFLICT(bucket, token) DO UPDATE SET count = count + 1;`, string(bucket), tok); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Score returns doc's relevance as a 0-100 probability that it belongs
+// in the Interested bucket, using a Naive Bayes classifier over
+// Tokens(doc) with Laplace smoothing. Buckets with no training data
+// yet score every doc 50 (no opinion), so an untrained classifier
+// never contradicts the caller's other filters.
+func Score(ctx context.Context, db *sql.DB, doc Doc) (int, error) {
+	docCounts, err := bucketDocCounts(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	totalDocs := docCounts[Interested] + docCounts[Discarded]
+	if totalDocs == 0 {
+		return 50, nil
+	}
+
+	vocab, err := vocabSize(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+
+	tokens := Tokens(doc)
+	logOdds, err := logOddsInterested(ctx, db, tokens, docCounts, totalDocs, vocab)
+	if err != nil {
+		return 0, err
+	}
+
+	// logistic squash of the log-odds into a 0-100 score
+	p := 1 / (1 + math.Exp(-logOdds))
+	score := int(math.Round(p * 100))
+	if score < 0 {
+		score = 0
+	}
+	if score > 100 {
+		score = 100
+	}
+	return score, nil
+}
+
+func logOddsInterested(ctx context.Context, db *sql.DB, tokens []string, docCounts map[Bucket]int, totalDocs, vocab int) (float64, error) {
+	priorInterested := float64(docCounts[Interested]) / float64(totalDocs)
+	priorDiscarded := float64(docCounts[Discarded]) / float64(totalDocs)
+	// avoid log(0) when one bucket has no training yet
+	priorInterested = clampProb(priorInterested)
+	priorDiscarded = clampProb(priorDiscarded)
+
+	logOdds := math.Log(priorInterested) - math.Log(priorDiscarded)
+
+	sumInterested, err := tokenCountSum(ctx, db, Interested)
+	if err != nil {
+		return 0, err
+	}
+	sumDiscarded, err := tokenCountSum(ctx, db, Discarded)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, tok := range tokens {
+		cInterested, err := tokenCount(ctx, db, Interested, tok)
+		if err != nil {
+			return 0, err
+		}
+		cDiscarded, err := tokenCount(ctx, db, Discarded, tok)
+		if err != nil {
+			return 0, err
+		}
+
+		pInterested := float64(cInterested+1) / float64(sumInterested+vocab)
+		pDiscarded := float64(cDiscarded+1) / float64(sumDiscarded+vocab)
+
+		logOdds += math.Log(pInterested) - math.Log(pDiscarded)
+	}
+
+	return logOdds, nil
+}
+
+func clampProb(p float64) float64 {
+	const epsilon = 1e-6
+	if p < epsilon {
+		return epsilon
+	}
+	if p > 1-epsilon {
+		return 1 - epsilon
+	}
+	return p
+}
+
+func bucketDocCounts(ctx context.Context, db *sql.DB) (map[Bucket]int, error) {
+	rows, err := db.QueryContext(ctx, `SELECT bucket, count FROM classify_docs;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[Bucket]int{}
+	for rows.Next() {
+		var bucket string
+		var count int
+		if err := rows.Scan(&bucket, &count); err != nil {
+			return nil, err
+		}
+		out[Bucket(bucket)] = count
+	}
+	return out, rows.Err()
+}
+
+func tokenCountSum(ctx context.Context, db *sql.DB, bucket Bucket) (int, error) {
+	var sum sql.NullInt64
+	if err := db.QueryRowContext(ctx, `SELECT SUM(count) FROM classify_tokens WHERE bucket = ?;`, string(bucket)).Scan(&sum); err != nil {
+		return 0, err
+	}
+	return int(sum.Int64), nil
+}
+
+func tokenCount(ctx context.Context, db *sql.DB, bucket Bucket, token string) (int, error) {
+	var count int
+	err := db.QueryRowContext(ctx, `SELECT count FROM classify_tokens WHERE bucket = ? AND token = ?;`, string(bucket), token).Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func vocabSize(ctx context.Context, db *sql.DB) (int, error) {
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(DISTINCT token) FROM classify_tokens;`).Scan(&n); err != nil {
+		return 0, err
+	}
+	// at least 1 so Laplace smoothing never divides by zero vocab
+	if n == 0 {
+		n = 1
+	}
+	return n, nil
+}
+
+// Tokens reduces doc to a bag of lowercased word 1-grams and 2-grams
+// drawn from its title, company, location, URL host/path, and link
+// context text.
+func Tokens(doc Doc) []string {
+	var words []string
+	words = append(words, splitWords(doc.Title)...)
+	words = append(words, splitWords(doc.Company)...)
+	words = append(words, splitWords(doc.Location)...)
+	words = append(words, splitWords(doc.Context)...)
+
+	if u, err := url.Parse(doc.URL); err == nil {
+		words = append(words, splitWords(u.Host)...)
+		words = append(words, splitWords(u.Path)...)
+	}
+
+	tokens := make([]string, 0, len(words)*2)
+	tokens = append(tokens, words...)
+	for i := 0; i+1 < len(words); i++ {
+		tokens = append(tokens, words[i]+"_"+words[i+1])
+	}
+	return tokens
+}
+
+func splitWords(s string) []string {
+	s = strings.ToLower(s)
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+}
@@ -0,0 +1,56 @@
+// Package compare holds small ordering helpers for sort.Slice-style
+// comparators - a couple of typed leaf comparators plus a composite
+// chainer, so callers building a multi-key sort (score, then date,
+// then name, ...) don't have to write the same "first non-zero field
+// wins" boilerplate by hand.
+package compare
+
+// Int, Float and String return -1/0/1 the way a comparator is
+// expected to: negative when a sorts before b, positive when it sorts
+// after, 0 when they're equal.
+
+func Int(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func Float(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func String(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// By chains comparators into one: the first that returns non-zero
+// decides the order, falling through to the next when it's a tie.
+func By[T any](cmps ...func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		for _, c := range cmps {
+			if r := c(a, b); r != 0 {
+				return r
+			}
+		}
+		return 0
+	}
+}
@@ -10,16 +10,60 @@ import (
 
 type Rule struct {
 	Tag    string   `yaml:"tag" json:"tag"`
-	Weight int      `yaml:"weight" json:"weight"`
+	Weight float64  `yaml:"weight" json:"weight"`
 	Any    []string `yaml:"any" json:"any"`
+
+	// Phrase is an optional exact phrase a lead's title+description must
+	// contain - unlike Any, which matches if any one needle appears
+	// anywhere, Phrase requires its tokens to appear together and in
+	// order, the same way strings.Contains already does for a multi-word
+	// needle.
+	Phrase string `yaml:"phrase" json:"phrase"`
+
+	// Regex is an optional pattern (regexp.Compile syntax) matched
+	// against the same title+description text. An invalid pattern is
+	// treated as a non-match rather than a config load error, so a typo
+	// here can't take every other rule down with it.
+	Regex string `yaml:"regex" json:"regex"`
 }
 
 type Penalty struct {
 	Reason string   `yaml:"reason" json:"reason"`
-	Weight int      `yaml:"weight" json:"weight"`
+	Weight float64  `yaml:"weight" json:"weight"`
 	Any    []string `yaml:"any" json:"any"`
 }
 
+// SavedQuery is one filters.saved_queries entry: a name plus an
+// internal/query search string. events.NewSavedQuerySubscriber
+// compiles Query once at startup and desktop-notifies for every newly
+// inserted job it matches.
+type SavedQuery struct {
+	Name  string `yaml:"name" json:"name"`
+	Query string `yaml:"query" json:"query"`
+}
+
+// PipelineWhen is one scoring.pipeline rule's trigger predicate; every
+// non-empty field must match. It mirrors internal/pipeline.When field
+// for field rather than reusing it directly, the same way SavedQuery
+// mirrors events.SavedQuery - keeps internal/pipeline free of a
+// dependency back on internal/config.
+type PipelineWhen struct {
+	SubjectRegex string `yaml:"subject_regex" json:"subject_regex"`
+	FromRegex    string `yaml:"from_regex" json:"from_regex"`
+	BodyRegex    string `yaml:"body_regex" json:"body_regex"`
+	Domain       string `yaml:"domain" json:"domain"`
+}
+
+// PipelineRule is one scoring.pipeline entry. Then holds raw action
+// strings ("set_company:Acme", "route_to:linkedin", "drop") parsed by
+// internal/pipeline.ParseAction.
+type PipelineRule struct {
+	Name string       `yaml:"name" json:"name"`
+	When PipelineWhen `yaml:"when" json:"when"`
+	Then []string     `yaml:"then" json:"then"`
+	Stop bool         `yaml:"stop" json:"stop"`
+}
+
 type Company struct {
 	Slug string `yaml:"slug" json:"slug"`
 	Name string `yaml:"name" json:"name"`
@@ -30,9 +74,175 @@ type SourceConfig struct {
 	Companies []Company `yaml:"companies" json:"companies"`
 }
 
+// WorkdaySourceConfig is SourceConfig plus the proxy list
+// workday.Scraper round-robins outbound requests through - Workday
+// tenants sit behind Cloudflare and are the only source in this
+// registry that currently needs one.
+type WorkdaySourceConfig struct {
+	Enabled   bool      `yaml:"enabled" json:"enabled"`
+	Companies []Company `yaml:"companies" json:"companies"`
+
+	// Proxies is an optional list of outbound proxy URLs
+	// (http://, https://, socks5://) workday.Config.Proxies
+	// round-robins requests through.
+	Proxies []string `yaml:"proxies" json:"proxies"`
+
+	// ChallengeSolverEndpoint, if set, is a FlareSolverr /v1 endpoint
+	// (e.g. "http://flaresolverr:8191/v1") workday.Config hands
+	// Cloudflare-challenge pages to instead of giving up on them.
+	ChallengeSolverEndpoint string `yaml:"challenge_solver_endpoint" json:"challenge_solver_endpoint"`
+}
+
 type Sources struct {
-	Greenhouse SourceConfig `yaml:"greenhouse" json:"greenhouse"`
-	Lever      SourceConfig `yaml:"lever" json:"lever"`
+	Greenhouse      SourceConfig         `yaml:"greenhouse" json:"greenhouse"`
+	Lever           SourceConfig         `yaml:"lever" json:"lever"`
+	Ashby           SourceConfig         `yaml:"ashby" json:"ashby"`
+	SmartRecruiters SourceConfig         `yaml:"smartrecruiters" json:"smartrecruiters"`
+	Workday         WorkdaySourceConfig  `yaml:"workday" json:"workday"`
+	JMAP            JMAPSourceConfig     `yaml:"jmap" json:"jmap"`
+	WebCrawl        WebCrawlSourceConfig `yaml:"webcrawl" json:"webcrawl"`
+
+	// URLScope lists site-specific overrides for scrape.ClassifyURL, so
+	// a user whose ATS/careers site doesn't fit the built-in heuristics
+	// can fix misclassified links without recompiling. Checked in the
+	// order given, first match wins; falls back to the heuristic
+	// classifier when nothing matches.
+	URLScope URLScopeConfig `yaml:"url_scope" json:"url_scope"`
+}
+
+// URLScopeConfig is Sources.URLScope: a flat allow/deny pattern list
+// consulted by scrape.ClassifyURL ahead of its built-in scoring.
+type URLScopeConfig struct {
+	Rules []URLScopeRule `yaml:"rules" json:"rules"`
+}
+
+// URLScopeRule overrides scrape.ClassifyURL's verdict for any URL
+// containing Pattern (case-insensitive substring match, same style as
+// the package's existing scoreURL/isObviousJunkURL heuristics). Tag
+// must be one of "primary", "related" or "ignore".
+type URLScopeRule struct {
+	Pattern string `yaml:"pattern" json:"pattern"`
+	Tag     string `yaml:"tag" json:"tag"`
+}
+
+// WebCrawlSourceConfig configures internal/scrape/webcrawl's BFS
+// career-page crawler, for companies whose postings live on a custom
+// site rather than a known ATS board.
+type WebCrawlSourceConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// Seeds are the career-page URLs to start each crawl from, one
+	// per company (Name is used for domain.JobLead.CompanyName).
+	Seeds []WebCrawlSeed `yaml:"seeds" json:"seeds"`
+
+	// MaxDepth bounds how many link hops a PRIMARY-scoped page may be
+	// followed through from its seed. <= 0 uses the package default.
+	MaxDepth int `yaml:"max_depth" json:"max_depth"`
+
+	// Concurrency caps how many crawl workers run at once across all
+	// seeds. <= 0 uses the package default.
+	Concurrency int `yaml:"concurrency" json:"concurrency"`
+
+	// PathAllow lists path substrings (e.g. "/careers", "/jobs") a
+	// same-host link must contain to be scored PRIMARY instead of
+	// RELATED/IGNORE. Empty uses the package default list.
+	PathAllow []string `yaml:"path_allow" json:"path_allow"`
+}
+
+// WebCrawlSeed is one company's career-page crawl root.
+type WebCrawlSeed struct {
+	Name string `yaml:"name" json:"name"`
+	URL  string `yaml:"url" json:"url"`
+}
+
+// JMAPSourceConfig configures a company job feed read over JMAP
+// (RFC 8620/8621) instead of IMAP, for providers like Fastmail/
+// Stalwart where app passwords are going away. It authenticates with a
+// bearer token resolved the same way as the "jmap" email backend (see
+// JMAPConfig), so cfg.Email.SecretBackend/SecretFile/etc apply here too.
+type JMAPSourceConfig struct {
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// SessionEndpoint is the JMAP session resource URL, e.g.
+	// https://api.fastmail.com/jmap/session. Also doubles as the key
+	// under which the last-seen Email/changes state is persisted, so
+	// don't alias two feeds to one endpoint.
+	SessionEndpoint string `yaml:"session_endpoint" json:"session_endpoint"`
+
+	// Mailbox is the JMAP mailbox name or role to poll, e.g. "inbox"
+	// or "Job Alerts". Defaults to "inbox".
+	Mailbox string `yaml:"mailbox" json:"mailbox"`
+
+	// SubjectAny mirrors Email.SearchSubjectAny: matching messages
+	// need only satisfy one of these subject substrings.
+	SubjectAny []string `yaml:"subject_any" json:"subject_any"`
+
+	// MaxMessages caps how many messages a single Email/query (the
+	// first run, before any state is stored) fetches. 0 uses the
+	// package default.
+	MaxMessages int `yaml:"max_messages" json:"max_messages"`
+}
+
+// EmailMailbox configures one IMAP mailbox/label to poll and the
+// search to run once it's selected. Configuring cfg.Email.Mailboxes
+// unlocks per-label workflows a single cfg.Email.Mailbox can't
+// express, e.g. a Gmail "Job Alerts" label filtered by sender, or a
+// "Starred" folder scanned without the unseen-only constraint.
+type EmailMailbox struct {
+	Name string `yaml:"name" json:"name"`
+
+	// Unseen restricts the search to messages without \Seen. Set to
+	// false to also revisit messages already read, e.g. a starred
+	// folder that's meant to be scanned repeatedly.
+	Unseen bool `yaml:"unseen" json:"unseen"`
+
+	From         []string `yaml:"from" json:"from"`
+	To           []string `yaml:"to" json:"to"`
+	SubjectAny   []string `yaml:"subject_any" json:"subject_any"`
+	SubjectAll   []string `yaml:"subject_all" json:"subject_all"`
+	BodyContains []string `yaml:"body_contains" json:"body_contains"`
+
+	// SinceDays bounds the search to messages received in the last N
+	// days. 0 means no lower bound.
+	SinceDays int `yaml:"since_days" json:"since_days"`
+
+	// WithFlags/WithoutFlags are raw IMAP flag names (e.g. "\\Flagged"
+	// or a custom keyword) required/excluded on top of Unseen.
+	WithFlags    []string `yaml:"with_flags" json:"with_flags"`
+	WithoutFlags []string `yaml:"without_flags" json:"without_flags"`
+
+	// MaxMessages caps how many messages are fetched from this
+	// mailbox per run. 0 uses the package default.
+	MaxMessages int `yaml:"max_messages" json:"max_messages"`
+}
+
+// MaildirConfig points at a single Maildir (a directory containing
+// new/, cur/ and tmp/), used by the "maildir" email backend. A label
+// named in cfg.Email.Mailboxes resolves to Path + "." + label, the
+// Maildir++ subfolder convention.
+type MaildirConfig struct {
+	Path string `yaml:"path" json:"path"`
+}
+
+// JMAPConfig configures the "jmap" email backend. The access token is
+// resolved the same way as the IMAP password, via cfg.Email.SecretBackend.
+type JMAPConfig struct {
+	// SessionEndpoint is the JMAP session resource URL, e.g.
+	// https://api.fastmail.com/jmap/session.
+	SessionEndpoint string `yaml:"session_endpoint" json:"session_endpoint"`
+}
+
+// POP3Config points at a dead-letter mailbox that internal/bounces
+// polls over POP3 for bounce DSNs. The password is resolved the same
+// way as the IMAP password, via cfg.Email.SecretBackend.
+type POP3Config struct {
+	Host     string `yaml:"host" json:"host"`
+	Port     int    `yaml:"port" json:"port"`
+	Username string `yaml:"username" json:"username"`
+
+	// MaxMessages caps how many messages a single poll downloads and
+	// deletes. 0 uses the package default.
+	MaxMessages int `yaml:"max_messages" json:"max_messages"`
 }
 
 type CompaniesFile struct {
@@ -43,18 +253,60 @@ type Config struct {
 	App struct {
 		Port    int    `yaml:"port" json:"port"`
 		DataDir string `yaml:"data_dir" json:"data_dir"`
+
+		// ShutdownTimeoutSeconds bounds how long the /shutdown
+		// coordinator (see internal/lifecycle) waits for in-flight
+		// scrapes/poller/SSE clients to drain before it stops
+		// waiting and reports them as timed out. <= 0 means the
+		// lifecycle package's own default (5s) applies.
+		ShutdownTimeoutSeconds int `yaml:"shutdown_timeout_seconds" json:"shutdown_timeout_seconds"`
 	} `yaml:"app" json:"app"`
 
+	Logging struct {
+		Level  string `yaml:"level" json:"level"`   // debug|info|warn|error, default info
+		Format string `yaml:"format" json:"format"` // json|text, default json
+		File   string `yaml:"file" json:"file"`     // optional path; empty means stderr
+	} `yaml:"logging" json:"logging"`
+
 	Polling struct {
 		EmailSeconds      int `yaml:"email_seconds" json:"email_seconds"`
 		FastLaneSeconds   int `yaml:"fast_lane_seconds" json:"fast_lane_seconds"`
 		NormalLaneSeconds int `yaml:"normal_lane_seconds" json:"normal_lane_seconds"`
+
+		// BounceSeconds, when > 0, enables internal/bounces' POP3
+		// poller against Email.BounceMailbox at that interval. 0
+		// (default) means the webhook is the only ingestion path.
+		BounceSeconds int `yaml:"bounce_seconds" json:"bounce_seconds"`
 	} `yaml:"polling" json:"polling"`
 
 	Filters struct {
 		RemoteOK       bool     `yaml:"remote_ok" json:"remote_ok"`
 		LocationsAllow []string `yaml:"locations_allow" json:"locations_allow"`
 		LocationsBlock []string `yaml:"locations_block" json:"locations_block"`
+
+		// CompaniesBlock excludes a company from scoring/insertion
+		// entirely, the same way LocationsBlock excludes a location.
+		// internal/bounces appends to this automatically once a
+		// company crosses HardBounceBlockThreshold.
+		CompaniesBlock []string `yaml:"companies_block" json:"companies_block"`
+
+		// HardBounceBlockThreshold is how many hard bounces to the
+		// same company it takes before internal/bounces auto-adds it
+		// to CompaniesBlock. 0 disables auto-blocking.
+		HardBounceBlockThreshold int `yaml:"hard_bounce_block_threshold" json:"hard_bounce_block_threshold"`
+
+		// SavedQueries are named internal/query searches users
+		// subscribe to for a desktop notification on every matching
+		// new job, in place of the one-size-fits-all
+		// Scoring.NotifyMinScore gate.
+		SavedQueries []SavedQuery `yaml:"saved_queries" json:"saved_queries"`
+
+		// MinScore is the scrape.ScoreJob threshold a lead's weighted
+		// score must clear to be kept - replaces the old all-or-nothing
+		// matchesAnyRule gate. 0 (default) keeps anything that isn't
+		// hard-blocked, matching the pre-scoring behavior for existing
+		// configs that don't set it.
+		MinScore float64 `yaml:"min_score" json:"min_score"`
 	} `yaml:"filters" json:"filters"`
 
 	Scoring struct {
@@ -62,6 +314,20 @@ type Config struct {
 		TitleRules     []Rule    `yaml:"title_rules" json:"title_rules"`
 		KeywordRules   []Rule    `yaml:"keyword_rules" json:"keyword_rules"`
 		Penalties      []Penalty `yaml:"penalties" json:"penalties"`
+
+		// BayesWeight scales rank.BayesScorer's -100..100 learned-relevance
+		// delta before it's added to the YAML rule score. 0 (default)
+		// disables the blend entirely, so existing configs are unaffected
+		// until a user opts in.
+		BayesWeight float64 `yaml:"bayes_weight" json:"bayes_weight"`
+
+		// Pipeline is an ordered list of Sieve-style rules evaluated
+		// against each incoming email before the hardcoded LinkedIn
+		// special-case runs. Empty means the built-in
+		// pipeline.DefaultRules() LinkedIn detection is used instead, so
+		// existing configs keep routing LinkedIn mail the same way they
+		// always have.
+		Pipeline []PipelineRule `yaml:"pipeline" json:"pipeline"`
 	} `yaml:"scoring" json:"scoring"`
 
 	Email struct {
@@ -71,10 +337,149 @@ type Config struct {
 		Username         string   `yaml:"username" json:"username"`
 		Mailbox          string   `yaml:"mailbox" json:"mailbox"`
 		SearchSubjectAny []string `yaml:"search_subject_any" json:"search_subject_any"`
+
+		// Mailboxes, when non-empty, replaces the single Mailbox +
+		// SearchSubjectAny sweep above with one SEARCH per configured
+		// mailbox/label. See EmailMailbox.
+		Mailboxes []EmailMailbox `yaml:"mailboxes" json:"mailboxes"`
+
+		// Backend selects which email_scrape.MailSource implementation
+		// RunEmailScrapeOnce scans: "imap" (default, IMAPHost/Username/
+		// Mailbox(es) above), "maildir" (Maildir below) or "jmap"
+		// (JMAP below).
+		Backend string `yaml:"backend" json:"backend"`
+
+		// IdleEnabled starts an email_scrape.IdleWatcher against the
+		// imap backend (Backend must be "imap"/unset) that blocks on
+		// IMAP IDLE for Mailbox and triggers RunEmailScrapeOnce as soon
+		// as new mail arrives, instead of waiting for the next
+		// Polling.EmailSeconds tick.
+		IdleEnabled bool `yaml:"idle_enabled" json:"idle_enabled"`
+
+		// CacheDir overrides where the imap backend's persistent
+		// per-message cache (internal/scrape/email/cache) is stored,
+		// defaulting to ~/.cache/jobhunt/email-cache if empty. The
+		// cache lets Fetch skip re-downloading a message's body on
+		// the next run instead of relying solely on \Seen, which can
+		// silently fail to get set.
+		CacheDir string `yaml:"cache_dir" json:"cache_dir"`
+
+		// CacheMaxAgeHours bounds how long a cached message entry is
+		// kept before a background sweep evicts it (default 720 =
+		// 30 days, if <= 0). An evicted UID is simply re-downloaded
+		// on its next SEARCH match, so this only trades disk for a
+		// little redundant bandwidth.
+		CacheMaxAgeHours int `yaml:"cache_max_age_hours" json:"cache_max_age_hours"`
+
+		// ProcessedFolder, when set, moves a successfully-parsed
+		// message there instead of leaving it \Seen in place (imap
+		// backend only - see email_scrape.MoveProcessed). Empty
+		// disables the move, the same as before this existed.
+		ProcessedFolder string `yaml:"processed_folder" json:"processed_folder"`
+
+		// TrashFolder overrides the folder ExpungeOldSeen moves a
+		// message to on Gmail, which lacks \Deleted and instead
+		// expects X-GM-LABELS \Trash plus a move to its Trash
+		// mailbox. Defaults to "[Gmail]/Trash" if empty and Gmail is
+		// detected via CAPABILITY.
+		TrashFolder string `yaml:"trash_folder" json:"trash_folder"`
+
+		// DeleteAfterDays, if > 0, lets email_scrape.ExpungeOldSeen
+		// permanently remove \Seen messages older than this many days
+		// from ProcessedFolder (or Mailbox if ProcessedFolder is
+		// unset). 0 (default) disables cleanup entirely.
+		DeleteAfterDays int `yaml:"delete_after_days" json:"delete_after_days"`
+
+		// Maildir configures the "maildir" backend: a local mirror kept
+		// in sync by offlineimap/mbsync/isync.
+		Maildir MaildirConfig `yaml:"maildir" json:"maildir"`
+
+		// JMAP configures the "jmap" backend.
+		JMAP JMAPConfig `yaml:"jmap" json:"jmap"`
+
+		// BounceMailbox configures internal/bounces' optional POP3
+		// poller for a dead-letter mailbox collecting DSN bounces.
+		// Unrelated to Backend above: this is read in addition to
+		// whichever backend scans for job alerts.
+		BounceMailbox POP3Config `yaml:"bounce_mailbox" json:"bounce_mailbox"`
+
+		// SecretBackend selects where the IMAP app password is read
+		// from: "keyring" (default, OS keychain via zalando/go-keyring),
+		// "env", "file", "age" or "vault". See internal/secrets.
+		SecretBackend string `yaml:"secret_backend" json:"secret_backend"`
+
+		// SecretFile is the path to a mode-0600 file holding the
+		// password in plain text, used by the "file" backend.
+		SecretFile string `yaml:"secret_file" json:"secret_file"`
+
+		// AgeIdentityFile is the path to an age identity (private key)
+		// used to decrypt SecretFile, used by the "age" backend.
+		AgeIdentityFile string `yaml:"age_identity_file" json:"age_identity_file"`
+
+		// Vault* configure the "vault" backend: a KV v2 secret at
+		// VaultMount/data/VaultPath with the password under VaultField
+		// (default "password"). The token comes from $VAULT_TOKEN, or
+		// from an AppRole login if VaultRoleID/VaultSecretIDFile are set.
+		VaultAddr         string `yaml:"vault_addr" json:"vault_addr"`
+		VaultMount        string `yaml:"vault_mount" json:"vault_mount"`
+		VaultPath         string `yaml:"vault_path" json:"vault_path"`
+		VaultField        string `yaml:"vault_field" json:"vault_field"`
+		VaultRoleID       string `yaml:"vault_role_id" json:"vault_role_id"`
+		VaultSecretIDFile string `yaml:"vault_secret_id_file" json:"vault_secret_id_file"`
 	} `yaml:"email" json:"email"`
 
+	Classify struct {
+		// MinScore is the relevance score (0-100, see internal/classify)
+		// below which a candidate is considered low-relevance.
+		MinScore int `yaml:"min_score" json:"min_score"`
+
+		// DropBelowMin drops low-relevance candidates instead of
+		// inserting them with a low score. Off by default so a
+		// cold-start classifier (no training data yet, score 50)
+		// can't silently swallow every lead.
+		DropBelowMin bool `yaml:"drop_below_min" json:"drop_below_min"`
+	} `yaml:"classify" json:"classify"`
+
 	Sources     Sources `yaml:"sources" json:"sources"`
 	SourcesFile string  `yaml:"sources_file" json:"sources_file"`
+
+	Events struct {
+		// WebhookURL, when set, gets an HMAC-signed JSON POST for every
+		// internal/events.Event (JobInserted, JobScored, ScrapeRunCompleted).
+		WebhookURL    string `yaml:"webhook_url" json:"webhook_url"`
+		WebhookSecret string `yaml:"webhook_secret" json:"webhook_secret"`
+
+		// DesktopNotify pops a native OS notification for each JobInserted.
+		DesktopNotify bool `yaml:"desktop_notify" json:"desktop_notify"`
+	} `yaml:"events" json:"events"`
+
+	// Maintenance holds retention windows for the background jobs
+	// registered alongside "email"/"backup" on the same jobs.Scheduler
+	// (see cmd/engine/main.go) - cleanup of old jobs rows, revalidation
+	// of stale cached logos, and re-resolution of stale company
+	// domains. Each *Days field <= 0 falls back to that job's own
+	// store-level default (store.DefaultJobsRetentionDays, etc), so an
+	// existing config with no maintenance section keeps today's
+	// behavior.
+	Maintenance struct {
+		JobsRetentionDays int `yaml:"jobs_retention_days" json:"jobs_retention_days"`
+		LogoRefreshDays   int `yaml:"logo_refresh_days" json:"logo_refresh_days"`
+		DomainRefreshDays int `yaml:"domain_refresh_days" json:"domain_refresh_days"`
+	} `yaml:"maintenance" json:"maintenance"`
+
+	Auth struct {
+		// Enabled requires every httpapi request to carry a valid
+		// "Authorization: Bearer <token>" header, and config PUT /
+		// api/secrets/* requests to additionally carry a valid
+		// X-JobHunt-Signature. The token and signing key themselves live
+		// in the OS keyring (see internal/secrets), not here.
+		Enabled bool `yaml:"enabled" json:"enabled"`
+
+		// CORSAllowOrigins replaces the SSE stream's hard-coded
+		// Access-Control-Allow-Origin: * with an explicit allowlist. An
+		// empty list means no CORS header is set (same-origin only).
+		CORSAllowOrigins []string `yaml:"cors_allow_origins" json:"cors_allow_origins"`
+	} `yaml:"auth" json:"auth"`
 }
 
 func Load(path string) (Config, error) {
@@ -122,6 +527,15 @@ func loadCompaniesFile(configPath string, cfg *Config) error {
 	if len(cf.Sources.Lever.Companies) > 0 {
 		cfg.Sources.Lever.Companies = cf.Sources.Lever.Companies
 	}
+	if len(cf.Sources.Ashby.Companies) > 0 {
+		cfg.Sources.Ashby.Companies = cf.Sources.Ashby.Companies
+	}
+	if len(cf.Sources.SmartRecruiters.Companies) > 0 {
+		cfg.Sources.SmartRecruiters.Companies = cf.Sources.SmartRecruiters.Companies
+	}
+	if len(cf.Sources.Workday.Companies) > 0 {
+		cfg.Sources.Workday.Companies = cf.Sources.Workday.Companies
+	}
 
 	return nil
 }
@@ -5,8 +5,32 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"jobhunt-engine/internal/pipeline"
+	"jobhunt-engine/internal/query"
+	"jobhunt-engine/internal/scrape/types"
 )
 
+// CompilePipeline compiles cfg.Scoring.Pipeline's raw rules into
+// executable pipeline.Rules, or internal/pipeline's own
+// DefaultRules() (today's hardcoded LinkedIn detection) when rules is
+// empty. Shared by NormalizeAndValidate (to catch bad rules at save
+// time) and the scrape/httpapi call sites that actually run them.
+func CompilePipeline(rules []PipelineRule) ([]pipeline.Rule, error) {
+	if len(rules) == 0 {
+		return pipeline.DefaultRules(), nil
+	}
+	out := make([]pipeline.Rule, 0, len(rules))
+	for _, r := range rules {
+		compiled, err := pipeline.CompileRule(r.Name, pipeline.When(r.When), r.Then, r.Stop)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, compiled)
+	}
+	return out, nil
+}
+
 type Validation struct {
 	Errors   []string `json:"errors"`
 	Warnings []string `json:"warnings"`
@@ -122,6 +146,7 @@ func NormalizeAndValidate(cfg Config) (Config, Validation) {
 	// ---------- normalization ----------
 	out.Filters.LocationsAllow = trimDedupe(out.Filters.LocationsAllow, true)
 	out.Filters.LocationsBlock = trimDedupe(out.Filters.LocationsBlock, true)
+	out.Filters.CompaniesBlock = trimDedupe(out.Filters.CompaniesBlock, true)
 	out.Email.SearchSubjectAny = trimDedupe(out.Email.SearchSubjectAny, false) // keep case, email subjects are case-insensitive anyway
 
 	out.Sources.Greenhouse.Companies = normalizeCompanies(out.Sources.Greenhouse.Companies)
@@ -202,6 +227,57 @@ func NormalizeAndValidate(cfg Config) (Config, Validation) {
 		}
 	}
 
+	// webcrawl isn't a SourceConfig (seeds, not slugged companies), so
+	// it gets its own block rather than going through the generic loop
+	// below.
+	if out.Sources.WebCrawl.Enabled {
+		if len(out.Sources.WebCrawl.Seeds) == 0 {
+			res.errf("sources.webcrawl.enabled=true but sources.webcrawl.seeds is empty")
+		}
+		for i, seed := range out.Sources.WebCrawl.Seeds {
+			if strings.TrimSpace(seed.URL) == "" {
+				res.errf("sources.webcrawl.seeds[%d] missing url", i)
+			}
+			if strings.TrimSpace(seed.Name) == "" {
+				res.warnf("sources.webcrawl.seeds[%d] url=%q missing name (UI may look less nice)", i, seed.URL)
+			}
+		}
+	}
+
+	// any other ATS source registered with internal/scrape/types (e.g.
+	// SmartRecruiters, Workday) declares its own config.SourceConfig
+	// schema instead of a hand-written block here; greenhouse/lever keep
+	// the block above since they predate the registry.
+	for _, name := range types.RegisteredFetcherNames() {
+		if name == "greenhouse" || name == "lever" {
+			continue
+		}
+		schema := types.FetcherSchema(name)
+		if schema == nil {
+			continue
+		}
+		if _, ok := schema.(SourceConfig); !ok {
+			continue
+		}
+		sc, _ := types.FetcherConfig(name, out).(SourceConfig)
+		if !sc.Enabled {
+			continue
+		}
+		if len(sc.Companies) == 0 {
+			res.errf("sources.%s.enabled=true but sources.%s.companies is empty", name, name)
+		}
+		for i, c := range sc.Companies {
+			if c.Slug == "" {
+				res.errf("sources.%s.companies[%d] missing slug", name, i)
+			} else if !slugRe.MatchString(c.Slug) {
+				res.warnf("sources.%s.companies[%d].slug %q looks unusual (expected lowercase slug)", name, i, c.Slug)
+			}
+			if strings.TrimSpace(c.Name) == "" {
+				res.warnf("sources.%s.companies[%d] slug=%q missing name (UI may look less nice)", name, i, c.Slug)
+			}
+		}
+	}
+
 	// email specifics
 	if out.Email.Enabled {
 		if strings.TrimSpace(out.Email.IMAPHost) == "" {
@@ -221,6 +297,48 @@ func NormalizeAndValidate(cfg Config) (Config, Validation) {
 		}
 	}
 
+	// bounce mailbox (POP3) specifics
+	if out.Polling.BounceSeconds > 0 {
+		if strings.TrimSpace(out.Email.BounceMailbox.Host) == "" {
+			res.errf("email.bounce_mailbox.host is required when polling.bounce_seconds > 0")
+		}
+		if out.Email.BounceMailbox.Port <= 0 || out.Email.BounceMailbox.Port > 65535 {
+			res.errf("email.bounce_mailbox.port must be a valid port (1-65535) when polling.bounce_seconds > 0")
+		}
+		if strings.TrimSpace(out.Email.BounceMailbox.Username) == "" {
+			res.errf("email.bounce_mailbox.username is required when polling.bounce_seconds > 0")
+		}
+	} else if out.Email.BounceMailbox.Host != "" {
+		res.warnf("email.bounce_mailbox is configured but polling.bounce_seconds is 0; the POP3 poller won't run")
+	}
+	if out.Filters.HardBounceBlockThreshold < 0 {
+		res.errf("filters.hard_bounce_block_threshold must be >= 0")
+	}
+
+	// saved queries
+	for i, sq := range out.Filters.SavedQueries {
+		if strings.TrimSpace(sq.Name) == "" {
+			res.errf("filters.saved_queries[%d] missing name", i)
+		}
+		if strings.TrimSpace(sq.Query) == "" {
+			res.errf("filters.saved_queries[%d] name=%q has empty query", i, sq.Name)
+			continue
+		}
+		if _, err := query.Parse(sq.Query); err != nil {
+			res.errf("filters.saved_queries[%d] name=%q: %s", i, sq.Name, err)
+		}
+	}
+
+	// scoring.pipeline rules
+	for i, r := range out.Scoring.Pipeline {
+		if strings.TrimSpace(r.Name) == "" {
+			res.errf("scoring.pipeline[%d] missing name", i)
+		}
+		if _, err := CompilePipeline([]PipelineRule{r}); err != nil {
+			res.errf("scoring.pipeline[%d] name=%q: %s", i, r.Name, err)
+		}
+	}
+
 	// scoring sanity
 	if out.Scoring.NotifyMinScore < 0 {
 		res.errf("scoring.notify_min_score must be >= 0")
@@ -13,4 +13,11 @@ type JobLead struct {
 	Description     string
 	PostedAt        *time.Time
 	FirstSeenSource string // email/greenhouse/etc.
+
+	// Score and ScoreReasons are set by scrape.ScoreJob: Score is the
+	// lead's weighted relevance score, ScoreReasons its top contributing
+	// rule/penalty labels (highest-weight first), so callers can sort
+	// leads by Score and show why each one ranked where it did.
+	Score        float64
+	ScoreReasons []string
 }
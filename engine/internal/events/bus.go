@@ -0,0 +1,100 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	applog "jobhunt-engine/internal/log"
+)
+
+// Handler reacts to one published Payload. Publish calls every
+// matching handler synchronously, in registration order, so a slow
+// handler (a stuck webhook POST) delays the others.
+type Handler func(ctx context.Context, p Payload)
+
+// Bus is the scrape loop's pub/sub: Publish fans a Payload out to every
+// Handler subscribed to its Kind plus every wildcard subscriber, then
+// (if Hub is set) forwards it as a MakeEvent string so the existing
+// GET /events SSE stream picks it up too.
+type Bus struct {
+	// Hub, when set, receives every published Payload via MakeEvent so
+	// httpapi.EventsHandler's SSE stream sees it alongside job_created/
+	// job_deleted. Optional: a Bus with no Hub just runs its Handlers.
+	Hub *Hub
+
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	all      []Handler
+}
+
+// NewBus returns an empty Bus, ready for Subscribe/Publish. hub may be
+// nil if nothing should stream to SSE.
+func NewBus(hub *Hub) *Bus {
+	return &Bus{Hub: hub, handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers handler for Payloads whose Kind() == kind,
+// returning a cancel func that removes it.
+func (b *Bus) Subscribe(kind string, handler Handler) (cancel func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[kind] = append(b.handlers[kind], handler)
+	idx := len(b.handlers[kind]) - 1
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		hs := b.handlers[kind]
+		if idx < len(hs) {
+			hs[idx] = nil
+		}
+	}
+}
+
+// SubscribeAll registers handler for every Payload published.
+func (b *Bus) SubscribeAll(handler Handler) (cancel func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.all = append(b.all, handler)
+	idx := len(b.all) - 1
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if idx < len(b.all) {
+			b.all[idx] = nil
+		}
+	}
+}
+
+// Publish calls every handler subscribed to p.Kind(), every wildcard
+// subscriber, then forwards p to Hub (if set) for SSE. A panicking
+// handler is recovered and logged so one bad subscriber (a broken
+// webhook config) can't crash the scraper.
+func (b *Bus) Publish(ctx context.Context, p Payload) {
+	b.mu.RLock()
+	hs := append(append([]Handler(nil), b.handlers[p.Kind()]...), b.all...)
+	b.mu.RUnlock()
+
+	for _, h := range hs {
+		if h == nil {
+			continue
+		}
+		callHandler(ctx, h, p)
+	}
+
+	if b.Hub != nil {
+		b.Hub.Publish(MakeEvent("", p.Kind(), 1, p))
+	}
+}
+
+func callHandler(ctx context.Context, h Handler, p Payload) {
+	defer func() {
+		if r := recover(); r != nil {
+			applog.Warn("events: subscriber panicked", "kind", p.Kind(), "panic", r)
+		}
+	}()
+	h(ctx, p)
+}
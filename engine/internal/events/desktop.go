@@ -0,0 +1,27 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	applog "jobhunt-engine/internal/log"
+
+	"github.com/gen2brain/beeep"
+)
+
+// NewDesktopSubscriber pops a native OS notification (D-Bus/notify-send
+// on Linux, AppleScript on macOS, the Windows Runtime API) for every
+// JobInserted, so a match can be noticed without the web UI open. Other
+// kinds are ignored.
+func NewDesktopSubscriber() Handler {
+	return func(ctx context.Context, p Payload) {
+		ji, ok := p.(JobInserted)
+		if !ok {
+			return
+		}
+		title := fmt.Sprintf("%s — %s", ji.Company, ji.Title)
+		if err := beeep.Notify("New job match", title, ""); err != nil {
+			applog.Warn("events: desktop notify failed", "error", err)
+		}
+	}
+}
@@ -1,39 +1,161 @@
 package events
 
-import "sync"
+import (
+	"sync"
 
+	applog "jobhunt-engine/internal/log"
+	"jobhunt-engine/internal/metrics"
+)
+
+const (
+	// subscriberBufferSize bounds how many events a client can fall
+	// behind before Publish disconnects it instead of blocking the
+	// publisher or silently dropping the event.
+	subscriberBufferSize = 256
+	// defaultHistorySize is the ring buffer size NewHub falls back to
+	// when given historySize <= 0.
+	defaultHistorySize = 512
+)
+
+// seqEvent pairs a published event's framed payload with the Hub-wide
+// sequence number it was assigned at publish time, so SSEHandler can
+// emit it as `id: <seq>` and a reconnecting client can resume from it.
+type seqEvent struct {
+	seq  int64
+	data string
+}
+
+// Subscriber is one live SSE client's delivery channel.
+type Subscriber struct {
+	ch chan seqEvent
+}
+
+// Stats summarizes Hub health (subscriber count, how many slow clients
+// have been disconnected, and the last sequence number published) so a
+// status page can show liveness at a glance.
+type Stats struct {
+	Subscribers int
+	Dropped     int64
+	LastSeq     int64
+}
+
+// Hub is the scrape loop's SSE fan-out: Publish assigns every event a
+// sequence number, keeps the last historySize of them for replay, and
+// pushes a copy to each Subscriber's buffered channel. A subscriber
+// whose buffer is already full when Publish fires is disconnected
+// (logged) rather than losing the event silently.
 type Hub struct {
-	mu      sync.Mutex
-	clients map[chan string]struct{}
+	mu          sync.Mutex
+	seq         int64
+	historySize int
+	history     []seqEvent
+	subs        map[*Subscriber]struct{}
+	dropped     int64
 }
 
-func NewHub() *Hub {
-	return &Hub{clients: make(map[chan string]struct{})}
+// NewHub returns a Hub whose replay ring buffer holds historySize
+// events (defaultHistorySize if historySize <= 0).
+func NewHub(historySize int) *Hub {
+	if historySize <= 0 {
+		historySize = defaultHistorySize
+	}
+	return &Hub{subs: make(map[*Subscriber]struct{}), historySize: historySize}
 }
 
-func (h *Hub) Subscribe() chan string {
-	ch := make(chan string, 10)
+// Subscribe registers a new client and returns it along with any
+// buffered events published after fromSeq, oldest first. fromSeq <= 0
+// means "no replay, live events only".
+func (h *Hub) Subscribe(fromSeq int64) (*Subscriber, []seqEvent) {
 	h.mu.Lock()
-	h.clients[ch] = struct{}{}
-	h.mu.Unlock()
-	return ch
+	defer h.mu.Unlock()
+
+	sub := &Subscriber{ch: make(chan seqEvent, subscriberBufferSize)}
+	h.subs[sub] = struct{}{}
+	metrics.SSEClients.Set(float64(len(h.subs)))
+
+	var replay []seqEvent
+	if fromSeq > 0 {
+		for _, e := range h.history {
+			if e.seq > fromSeq {
+				replay = append(replay, e)
+			}
+		}
+	}
+	return sub, replay
 }
 
-func (h *Hub) Unsubscribe(ch chan string) {
+// Unsubscribe removes sub and closes its channel. Safe to call more
+// than once (e.g. after Publish has already dropped sub for being slow).
+func (h *Hub) Unsubscribe(sub *Subscriber) {
 	h.mu.Lock()
-	delete(h.clients, ch)
-	h.mu.Unlock()
-	close(ch)
+	defer h.mu.Unlock()
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+	delete(h.subs, sub)
+	close(sub.ch)
+	metrics.SSEClients.Set(float64(len(h.subs)))
 }
 
+// Publish assigns evt the next sequence number, appends it to the
+// replay history, and fans it out to every subscriber. Slow
+// subscribers are disconnected rather than blocking the publisher.
 func (h *Hub) Publish(evt string) {
 	h.mu.Lock()
-	defer h.mu.Unlock()
-	for ch := range h.clients {
+	h.seq++
+	se := seqEvent{seq: h.seq, data: evt}
+
+	h.history = append(h.history, se)
+	if len(h.history) > h.historySize {
+		h.history = h.history[len(h.history)-h.historySize:]
+	}
+
+	var slow []*Subscriber
+	for sub := range h.subs {
 		select {
-		case ch <- evt:
+		case sub.ch <- se:
 		default:
-			// drop if slow
+			slow = append(slow, sub)
 		}
 	}
+	for _, sub := range slow {
+		delete(h.subs, sub)
+		close(sub.ch)
+		h.dropped++
+	}
+	if len(slow) > 0 {
+		metrics.SSEClients.Set(float64(len(h.subs)))
+		metrics.EventsDroppedTotal.Add(float64(len(slow)))
+	}
+	h.mu.Unlock()
+
+	for range slow {
+		applog.Warn("events: disconnected slow SSE subscriber", "buffer", subscriberBufferSize)
+	}
+}
+
+// Close disconnects every current subscriber by closing its channel,
+// so an SSEHandler goroutine blocked on <-sub.ch returns immediately
+// instead of leaking past shutdown. Callers should Publish a final
+// "shutdown" event first so clients see why the stream ended. Safe to
+// call once; a Hub isn't meant to be reused after Close.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs {
+		delete(h.subs, sub)
+		close(sub.ch)
+	}
+	metrics.SSEClients.Set(0)
+}
+
+// Stats reports current Hub health.
+func (h *Hub) Stats() Stats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return Stats{
+		Subscribers: len(h.subs),
+		Dropped:     h.dropped,
+		LastSeq:     h.seq,
+	}
 }
@@ -0,0 +1,83 @@
+package events
+
+// Payload is the tagged union Bus dispatches to kind-specific
+// subscribers (a webhook poster, a desktop notifier, ...). MakeEvent
+// wraps a Payload's fields as the envelope's Data when Bus forwards a
+// publish to a Hub for the existing GET /events SSE stream.
+type Payload interface {
+	Kind() string
+}
+
+const (
+	KindJobInserted        = "job_inserted"
+	KindJobScored          = "job_scored"
+	KindScrapeRunCompleted = "scrape_run_completed"
+	KindBackupStarted      = "backup_started"
+	KindBackupProgress     = "backup_progress"
+	KindBackupCompleted    = "backup_completed"
+)
+
+// JobInserted fires once per row insertJobIfNew actually inserts (not
+// on duplicates it silently ignores).
+type JobInserted struct {
+	ID       int64  `json:"id"`
+	Company  string `json:"company"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Score    int    `json:"score"`
+	SourceID string `json:"source_id"`
+}
+
+func (JobInserted) Kind() string { return KindJobInserted }
+
+// JobScored fires whenever a lead is scored, independent of whether it
+// ends up inserted.
+type JobScored struct {
+	Company string   `json:"company"`
+	Title   string   `json:"title"`
+	URL     string   `json:"url"`
+	Score   int      `json:"score"`
+	Tags    []string `json:"tags"`
+}
+
+func (JobScored) Kind() string { return KindJobScored }
+
+// ScrapeRunCompleted fires once per mailbox/source scrape pass, win or
+// lose, so subscribers see every attempt.
+type ScrapeRunCompleted struct {
+	Added    int    `json:"added"`
+	Duration int64  `json:"duration_ms"` // milliseconds
+	Mailbox  string `json:"mailbox"`
+}
+
+func (ScrapeRunCompleted) Kind() string { return KindScrapeRunCompleted }
+
+// BackupStarted fires once a GET /jobs/backup export or POST
+// /jobs/restore import begins, before the first row is streamed.
+type BackupStarted struct {
+	Mode string `json:"mode"` // "export" | "import"
+}
+
+func (BackupStarted) Kind() string { return KindBackupStarted }
+
+// BackupProgress fires every N rows during a POST /jobs/restore
+// import (N is httpapi's backupProgressEvery), so a long restore shows
+// life on the SSE stream instead of going quiet until BackupCompleted.
+type BackupProgress struct {
+	Seen     int `json:"seen"`
+	Inserted int `json:"inserted"`
+}
+
+func (BackupProgress) Kind() string { return KindBackupProgress }
+
+// BackupCompleted fires once an export/import finishes, success or
+// not - Err is set on failure so a subscriber can tell a short backup
+// apart from a broken one.
+type BackupCompleted struct {
+	Mode     string `json:"mode"` // "export" | "import"
+	Seen     int    `json:"seen"`
+	Inserted int    `json:"inserted,omitempty"`
+	Err      string `json:"err,omitempty"`
+}
+
+func (BackupCompleted) Kind() string { return KindBackupCompleted }
@@ -0,0 +1,88 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	applog "jobhunt-engine/internal/log"
+	"jobhunt-engine/internal/query"
+
+	"github.com/gen2brain/beeep"
+)
+
+// SavedQuery is one filters.saved_queries entry: a named
+// internal/query search a user wants desktop-notified about whenever
+// a newly inserted job matches it.
+type SavedQuery struct {
+	Name  string
+	Query string
+}
+
+type compiledSavedQuery struct {
+	name  string
+	where string
+	args  []any
+}
+
+// NewSavedQuerySubscriber pops a native OS notification for every
+// JobInserted whose row matches one of queries, labeled with the
+// matching saved query's name. It supersedes the old single
+// Scoring.NotifyMinScore gate with arbitrarily many named filters, but
+// doesn't remove that field - existing configs that only set
+// notify_min_score keep validating, they just won't get a saved-query
+// notification until they add one. A query that fails to parse (it
+// should have been caught by NormalizeAndValidate already) is skipped
+// rather than failing every other query.
+func NewSavedQuerySubscriber(db *sql.DB, queries []SavedQuery) Handler {
+	var compiled []compiledSavedQuery
+	for _, sq := range queries {
+		expr, err := query.Parse(sq.Query)
+		if err != nil {
+			applog.Warn("events: saved query failed to parse, skipping", "name", sq.Name, "error", err)
+			continue
+		}
+		where, args := query.Compile(expr)
+		compiled = append(compiled, compiledSavedQuery{name: sq.Name, where: where, args: args})
+	}
+
+	return func(ctx context.Context, p Payload) {
+		if len(compiled) == 0 {
+			return
+		}
+		ji, ok := p.(JobInserted)
+		if !ok {
+			return
+		}
+		for _, c := range compiled {
+			matched, err := jobMatchesCompiled(ctx, db, ji.ID, c.where, c.args)
+			if err != nil {
+				applog.Warn("events: saved query match check failed", "name", c.name, "error", err)
+				continue
+			}
+			if !matched {
+				continue
+			}
+			title := fmt.Sprintf("%s — %s", ji.Company, ji.Title)
+			if err := beeep.Notify("Saved search: "+c.name, title, ""); err != nil {
+				applog.Warn("events: desktop notify failed", "error", err)
+			}
+		}
+	}
+}
+
+// jobMatchesCompiled re-checks id's actual row against a
+// query.Compile'd predicate: JobInserted only carries a handful of
+// fields, not enough to evaluate loc:/tag:/mode: terms in memory.
+func jobMatchesCompiled(ctx context.Context, db *sql.DB, id int64, where string, args []any) (bool, error) {
+	full := append([]any{id}, args...)
+	var one int
+	err := db.QueryRowContext(ctx, `SELECT 1 FROM jobs WHERE id = ? AND (`+where+`) LIMIT 1;`, full...).Scan(&one)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
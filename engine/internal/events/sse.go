@@ -0,0 +1,95 @@
+package events
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// keepaliveInterval bounds how long an idle SSE stream can go without
+// writing anything, so reverse proxies/load balancers that kill
+// connections after some quiet period don't mistake a live-but-idle
+// client for a dead one.
+const keepaliveInterval = 15 * time.Second
+
+// SSEHandler streams Hub's events as text/event-stream: each message
+// carries `id: <seq>` (so a client can reconnect with Last-Event-ID),
+// `event: message`, `retry:` (reconnect backoff), and the MakeEvent
+// JSON as `data:`. A Last-Event-ID request header (or its query-string
+// equivalent, since EventSource can't set custom headers on the
+// initial request) replays everything published since that seq before
+// switching to live events.
+//
+// A `: keepalive` comment line is sent every keepaliveInterval so
+// intermediaries that close idle connections don't kill the stream.
+//
+// allowOrigin, when non-nil, is consulted for the request's Origin
+// header and only sets Access-Control-Allow-Origin when it returns
+// true - callers resolve this against their own config (e.g.
+// cfg.Auth.CORSAllowOrigins) instead of this package hard-coding "*".
+// A nil allowOrigin sets no CORS header at all (same-origin only).
+func SSEHandler(h *Hub, allowOrigin func(origin string) bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		if origin := r.Header.Get("Origin"); origin != "" && allowOrigin != nil && allowOrigin(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Vary", "Origin")
+		}
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, "retry: 3000\n\n")
+
+		sub, replay := h.Subscribe(lastEventID(r))
+		defer h.Unsubscribe(sub)
+
+		for _, se := range replay {
+			fmt.Fprintf(w, "id: %d\nevent: message\ndata: %s\n\n", se.seq, se.data)
+		}
+		flusher.Flush()
+
+		keepalive := time.NewTicker(keepaliveInterval)
+		defer keepalive.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-keepalive.C:
+				fmt.Fprintf(w, ": keepalive\n\n")
+				flusher.Flush()
+			case se, ok := <-sub.ch:
+				if !ok {
+					// Publish disconnected us for falling behind.
+					return
+				}
+				fmt.Fprintf(w, "id: %d\nevent: message\ndata: %s\n\n", se.seq, se.data)
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+// lastEventID reads the resume point from the Last-Event-ID header, or
+// its "lastEventId" query-string equivalent for browsers' EventSource,
+// which cannot set custom headers on the initial request. Returns 0
+// (no replay) if absent or unparsable.
+func lastEventID(r *http.Request) int64 {
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("lastEventId")
+	}
+	seq, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return seq
+}
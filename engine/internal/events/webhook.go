@@ -0,0 +1,60 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	applog "jobhunt-engine/internal/log"
+)
+
+// NewWebhookSubscriber posts every event to url as JSON, signing the
+// body with HMAC-SHA256 when secret is non-empty (e.g. for a Slack/
+// Discord relay or a personal automation) so the receiver can verify it
+// came from this engine. Failures are logged, not returned, since a
+// down webhook must never block the scrape loop.
+func NewWebhookSubscriber(url, secret string) Handler {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	return func(ctx context.Context, p Payload) {
+		body, err := json.Marshal(struct {
+			Kind    string  `json:"kind"`
+			Payload Payload `json:"payload"`
+		}{p.Kind(), p})
+		if err != nil {
+			applog.Warn("events: webhook marshal failed", "kind", p.Kind(), "error", err)
+			return
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			applog.Warn("events: webhook request build failed", "kind", p.Kind(), "error", err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if secret != "" {
+			req.Header.Set("X-Signature-256", signBody(body, secret))
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			applog.Warn("events: webhook post failed", "kind", p.Kind(), "url", url, "error", err)
+			return
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			applog.Warn("events: webhook rejected", "kind", p.Kind(), "url", url, "status", resp.StatusCode)
+		}
+	}
+}
+
+func signBody(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,147 @@
+// Package fetch wraps http.Client with the policy every scraper entry
+// point in this repo otherwise had to hand-roll: per-host rate
+// limiting, exponential backoff on 429/5xx, a rotating User-Agent
+// pool, and an explicit choice of whether to follow redirects.
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"jobhunt-engine/internal/scrape/util"
+)
+
+// defaultUserAgents is rotated through when no WithUserAgents option is
+// given, so a run of searches doesn't look like the same client
+// hammering a site over and over.
+var defaultUserAgents = []string{
+	"JobHunt/1.0 (+local)",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Safari/537.36",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Safari/537.36",
+	"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Safari/537.36",
+}
+
+// Fetcher is a policy-wrapped HTTP GET, shared by jobboard.Source and
+// the ATS scrapers under internal/scrape. The zero value is not usable;
+// build one with New.
+type Fetcher struct {
+	hc *http.Client
+
+	// FollowRedirects matches the webanalyze `-redirect` flag: off by
+	// default, so a 3xx response (e.g. LinkedIn's ?currentJobId=
+	// redirect page) comes back to the caller as-is for the
+	// query-param extraction path instead of being silently followed
+	// to its final URL. New reads it once, after applying opts, so set
+	// it with WithFollowRedirects(true) to follow redirects the normal
+	// way; changing the field after New has no effect.
+	FollowRedirects bool
+
+	limiter     *util.HostLimiter
+	userAgents  []string
+	uaIdx       uint64
+	maxRetries  int
+	baseBackoff time.Duration
+}
+
+// Option configures a Fetcher built by New.
+type Option func(*Fetcher)
+
+// WithTimeout sets the underlying http.Client's per-request timeout
+// (default 20s).
+func WithTimeout(d time.Duration) Option {
+	return func(f *Fetcher) { f.hc.Timeout = d }
+}
+
+// WithFollowRedirects sets Fetcher.FollowRedirects.
+func WithFollowRedirects(follow bool) Option {
+	return func(f *Fetcher) { f.FollowRedirects = follow }
+}
+
+// WithRateLimit sets the per-host token bucket (default 1 req/s,
+// burst 2 — see util.NewHostLimiter).
+func WithRateLimit(reqPerSec float64, burst int) Option {
+	return func(f *Fetcher) { f.limiter = util.NewHostLimiter(reqPerSec, burst) }
+}
+
+// WithUserAgents replaces the rotating User-Agent pool (default
+// defaultUserAgents).
+func WithUserAgents(agents ...string) Option {
+	return func(f *Fetcher) { f.userAgents = agents }
+}
+
+// WithMaxRetries caps how many times Get retries a 429/5xx or
+// transport error (default 3, on top of the initial attempt).
+func WithMaxRetries(n int) Option {
+	return func(f *Fetcher) { f.maxRetries = n }
+}
+
+// New builds a Fetcher with FollowRedirects off, a 1req/s-per-host
+// limiter, the default User-Agent pool, and 3 retries, then applies
+// opts.
+func New(opts ...Option) *Fetcher {
+	f := &Fetcher{
+		hc:          &http.Client{Timeout: 20 * time.Second},
+		limiter:     util.NewHostLimiter(1.0, 2),
+		userAgents:  defaultUserAgents,
+		maxRetries:  3,
+		baseBackoff: 500 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	if !f.FollowRedirects {
+		f.hc.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	return f
+}
+
+// Get issues a GET to rawURL: it waits on the per-host limiter, sets a
+// rotating User-Agent, and on a 429/5xx response or transport error
+// retries with exponential backoff (baseBackoff*2^(attempt-1)) up to
+// maxRetries times. The caller owns the returned response's body.
+func (f *Fetcher) Get(ctx context.Context, rawURL string) (*http.Response, error) {
+	if err := f.limiter.WaitURL(ctx, rawURL); err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= f.maxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := f.baseBackoff * time.Duration(uint64(1)<<uint(attempt-1))
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", f.nextUserAgent())
+
+		res, err := f.hc.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500 {
+			lastErr = fmt.Errorf("fetch %s: status %d", rawURL, res.StatusCode)
+			res.Body.Close()
+			continue
+		}
+		return res, nil
+	}
+	return nil, lastErr
+}
+
+func (f *Fetcher) nextUserAgent() string {
+	i := atomic.AddUint64(&f.uaIdx, 1)
+	return f.userAgents[int(i-1)%len(f.userAgents)]
+}
@@ -0,0 +1,134 @@
+package httpapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"jobhunt-engine/internal/rank"
+	"jobhunt-engine/internal/store"
+)
+
+// BayesHandler trains rank.BayesScorer from user feedback on jobs
+// already in the DB, and resets it back to untrained.
+type BayesHandler struct {
+	DB     *sql.DB
+	Scorer *rank.BayesScorer
+}
+
+// Feedback records id's relevance label and, for training labels
+// (interested/applied/dismissed), tokenizes that job's title+tags,
+// increments bayes_tokens, and reloads Scorer so the change is visible
+// immediately instead of waiting for the next retrain tick.
+func (h BayesHandler) Feedback(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ID       int64  `json:"id"`
+		Feedback string `json:"feedback"` // interested|applied|dismissed|""
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "bad request body", http.StatusBadRequest)
+		return
+	}
+	if body.ID <= 0 {
+		http.Error(w, "missing id", http.StatusBadRequest)
+		return
+	}
+	positive, train := bayesTrainingSignal(body.Feedback)
+	if body.Feedback != "" && !train {
+		http.Error(w, "feedback must be interested, applied, dismissed, or empty", http.StatusBadRequest)
+		return
+	}
+
+	title, tags, err := store.SetJobFeedback(r.Context(), h.DB, body.ID, body.Feedback)
+	if err == sql.ErrNoRows {
+		http.Error(w, "job not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if train {
+		tokens := rank.Tokenize(title + " " + strings.Join(tags, " "))
+		if err := store.IncrementBayesTokens(r.Context(), h.DB, tokens, positive); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if h.Scorer != nil {
+			if err := reloadBayesScorer(r.Context(), h.DB, h.Scorer); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
+	writeJSON(w, map[string]any{"ok": true})
+}
+
+// Reset clears every learned token and the in-memory scorer, for
+// starting over after a bad batch of labels.
+func (h BayesHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	if err := store.ResetBayesTokens(r.Context(), h.DB); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if h.Scorer != nil {
+		h.Scorer.Load(nil)
+	}
+	writeJSON(w, map[string]any{"ok": true})
+}
+
+// bayesTrainingSignal maps a feedback label to the naive-Bayes class
+// it trains (positive for interested/applied, negative for dismissed)
+// and whether it's a training label at all ("" just clears feedback).
+func bayesTrainingSignal(feedback string) (positive, train bool) {
+	switch feedback {
+	case "interested", "applied":
+		return true, true
+	case "dismissed":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+func reloadBayesScorer(ctx context.Context, db *sql.DB, scorer *rank.BayesScorer) error {
+	tokens, err := store.LoadBayesTokens(ctx, db)
+	if err != nil {
+		return err
+	}
+	snapshot := make(map[string]rank.BayesTokenStats, len(tokens))
+	for tok, c := range tokens {
+		snapshot[tok] = rank.BayesTokenStats(c)
+	}
+	scorer.Load(snapshot)
+	return nil
+}
+
+// StartBayesRetrainLoop periodically reloads scorer's in-memory
+// snapshot from bayes_tokens until ctx is canceled, so scoring stays
+// correct even if a feedback write's inline reload was skipped (e.g.
+// the process restarted, or a future caller writes bayes_tokens
+// directly). Feedback already reloads inline, so this is a safety net,
+// not the primary path.
+func StartBayesRetrainLoop(ctx context.Context, db *sql.DB, scorer *rank.BayesScorer, interval time.Duration) {
+	if scorer == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = reloadBayesScorer(ctx, db, scorer)
+			}
+		}
+	}()
+}
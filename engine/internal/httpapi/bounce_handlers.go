@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"jobhunt-engine/internal/bounces"
+	"jobhunt-engine/internal/config"
+	"jobhunt-engine/internal/metrics"
+)
+
+// BounceHandler ingests delivery-failure reports for outbound
+// application/watch email via POST /webhooks/bounce, and auto-blocks a
+// company (persisting cfg.Filters.CompaniesBlock) once its hard
+// bounces cross cfg.Filters.HardBounceBlockThreshold.
+type BounceHandler struct {
+	DB          *sql.DB
+	CfgVal      *atomic.Value // stores config.Config
+	UserCfgPath string
+	LoadCfg     func() (config.Config, error)
+}
+
+// Webhook records one {source_id, reason, type, received_at} bounce
+// report. received_at defaults to now if omitted.
+func (h BounceHandler) Webhook(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		SourceID   string    `json:"source_id"`
+		Reason     string    `json:"reason"`
+		Type       string    `json:"type"` // hard|soft
+		ReceivedAt time.Time `json:"received_at"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "bad request body", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(body.SourceID) == "" {
+		http.Error(w, "missing source_id", http.StatusBadRequest)
+		return
+	}
+	typ := strings.ToLower(body.Type)
+	if typ != "hard" && typ != "soft" {
+		http.Error(w, `type must be "hard" or "soft"`, http.StatusBadRequest)
+		return
+	}
+
+	cfg := h.CfgVal.Load().(config.Config)
+	blockCompany, err := bounces.Record(r.Context(), h.DB, cfg, bounces.Bounce{
+		SourceID:   body.SourceID,
+		Reason:     body.Reason,
+		Type:       typ,
+		ReceivedAt: body.ReceivedAt,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if blockCompany != "" {
+		if err := h.blockCompany(blockCompany); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	writeJSON(w, map[string]any{"ok": true, "blocked_company": blockCompany})
+}
+
+// blockCompany appends company to cfg.Filters.CompaniesBlock and saves
+// it through the same NormalizeAndValidate/SaveAtomic path PUT /config
+// uses, then refreshes CfgVal so the block takes effect immediately.
+func (h BounceHandler) blockCompany(company string) error {
+	cfg := h.CfgVal.Load().(config.Config)
+	cfg.Filters.CompaniesBlock = append(cfg.Filters.CompaniesBlock, company)
+
+	normalized, vr := config.NormalizeAndValidate(cfg)
+	if !vr.OK() {
+		return fmt.Errorf("auto-block %q: %s", company, strings.Join(vr.Errors, "; "))
+	}
+	if err := config.SaveAtomic(h.UserCfgPath, normalized); err != nil {
+		return err
+	}
+
+	saved, err := h.LoadCfg()
+	if err != nil {
+		return err
+	}
+	h.CfgVal.Store(saved)
+	metrics.ActiveConfigVersion.Inc()
+	return nil
+}
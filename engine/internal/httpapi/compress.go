@@ -0,0 +1,196 @@
+package httpapi
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// minCompressSize is the smallest response body Compress bothers
+// gzip/deflate-encoding - below this, the gzip/deflate framing
+// overhead usually outweighs the savings, so a small payload (an
+// "{"ok":true}") passes through unmodified.
+const minCompressSize = 1024
+
+// gzipWriterPool recycles *gzip.Writer across requests so Compress's
+// common case (a big /jobs or /api/jobs/search payload) doesn't
+// allocate a fresh compressor per response.
+var gzipWriterPool = sync.Pool{
+	New: func() any { return gzip.NewWriter(io.Discard) },
+}
+
+// isStreamingContentType flags a response Compress must never buffer
+// or encode - an SSE stream (see EventsHandler) writes and flushes
+// incrementally forever, which buffering for a size check would break.
+func isStreamingContentType(ct string) bool {
+	return strings.HasPrefix(ct, "text/event-stream")
+}
+
+// Compress negotiates gzip (preferred) or deflate from Accept-Encoding
+// and transparently encodes the response body, skipping responses
+// that are already encoded, text/event-stream, or that never grow
+// past minCompressSize. Must sit directly inside AccessLog in Chain
+// (AccessLog outermost) so statusWriter's byte count reflects the
+// compressed bytes actually written to the wire; withRawBytesCounter
+// is how AccessLog's log line still gets the pre-compression size.
+func Compress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if w.Header().Get("Content-Encoding") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		accept := r.Header.Get("Accept-Encoding")
+		var encoding string
+		switch {
+		case strings.Contains(accept, "gzip"):
+			encoding = "gzip"
+		case strings.Contains(accept, "deflate"):
+			encoding = "deflate"
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding, rawBytes: rawBytesFrom(r.Context())}
+		defer cw.Close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// compressWriter buffers a response until it either crosses
+// minCompressSize (committing to compression) or its Content-Type
+// marks it as streaming (committing to an uncompressed passthrough) -
+// whichever happens first. If the body never grows past
+// minCompressSize, Close flushes the buffered bytes uncompressed.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	rawBytes *int // see withRawBytesCounter; nil if AccessLog isn't in the chain
+
+	status   int
+	buf      []byte
+	enc      io.WriteCloser // set once compression is committed
+	bypassed bool           // set once uncompressed passthrough is committed
+}
+
+func (cw *compressWriter) WriteHeader(status int) {
+	cw.status = status
+	// Deferred: headers only actually go out once Write commits to
+	// compress or bypass (or Close does, for a body under the
+	// threshold), since Content-Encoding depends on that decision.
+}
+
+func (cw *compressWriter) Write(b []byte) (int, error) {
+	if cw.rawBytes != nil {
+		*cw.rawBytes += len(b)
+	}
+
+	if cw.enc != nil {
+		return cw.enc.Write(b)
+	}
+	if cw.bypassed {
+		return cw.ResponseWriter.Write(b)
+	}
+
+	if len(cw.buf) == 0 && (isStreamingContentType(cw.Header().Get("Content-Type")) || cw.Header().Get("Content-Encoding") != "") {
+		cw.commitBypass()
+		return cw.ResponseWriter.Write(b)
+	}
+
+	cw.buf = append(cw.buf, b...)
+	if len(cw.buf) < minCompressSize {
+		return len(b), nil
+	}
+	if err := cw.commitCompress(); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Flush satisfies http.Flusher so a handler that type-asserts for it
+// (every SSE handler in this package) still gets one, whether or not
+// this response ends up compressed.
+func (cw *compressWriter) Flush() {
+	switch enc := cw.enc.(type) {
+	case *gzip.Writer:
+		_ = enc.Flush()
+	case *flate.Writer:
+		_ = enc.Flush()
+	}
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (cw *compressWriter) commitBypass() {
+	cw.bypassed = true
+	cw.flushHeader("")
+}
+
+func (cw *compressWriter) commitCompress() error {
+	cw.flushHeader(cw.encoding)
+
+	var enc io.WriteCloser
+	switch cw.encoding {
+	case "gzip":
+		gz := gzipWriterPool.Get().(*gzip.Writer)
+		gz.Reset(cw.ResponseWriter)
+		enc = gz
+	case "deflate":
+		fw, err := flate.NewWriter(cw.ResponseWriter, flate.DefaultCompression)
+		if err != nil {
+			return err
+		}
+		enc = fw
+	}
+
+	cw.enc = enc
+	buf := cw.buf
+	cw.buf = nil
+	_, err := enc.Write(buf)
+	return err
+}
+
+// flushHeader sends the status line and headers exactly once, setting
+// Content-Encoding/Vary only when encoding is non-empty (the bypass
+// and under-threshold paths send neither).
+func (cw *compressWriter) flushHeader(encoding string) {
+	if encoding != "" {
+		cw.Header().Set("Content-Encoding", encoding)
+		cw.Header().Add("Vary", "Accept-Encoding")
+	}
+	status := cw.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	cw.ResponseWriter.WriteHeader(status)
+}
+
+// Close finishes whichever path Write committed to: closes (and, for
+// gzip, pools) the compressor, or - if the body never reached
+// minCompressSize - flushes the buffered bytes uncompressed. A no-op
+// if the response already bypassed compression itself.
+func (cw *compressWriter) Close() error {
+	if cw.bypassed {
+		return nil
+	}
+	if cw.enc != nil {
+		err := cw.enc.Close()
+		if gz, ok := cw.enc.(*gzip.Writer); ok {
+			gz.Reset(io.Discard)
+			gzipWriterPool.Put(gz)
+		}
+		return err
+	}
+
+	cw.flushHeader("")
+	if len(cw.buf) == 0 {
+		return nil
+	}
+	_, err := cw.ResponseWriter.Write(cw.buf)
+	return err
+}
@@ -7,6 +7,7 @@ import (
 	"sync/atomic"
 
 	"jobhunt-engine/internal/config"
+	"jobhunt-engine/internal/metrics"
 )
 
 type ConfigHandler struct {
@@ -54,6 +55,7 @@ func (h ConfigHandler) Put(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	h.CfgVal.Store(saved)
+	metrics.ActiveConfigVersion.Inc()
 	writeJSON(w, saved)
 }
 
@@ -7,11 +7,17 @@ import (
 
 	"jobhunt-engine/internal/config"
 	"jobhunt-engine/internal/events"
+	"jobhunt-engine/internal/jobs"
+	"jobhunt-engine/internal/lifecycle"
+	"jobhunt-engine/internal/rank"
 )
 
 type Deps struct {
 	DB *sql.DB
 
+	// DataDir is the on-disk root for content-addressed caches (logos, ...).
+	DataDir string
+
 	Hub *events.Hub
 
 	// Atomic stores
@@ -24,6 +30,22 @@ type Deps struct {
 
 	DeleteJob func(ctx context.Context, db *sql.DB, id int64) error
 
-	// Scrape entrypoint (inject for testability)
-	RunPollOnce func(db *sql.DB, cfg config.Config, onNewJob func()) (added int, err error)
+	// Scrape entrypoint (inject for testability) - main.go wires this to
+	// an adapter over scrape.RunScrapeOnce.
+	RunEmailScrape func(db *sql.DB, cfg config.Config, onNewJob func()) (added int, err error)
+
+	// BayesScorer is the in-memory snapshot BayesHandler trains and
+	// StartBayesRetrainLoop periodically refreshes from bayes_tokens.
+	BayesScorer *rank.BayesScorer
+
+	// Lifecycle tracks in-flight scrapes and the poller's cancel func
+	// so ShutdownHandler can drain them instead of just calling
+	// srv.Shutdown. Nil disables tracking (ScrapeHandler.Run then
+	// behaves as before).
+	Lifecycle *lifecycle.Coordinator
+
+	// Jobs, when set, moves ScrapeHandler onto the job_runs queue
+	// (see internal/jobs) instead of the monolithic PollOnce call.
+	// Nil keeps the legacy single-slot behavior.
+	Jobs *jobs.Server
 }
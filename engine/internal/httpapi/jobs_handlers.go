@@ -3,14 +3,22 @@ package httpapi
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"jobhunt-engine/internal/classify"
 	"jobhunt-engine/internal/events"
+	"jobhunt-engine/internal/query"
 	"jobhunt-engine/internal/store"
 )
 
+// backupProgressEvery is how many imported rows ImportJobs processes
+// between backup_progress events - frequent enough to show life on a
+// large restore without flooding the SSE stream.
+const backupProgressEvery = 100
+
 type JobsHandler struct {
 	DB        *sql.DB
 	Hub       *events.Hub
@@ -32,9 +40,48 @@ func (h JobsHandler) List(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, jobs)
 }
 
-func (h JobsHandler) DeleteByPath(w http.ResponseWriter, r *http.Request) {
-	idStr := strings.TrimPrefix(r.URL.Path, "/jobs/")
-	id, err := strconv.ParseInt(idStr, 10, 64)
+// Search handles GET /api/jobs/search?q=..., parsing q with the
+// internal/query grammar (field terms, date ranges, AND/OR/NOT,
+// parens, free text) and compiling it to a parameterized WHERE clause
+// AND'd onto the usual sort/window filter. q="" behaves like List.
+func (h JobsHandler) Search(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	expr, err := query.Parse(q.Get("q"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	where, args := query.Compile(expr)
+
+	jobs, err := store.ListJobs(r.Context(), h.DB, store.ListJobsOpts{
+		Sort: q.Get("sort"), Window: q.Get("window"), Limit: 50000,
+		Where: where, Args: args,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	writeJSON(w, jobs)
+}
+
+// ByID dispatches /jobs/{id} and /jobs/{id}/feedback: DELETE removes
+// the job, POST .../feedback trains the classify package's Bayesian
+// buckets from the user's interested/discard decision and rescopes the
+// job's stored score to match.
+func (h JobsHandler) ByID(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/jobs/")
+	if idStr, ok := strings.CutSuffix(rest, "/feedback"); ok {
+		h.Feedback(w, r, idStr)
+		return
+	}
+
+	if r.Method != http.MethodDelete {
+		http.Error(w, "DELETE only", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := strconv.ParseInt(rest, 10, 64)
 	if err != nil || id <= 0 {
 		http.Error(w, "invalid id", 400)
 		return
@@ -47,10 +94,77 @@ func (h JobsHandler) DeleteByPath(w http.ResponseWriter, r *http.Request) {
 
 	reqID := RequestIDFrom(r.Context())
 	h.Hub.Publish(events.MakeEvent(reqID, "job_deleted", 1, map[string]any{"id": id}))
-	// h.Hub.Publish(`{"type":"job_deleted","id":` + fmt.Sprint(id) + `}`)
 	writeJSON(w, map[string]any{"ok": true, "id": id})
 }
 
+// Feedback implements POST /jobs/{id}/feedback, training classify's
+// Bayesian buckets from the user's interested/discard decision on an
+// existing job and updating its stored score so the UI reflects the
+// classifier's latest opinion. Distinct from BayesHandler.Feedback
+// (rank.BayesScorer, /api/bayes/feedback) - these are two independent
+// scoring/feedback subsystems that happen to coexist.
+func (h JobsHandler) Feedback(w http.ResponseWriter, r *http.Request, idStr string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST only", http.StatusMethodNotAllowed)
+		return
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "invalid id", 400)
+		return
+	}
+
+	var body struct {
+		Decision string `json:"decision"` // "interested" | "discard"
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid JSON: "+err.Error(), 400)
+		return
+	}
+
+	var bucket classify.Bucket
+	switch body.Decision {
+	case "interested":
+		bucket = classify.Interested
+	case "discard":
+		bucket = classify.Discarded
+	default:
+		http.Error(w, `decision must be "interested" or "discard"`, 400)
+		return
+	}
+
+	var company, title, location, jobURL string
+	err = h.DB.QueryRowContext(r.Context(), `
+SELECT company, title, location, url FROM jobs WHERE id = ?;`, id).
+		Scan(&company, &title, &location, &jobURL)
+	if err == sql.ErrNoRows {
+		http.Error(w, "job not found", 404)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	doc := classify.Doc{Title: title, Company: company, Location: location, URL: jobURL}
+	if err := classify.Train(r.Context(), h.DB, doc, bucket); err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	score, err := classify.Score(r.Context(), h.DB, doc)
+	if err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+	if _, err := h.DB.ExecContext(r.Context(), `UPDATE jobs SET score = ? WHERE id = ?;`, score, id); err != nil {
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	writeJSON(w, map[string]any{"ok": true, "id": id, "score": score})
+}
+
 func (h JobsHandler) Seed(w http.ResponseWriter, r *http.Request) {
 	job, err := store.SeedJob(r.Context(), h.DB)
 	if err != nil {
@@ -63,6 +177,51 @@ func (h JobsHandler) Seed(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, job)
 }
 
+// Export handles GET /jobs/backup, streaming every job as one
+// store.BackupJob JSON object per line (NDJSON) directly to the
+// response instead of buffering the whole table - see store.ExportJobs.
+// Import (below) reads exactly this format back.
+func (h JobsHandler) Export(w http.ResponseWriter, r *http.Request) {
+	reqID := RequestIDFrom(r.Context())
+	h.Hub.Publish(events.MakeEvent(reqID, events.KindBackupStarted, 1, events.BackupStarted{Mode: "export"}))
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", `attachment; filename="jobhunt-backup.ndjson"`)
+
+	enc := json.NewEncoder(w)
+	n, err := store.ExportJobs(r.Context(), h.DB, func(j store.BackupJob) error {
+		return enc.Encode(j)
+	})
+	if err != nil {
+		h.Hub.Publish(events.MakeEvent(reqID, events.KindBackupCompleted, 1, events.BackupCompleted{Mode: "export", Seen: n, Err: err.Error()}))
+		return
+	}
+	h.Hub.Publish(events.MakeEvent(reqID, events.KindBackupCompleted, 1, events.BackupCompleted{Mode: "export", Seen: n, Inserted: n}))
+}
+
+// Import handles POST /jobs/restore, reading the NDJSON body Export
+// writes and inserting it inside a single transaction via
+// store.ImportJobs, which skips any row already present by ATSJobID or
+// canonicalized URL. Publishes backup_started/backup_progress/
+// backup_completed on h.Hub so a client watching /events sees a long
+// restore's progress instead of just a final response.
+func (h JobsHandler) Import(w http.ResponseWriter, r *http.Request) {
+	reqID := RequestIDFrom(r.Context())
+	h.Hub.Publish(events.MakeEvent(reqID, events.KindBackupStarted, 1, events.BackupStarted{Mode: "import"}))
+
+	seen, inserted, err := store.ImportJobs(r.Context(), h.DB, r.Body, backupProgressEvery, func(seen, inserted int) {
+		h.Hub.Publish(events.MakeEvent(reqID, events.KindBackupProgress, 1, events.BackupProgress{Seen: seen, Inserted: inserted}))
+	})
+	if err != nil {
+		h.Hub.Publish(events.MakeEvent(reqID, events.KindBackupCompleted, 1, events.BackupCompleted{Mode: "import", Seen: seen, Inserted: inserted, Err: err.Error()}))
+		http.Error(w, err.Error(), 500)
+		return
+	}
+
+	h.Hub.Publish(events.MakeEvent(reqID, events.KindBackupCompleted, 1, events.BackupCompleted{Mode: "import", Seen: seen, Inserted: inserted}))
+	writeJSON(w, map[string]any{"ok": true, "seen": seen, "inserted": inserted})
+}
+
 func DeleteJob(ctx context.Context, db *sql.DB, id int64) error {
 	_, err := db.ExecContext(ctx, `DELETE FROM jobs WHERE id = ?;`, id)
 	return err
@@ -18,7 +18,10 @@ type statusWriter struct {
 
 type ctxKey string
 
-const requestIDKey ctxKey = "request_id"
+const (
+	requestIDKey ctxKey = "request_id"
+	rawBytesKey  ctxKey = "raw_bytes"
+)
 
 type Middleware func(http.Handler) http.Handler
 
@@ -58,6 +61,22 @@ func newRequestID() string {
 	return hex.EncodeToString(b[:])
 }
 
+// withRawBytesCounter attaches a *int to ctx that Compress's
+// compressWriter increments with every uncompressed byte it is handed,
+// so AccessLog (which allocates the counter before calling next) can
+// still log the pre-compression size alongside statusWriter's
+// post-compression bytes.
+func withRawBytesCounter(ctx context.Context, n *int) context.Context {
+	return context.WithValue(ctx, rawBytesKey, n)
+}
+
+func rawBytesFrom(ctx context.Context) *int {
+	if v, ok := ctx.Value(rawBytesKey).(*int); ok {
+		return v
+	}
+	return nil
+}
+
 func Chain(h http.Handler, m ...Middleware) http.Handler {
 	for i := len(m) - 1; i >= 0; i-- {
 		h = m[i](h)
@@ -107,12 +126,23 @@ func AccessLog(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 		sw := &statusWriter{ResponseWriter: w}
+
+		rawBytes := new(int)
+		r = r.WithContext(withRawBytesCounter(r.Context(), rawBytes))
 		next.ServeHTTP(sw, r)
 
+		// Compress (if it ran) populated rawBytes with the
+		// pre-compression size; otherwise it's still zero, so the
+		// response was never wrapped and bytes==rawBytes is correct.
+		uncompressed := *rawBytes
+		if uncompressed == 0 {
+			uncompressed = sw.bytes
+		}
+
 		reqID := RequestIDFrom(r.Context())
 		log.Printf(
-			"level=info msg=\"http\" request_id=%s method=%s path=%s status=%d bytes=%d dur_ms=%d",
-			reqID, r.Method, r.URL.Path, sw.status, sw.bytes, time.Since(start).Milliseconds(),
+			"level=info msg=\"http\" request_id=%s method=%s path=%s status=%d bytes=%d uncompressed_bytes=%d dur_ms=%d",
+			reqID, r.Method, r.URL.Path, sw.status, sw.bytes, uncompressed, time.Since(start).Milliseconds(),
 		)
 	})
 }
@@ -0,0 +1,62 @@
+package httpapi
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"jobhunt-engine/internal/config"
+	"jobhunt-engine/internal/pipeline"
+	email_scrape "jobhunt-engine/internal/scrape/email"
+)
+
+// PipelineHandler exposes internal/pipeline for dry-running
+// scoring.pipeline against a real message without touching the
+// mailbox or the DB, so users can try out a rule before saving it.
+type PipelineHandler struct {
+	CfgVal *atomic.Value // stores config.Config
+}
+
+// Dryrun runs cfg.Scoring.Pipeline (or pipeline.DefaultRules, the
+// built-in LinkedIn detection, if Pipeline is empty) against a raw
+// RFC822 blob posted as the request body, returning the ordered list
+// of rules that fired and the resulting pipeline.Result.
+func (h PipelineHandler) Dryrun(w http.ResponseWriter, r *http.Request) {
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	cfg := h.CfgVal.Load().(config.Config)
+	rules, err := config.CompilePipeline(cfg.Scoring.Pipeline)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	_, bodyText, htmlBody, subj := email_scrape.ParseRFC822(raw, "")
+	subj = email_scrape.DecodeRFC2047(subj)
+	from := email_scrape.ParseFromHeader(raw)
+
+	fired, result := pipeline.Run(rules, pipeline.Input{
+		Subject:  subj,
+		From:     from,
+		Domain:   domainOfAddress(from),
+		BodyText: bodyText,
+		HTMLBody: htmlBody,
+	})
+
+	writeJSON(w, map[string]any{"fired": fired, "result": result})
+}
+
+// domainOfAddress pulls the domain out of a From header like
+// "Jane Doe <jane@acme.com>" or a bare "jane@acme.com".
+func domainOfAddress(from string) string {
+	at := strings.LastIndex(from, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.Trim(from[at+1:], "> ")
+}
@@ -3,9 +3,15 @@ package httpapi
 import (
 	"context"
 	"crypto/subtle"
+	"database/sql"
 	"net"
 	"net/http"
 	"time"
+
+	"jobhunt-engine/internal/events"
+	"jobhunt-engine/internal/lifecycle"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // NewMux returns the raw mux so main() can still attach /shutdown (needs srv+token).
@@ -17,12 +23,30 @@ func NewMux(d Deps) *http.ServeMux {
 	mux.HandleFunc("/jobs", methodMux(map[string]http.HandlerFunc{
 		http.MethodGet: jh.List,
 	}))
-	mux.HandleFunc("/jobs/", methodMux(map[string]http.HandlerFunc{
-		http.MethodDelete: jh.DeleteByPath, // expects /jobs/{id}
-	}))
+	// /jobs/{id} (DELETE) and /jobs/{id}/feedback (POST) - see
+	// JobsHandler.ByID for the suffix dispatch.
+	mux.HandleFunc("/jobs/", jh.ByID)
 	mux.HandleFunc("/seed", methodMux(map[string]http.HandlerFunc{
 		http.MethodPost: jh.Seed,
 	}))
+	mux.HandleFunc("/jobs/backup", methodMux(map[string]http.HandlerFunc{
+		http.MethodGet: jh.Export,
+	}))
+	mux.HandleFunc("/jobs/restore", methodMux(map[string]http.HandlerFunc{
+		http.MethodPost: jh.Import,
+	}))
+	mux.HandleFunc("/api/jobs/search", methodMux(map[string]http.HandlerFunc{
+		http.MethodGet: jh.Search,
+	}))
+
+	// Unified single-box search (exact-match job/company lookup,
+	// falling back to ranked jobs_fts) - distinct from /api/jobs/search
+	// above, which is the LIKE-based field-grammar filter for the main
+	// jobs table view.
+	srch := SearchHandler{DB: d.DB}
+	mux.HandleFunc("/api/search", methodMux(map[string]http.HandlerFunc{
+		http.MethodGet: srch.Search,
+	}))
 
 	// Config
 	ch := ConfigHandler{
@@ -46,11 +70,13 @@ func NewMux(d Deps) *http.ServeMux {
 
 	// Scrape
 	sch := ScrapeHandler{
-		DB:             d.DB,
-		CfgVal:         d.CfgVal,
-		ScrapeStatus:   d.ScrapeStatus,
-		Hub:            d.Hub,
-		RunEmailScrape: d.RunEmailScrape,
+		DB:           d.DB,
+		CfgVal:       d.CfgVal,
+		ScrapeStatus: d.ScrapeStatus,
+		Hub:          d.Hub,
+		PollOnce:     d.RunEmailScrape,
+		Lifecycle:    d.Lifecycle,
+		Jobs:         d.Jobs,
 	}
 	mux.HandleFunc("/scrape/status", methodMux(map[string]http.HandlerFunc{
 		http.MethodGet: sch.Status,
@@ -58,23 +84,92 @@ func NewMux(d Deps) *http.ServeMux {
 	mux.HandleFunc("/scrape/run", methodMux(map[string]http.HandlerFunc{
 		http.MethodPost: sch.Run,
 	}))
+	mux.HandleFunc("/scrape/reset", methodMux(map[string]http.HandlerFunc{
+		http.MethodPost: sch.Reset,
+	}))
 
 	// SSE events
 	eh := EventsHandler{Hub: d.Hub}
 	mux.HandleFunc("/events", methodMux(map[string]http.HandlerFunc{
-		http.MethodGet: eh.ServeSSE,
+		http.MethodGet: events.SSEHandler(d.Hub, corsAllowOrigin(d.CfgVal)),
+	}))
+	mux.HandleFunc("/events/stats", methodMux(map[string]http.HandlerFunc{
+		http.MethodGet: eh.Stats,
 	}))
 
 	// Logos
-	lh := LogosHandler{DB: d.DB}
+	lh := LogosHandler{DB: d.DB, DataDir: d.DataDir}
 	mux.HandleFunc("/logo/", methodMux(map[string]http.HandlerFunc{
 		http.MethodGet: lh.GetByPath,
 	}))
 
+	// Bayes relevance feedback
+	bh := BayesHandler{DB: d.DB, Scorer: d.BayesScorer}
+	mux.HandleFunc("/api/bayes/feedback", methodMux(map[string]http.HandlerFunc{
+		http.MethodPost: bh.Feedback,
+	}))
+	mux.HandleFunc("/api/bayes/reset", methodMux(map[string]http.HandlerFunc{
+		http.MethodPost: bh.Reset,
+	}))
+
+	// Bounce webhook (outbound apply-tracking)
+	bnh := BounceHandler{DB: d.DB, CfgVal: d.CfgVal, UserCfgPath: d.UserCfgPath, LoadCfg: d.LoadCfg}
+	mux.HandleFunc("/webhooks/bounce", methodMux(map[string]http.HandlerFunc{
+		http.MethodPost: bnh.Webhook,
+	}))
+
+	// Pipeline dry-run
+	ph := PipelineHandler{CfgVal: d.CfgVal}
+	mux.HandleFunc("/api/pipeline/dryrun", methodMux(map[string]http.HandlerFunc{
+		http.MethodPost: ph.Dryrun,
+	}))
+
+	// Prometheus metrics (internal/metrics registers its collectors via
+	// init() on the default registry, which promhttp.Handler serves)
+	mux.Handle("/metrics", promhttp.Handler())
+
+	// Scrape target health, one entry per source/company target
+	mux.HandleFunc("/targets", methodMux(map[string]http.HandlerFunc{
+		http.MethodGet: TargetsHandler,
+	}))
+
+	hh := HealthHandler{}
+	mux.HandleFunc("/health", methodMux(map[string]http.HandlerFunc{
+		http.MethodGet: hh.Health,
+	}))
+
 	return mux
 }
 
-func ShutdownHandler(token *string, srv *http.Server) http.HandlerFunc {
+// NewHandler wraps NewMux with the full middleware chain: auth (bearer
+// token + per-path HMAC, both gated on cfg.Auth.Enabled), CORS, request
+// ID tagging, panic recovery, gzip/deflate compression, and access
+// logging. Use this instead of NewMux directly so /config PUT,
+// /api/secrets/imap, and /scrape/run aren't reachable by any local
+// process or LAN peer once auth.enabled is turned on.
+//
+// Compress sits directly inside AccessLog (not further down, next to
+// Recover) so statusWriter's byte count reflects what actually went
+// out on the wire post-compression - see Compress's doc comment.
+func NewHandler(d Deps) http.Handler {
+	return Chain(NewMux(d),
+		AccessLog,
+		Compress,
+		Recover,
+		RequestID,
+		Cors,
+		RequireAuth(d.CfgVal),
+	)
+}
+
+// ShutdownHandler authenticates the request exactly as before, then
+// runs lc.Shutdown (canceling the poller, draining in-flight scrapes,
+// closing the SSE hub, flushing db) and returns its Report as the
+// response body before asynchronously calling srv.Shutdown, so a
+// caller can tell which subsystems actually drained cleanly versus
+// timed out. lc == nil degrades to the old behavior (empty report,
+// no draining) for callers that haven't wired a Coordinator.
+func ShutdownHandler(token *string, srv *http.Server, lc *lifecycle.Coordinator, db *sql.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -99,9 +194,11 @@ func ShutdownHandler(token *string, srv *http.Server) http.HandlerFunc {
 			return
 		}
 
-		// Respond immediately, then shutdown asynchronously
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("shutting down\n"))
+		report := lifecycle.Report{Subsystems: map[string]string{}}
+		if lc != nil {
+			report = lc.Shutdown(db)
+		}
+		writeJSON(w, report)
 
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -1,6 +1,7 @@
 package httpapi
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net/http"
@@ -9,23 +10,130 @@ import (
 
 	"jobhunt-engine/internal/config"
 	"jobhunt-engine/internal/events"
+	"jobhunt-engine/internal/jobs"
+	"jobhunt-engine/internal/lifecycle"
+	"jobhunt-engine/internal/metrics"
+	email_scrape "jobhunt-engine/internal/scrape/email"
+	"jobhunt-engine/internal/scrape/targets"
 	"jobhunt-engine/internal/scrape/types"
+	"jobhunt-engine/internal/scrape/util"
+	"jobhunt-engine/internal/store"
 )
 
+// pollSource labels the metrics this handler emits around PollOnce,
+// the same "source" dimension per-ATS scrapers use.
+const pollSource = "poll"
+
 type ScrapeHandler struct {
 	DB           *sql.DB
 	CfgVal       *atomic.Value // config.Config
 	ScrapeStatus *atomic.Value // httpapi.ScrapeStatus
 	Hub          *events.Hub
 	PollOnce     func(db *sql.DB, cfg config.Config, onNewJob func()) (added int, err error)
+
+	// Lifecycle, when set, has Run register itself as in-flight so
+	// ShutdownHandler can wait for it to finish before closing the
+	// SSE hub and DB. Nil runs Run exactly as before.
+	Lifecycle *lifecycle.Coordinator
+
+	// Jobs, when set, has Run enqueue one job per enabled fetcher
+	// instead of running the monolithic PollOnce inline, and has
+	// Status include job_runs rows alongside the legacy ScrapeStatus
+	// snapshot. Nil runs exactly as before.
+	Jobs *jobs.Server
 }
 
 func (h ScrapeHandler) Status(w http.ResponseWriter, r *http.Request) {
 	st := h.ScrapeStatus.Load().(types.ScrapeStatus)
-	writeJSON(w, st)
+
+	// Surface resumable-pagination progress (see store.ATSCheckpoint)
+	// alongside the run status so a UI can show "smartrecruiters: acme
+	// at offset 1400" without a separate round-trip.
+	checkpoints := map[string]any{}
+	for _, name := range types.RegisteredFetcherNames() {
+		cps, err := store.ListATSCheckpoints(r.Context(), h.DB, name)
+		if err != nil || len(cps) == 0 {
+			continue
+		}
+		checkpoints[name] = cps
+	}
+	st.Checkpoints = checkpoints
+
+	if stats, ok := email_scrape.CacheStats(); ok {
+		st.EmailCacheStats = map[string]int64{"hits": stats.Hits, "misses": stats.Misses}
+	}
+
+	if h.Jobs == nil {
+		writeJSON(w, st)
+		return
+	}
+
+	// Jobs is set: report the job_runs queue alongside the legacy
+	// single-slot status so a UI can move to the richer view without
+	// the old one disappearing out from under it mid-rollout.
+	runs, err := h.Jobs.Status(r.Context(), store.JobListOpts{Limit: 50})
+	if err != nil {
+		writeJSON(w, map[string]any{"legacy": st, "jobs_error": err.Error()})
+		return
+	}
+	writeJSON(w, map[string]any{"legacy": st, "jobs": runs})
+}
+
+// Reset clears a persisted ATS pagination checkpoint so the next run
+// for that source (and optional slug) starts over from offset 0,
+// re-scanning postings instead of resuming. POST /scrape/reset?source=smartrecruiters&slug=acme
+// (slug omitted resets every company under that source).
+func (h ScrapeHandler) Reset(w http.ResponseWriter, r *http.Request) {
+	sourceName := r.URL.Query().Get("source")
+	if sourceName == "" {
+		http.Error(w, "source is required", http.StatusBadRequest)
+		return
+	}
+	slug := r.URL.Query().Get("slug")
+
+	if err := store.ResetATSCheckpoint(r.Context(), h.DB, sourceName, slug); err != nil {
+		http.Error(w, fmt.Sprintf("reset checkpoint: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]any{"ok": true})
+}
+
+// runViaJobs enqueues one "scrape:<name>" job per currently-enabled
+// fetcher instead of running PollOnce inline, so each source retries
+// and reports status independently through job_runs.
+func (h ScrapeHandler) runViaJobs(ctx context.Context, cfg config.Config) (int, error) {
+	limiter := util.NewHostLimiter(1.0, 2)
+	enqueued := 0
+	for _, name := range types.RegisteredFetcherNames() {
+		if _, ok := types.BuildFetcher(name, cfg, limiter); !ok {
+			continue
+		}
+		if _, err := h.Jobs.Enqueue(ctx, "scrape:"+name, map[string]any{}); err != nil {
+			return enqueued, err
+		}
+		enqueued++
+	}
+	return enqueued, nil
 }
 
 func (h ScrapeHandler) Run(w http.ResponseWriter, r *http.Request) {
+	if h.Jobs != nil {
+		cfgAny := h.CfgVal.Load()
+		cfg, ok := cfgAny.(config.Config)
+		if !ok {
+			http.Error(w, "config not loaded", http.StatusInternalServerError)
+			return
+		}
+		n, err := h.runViaJobs(r.Context(), cfg)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("enqueue: %v", err), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, map[string]any{"ok": true, "enqueued": n})
+		return
+	}
+
 	st := h.ScrapeStatus.Load().(types.ScrapeStatus)
 	if st.Running {
 		writeJSON(w, map[string]any{"ok": false, "msg": "already running"})
@@ -41,6 +149,10 @@ func (h ScrapeHandler) Run(w http.ResponseWriter, r *http.Request) {
 	})
 
 	go func() {
+		if h.Lifecycle != nil {
+			done := h.Lifecycle.TrackScrape()
+			defer done()
+		}
 		defer func() {
 			if v := recover(); v != nil {
 				now := time.Now().Format(time.RFC3339)
@@ -66,10 +178,12 @@ func (h ScrapeHandler) Run(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		start := time.Now()
 		added, err := h.PollOnce(h.DB, cfg, func() {
 			reqID := RequestIDFrom(r.Context())
 			h.Hub.Publish(events.MakeEvent(reqID, "job_created", 1, nil))
 		})
+		metrics.ScrapeDurationSeconds.WithLabelValues(pollSource, "").Observe(time.Since(start).Seconds())
 
 		now := time.Now().Format(time.RFC3339)
 		nextAny := h.ScrapeStatus.Load()
@@ -79,12 +193,27 @@ func (h ScrapeHandler) Run(w http.ResponseWriter, r *http.Request) {
 		next.LastAdded = added
 		if err != nil {
 			next.LastError = err.Error()
+			metrics.ScrapeErrorsTotal.WithLabelValues(pollSource, "run").Inc()
+			metrics.ScrapeRunsTotal.WithLabelValues(pollSource, "error").Inc()
 		} else {
 			next.LastError = ""
 			next.LastOkAt = now
+			metrics.ScrapeRunsTotal.WithLabelValues(pollSource, "success").Inc()
+			metrics.ScrapeLastSuccessTimestamp.WithLabelValues(pollSource).SetToCurrentTime()
 		}
 		h.ScrapeStatus.Store(next)
 	}()
 
 	writeJSON(w, map[string]any{"ok": true})
 }
+
+// TargetsHandler serves the same internal/scrape/targets.Snapshot the
+// jobhunt_target_up/jobhunt_last_scrape_timestamp_seconds gauges
+// already report at /metrics, as JSON for the UI. GET /targets
+func TargetsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "GET only", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, targets.Snapshot())
+}
@@ -0,0 +1,160 @@
+package httpapi
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"jobhunt-engine/internal/store"
+)
+
+// SearchHandler implements the single search box behind GET
+// /api/search?q=...: an exact-match fast path (source_id, url, or an
+// exact company name) that returns a direct {"type":"job"|"company",
+// ...} pointer, falling back to a ranked jobs_fts query when nothing
+// matches exactly. See internal/store/search.go for the SQL side and
+// parseSearchQuery below for how typed prefixes (company:, tag:,
+// mode:) become jobs_fts column filters.
+type SearchHandler struct {
+	DB *sql.DB
+}
+
+// defaultSearchLimit caps the ranked fallback's result count; callers
+// can raise it with ?limit=.
+const defaultSearchLimit = 20
+
+func (h SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		writeJSON(w, map[string]any{"type": "results", "hits": []store.JobSearchHit{}})
+		return
+	}
+	ctx := r.Context()
+
+	job, err := store.FindJobExact(ctx, h.DB, q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if job != nil {
+		writeJSON(w, map[string]any{"type": "job", "id": job.ID})
+		return
+	}
+
+	if company, ok, err := store.FindCompanyExact(ctx, h.DB, q); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	} else if ok {
+		writeJSON(w, map[string]any{"type": "company", "slug": slugifyCompany(company), "company": company})
+		return
+	}
+
+	limit := defaultSearchLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+
+	hits, err := store.SearchJobs(ctx, h.DB, parseSearchQuery(q), limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]any{"type": "results", "hits": hits})
+}
+
+// searchPrefixColumn maps this endpoint's typed prefixes to the
+// jobs_fts column FTS5 should filter on when it sees them - FTS5
+// natively supports "column:value" inside a MATCH expression, so
+// rewriting here is all the "query AST" needs to do; there's no
+// separate compiler to maintain the way internal/query has one for
+// the LIKE-based /api/jobs/search.
+var searchPrefixColumn = map[string]string{
+	"company": "company",
+	"tag":     "tags",
+	"mode":    "work_mode",
+}
+
+// parseSearchQuery turns q into an FTS5 MATCH expression: tokens with
+// a recognized prefix (company:, tag:, mode:) become "<col>:<value>"
+// column filters, everything else is passed through as free text
+// matched against every jobs_fts column. Tokens are ANDed together.
+func parseSearchQuery(q string) string {
+	var parts []string
+	for _, tok := range splitSearchTokens(q) {
+		field, value, hasField := strings.Cut(tok, ":")
+		if hasField {
+			if col, ok := searchPrefixColumn[strings.ToLower(field)]; ok && value != "" {
+				parts = append(parts, col+":"+ftsQuote(unquoteSearchToken(value)))
+				continue
+			}
+		}
+		parts = append(parts, ftsQuote(unquoteSearchToken(tok)))
+	}
+	return strings.Join(parts, " AND ")
+}
+
+// splitSearchTokens splits q on whitespace, treating a double-quoted
+// span (even mid-token, e.g. tag:"two words") as one token.
+func splitSearchTokens(q string) []string {
+	var out []string
+	var b strings.Builder
+	inQuote := false
+	flush := func() {
+		if b.Len() > 0 {
+			out = append(out, b.String())
+			b.Reset()
+		}
+	}
+	for _, r := range q {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			b.WriteRune(r)
+		case r == ' ' && !inQuote:
+			flush()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	flush()
+	return out
+}
+
+func unquoteSearchToken(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// ftsQuote wraps s in double quotes (escaping any it already
+// contains) so FTS5 treats it as a literal phrase rather than parsing
+// it for its own operators (AND/OR/NOT, "-", "*", ...).
+func ftsQuote(s string) string {
+	s = strings.ReplaceAll(s, `"`, `""`)
+	return `"` + s + `"`
+}
+
+// slugifyCompany lowercases company and collapses runs of anything
+// but letters/digits into a single "-", giving a stable, URL-safe
+// identifier for the {"type":"company"} response - there's no
+// separate companies table to carry a stored slug.
+func slugifyCompany(company string) string {
+	var b strings.Builder
+	lastDash := true
+	for _, r := range strings.ToLower(company) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			lastDash = false
+			continue
+		}
+		if !lastDash {
+			b.WriteByte('-')
+			lastDash = true
+		}
+	}
+	return strings.TrimRight(b.String(), "-")
+}
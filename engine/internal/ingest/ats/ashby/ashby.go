@@ -0,0 +1,124 @@
+package ashby
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/ingest/ats"
+	"jobhunt-engine/internal/scrape/util"
+)
+
+const source = "ashby"
+
+// Config builds a Connector. Limiter and Cache are both optional: a
+// nil Limiter means no per-host rate limiting, a nil Cache means every
+// ListJobs call is an unconditional GET.
+type Config struct {
+	Limiter *util.HostLimiter
+	Cache   ats.ConditionalCache
+}
+
+// Connector talks to Ashby's public job-board API
+// (api.ashbyhq.com/posting-api/job-board/{slug}) directly. It
+// satisfies ats.Connector.
+type Connector struct {
+	cfg Config
+	hc  *http.Client
+}
+
+func New(cfg Config) *Connector {
+	return &Connector{
+		cfg: cfg,
+		hc:  &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+func (c *Connector) Type() string { return source }
+
+type ashbyBoard struct {
+	Jobs []ashbyJob `json:"jobs"`
+}
+
+type ashbyJob struct {
+	ID              string `json:"id"`
+	Title           string `json:"title"`
+	JobURL          string `json:"jobUrl"`
+	Location        string `json:"location"`
+	PublishedAt     string `json:"publishedAt"`
+	DescriptionHTML string `json:"descriptionHtml"`
+	IsRemote        bool   `json:"isRemote"`
+}
+
+// ListJobs fetches company's full job board in one call; Ashby's
+// posting API already returns full description HTML, so there's no
+// separate detail fetch for the common case (see FetchJobDetail). The
+// response is cached via cfg.Cache so an unchanged board costs a 304
+// next poll.
+func (c *Connector) ListJobs(ctx context.Context, company domain.Company) ([]domain.JobLead, error) {
+	slug := ats.SlugFromCareerURL(company.CareerURL)
+	if slug == "" {
+		return nil, fmt.Errorf("ashby: company %q has no board slug in CareerURL %q", company.Name, company.CareerURL)
+	}
+	apiURL := fmt.Sprintf("https://api.ashbyhq.com/posting-api/job-board/%s", slug)
+
+	body, err := ats.FetchConditional(ctx, c.hc, c.cfg.Limiter, c.cfg.Cache, source, slug, apiURL,
+		map[string]string{"User-Agent": "JobHunt/1.0 (+local)"})
+	if err != nil {
+		return nil, fmt.Errorf("ashby list jobs: %w", err)
+	}
+
+	var board ashbyBoard
+	if err := json.Unmarshal(body, &board); err != nil {
+		return nil, fmt.Errorf("ashby decode: %w", err)
+	}
+
+	out := make([]domain.JobLead, 0, len(board.Jobs))
+	for _, j := range board.Jobs {
+		title := strings.TrimSpace(j.Title)
+		if j.ID == "" || j.JobURL == "" || title == "" {
+			continue
+		}
+
+		t := time.Now()
+		if j.PublishedAt != "" {
+			if parsed, err := time.Parse(time.RFC3339, j.PublishedAt); err == nil {
+				t = parsed
+			}
+		}
+
+		loc := util.NormalizeLocation(j.Location)
+		mode := util.InferWorkModeFromText(loc, title, j.DescriptionHTML)
+		if j.IsRemote {
+			mode = "Remote"
+		}
+
+		out = append(out, domain.JobLead{
+			CompanyName:     company.Name,
+			Title:           title,
+			LocationRaw:     loc,
+			WorkMode:        mode,
+			URL:             j.JobURL,
+			PostedAt:        &t,
+			Description:     j.DescriptionHTML,
+			FirstSeenSource: source,
+			ATSJobID:        fmt.Sprintf("ashby:%s:%s", slug, j.ID),
+		})
+	}
+	return out, nil
+}
+
+// FetchJobDetail is a no-op: ListJobs already returns full description
+// HTML for every posting.
+func (c *Connector) FetchJobDetail(ctx context.Context, company domain.Company, lead domain.JobLead) (domain.JobLead, error) {
+	return lead, nil
+}
+
+// HealthCheck reports whether api.ashbyhq.com is reachable.
+func (c *Connector) HealthCheck(ctx context.Context) error {
+	return ats.PingHost(ctx, c.hc, "https://api.ashbyhq.com/posting-api/job-board/_healthcheck")
+}
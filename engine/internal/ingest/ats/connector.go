@@ -6,7 +6,30 @@ import (
 	"jobhunt-engine/internal/domain"
 )
 
+// Connector is the common interface for a first-class ATS API
+// integration (greenhouse, lever, ashby, workday, ...) - as opposed to
+// scrape.Source, which scrapes public board HTML/JSON with no
+// per-vendor API contract. ListJobs lists current postings for one
+// company; FetchJobDetail fills in anything ListJobs left sparse for a
+// single lead (a no-op for vendors whose listing API already returns
+// full descriptions); HealthCheck reports whether the connector's
+// upstream API is currently reachable.
 type Connector interface {
 	Type() string
 	ListJobs(ctx context.Context, company domain.Company) ([]domain.JobLead, error)
+	FetchJobDetail(ctx context.Context, company domain.Company, lead domain.JobLead) (domain.JobLead, error)
+	HealthCheck(ctx context.Context) error
+}
+
+// ConditionalCache lets a Connector persist conditional-GET metadata
+// (ETag/Last-Modified) and the last good response body between runs,
+// so polling an unchanged board costs a 304 instead of a full refetch.
+// It's an interface rather than a direct internal/store dependency
+// because internal/store already imports this package (for
+// Discovered); store.ConnectorCache satisfies it without either
+// package importing the other. Implementations may be nil, in which
+// case every ListJobs call is an unconditional GET.
+type ConditionalCache interface {
+	Get(ctx context.Context, connectorType, cacheKey string) (etag, lastModified string, body []byte, found bool, err error)
+	Put(ctx context.Context, connectorType, cacheKey, etag, lastModified string, body []byte) error
 }
@@ -0,0 +1,10 @@
+package ats
+
+// Discovered is one ATS board slug found by crawling a company's own
+// site (see scrape.DiscoverATS), before it's been confirmed and turned
+// into a config.Company entry.
+type Discovered struct {
+	ATSType    string // "greenhouse", "lever", "workday", "ashby", "smartrecruiters"
+	Slug       string
+	Confidence int // 0-100; higher means a stronger signal (e.g. found on the seed page vs. several hops deep)
+}
@@ -2,15 +2,121 @@ package greenhouse
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
 	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/ingest/ats"
+	"jobhunt-engine/internal/scrape/util"
 )
 
-type Connector struct{}
+const source = "greenhouse"
 
-func (c Connector) Type() string { return "greenhouse" }
+// Config builds a Connector. Limiter and Cache are both optional: a
+// nil Limiter means no per-host rate limiting, a nil Cache means every
+// ListJobs call is an unconditional GET.
+type Config struct {
+	Limiter *util.HostLimiter
+	Cache   ats.ConditionalCache
+}
+
+// Connector talks to Greenhouse's public Job Board API directly
+// (boards-api.greenhouse.io), unlike internal/scrape/greenhouse, which
+// scrapes the rendered boards.greenhouse.io HTML. It satisfies
+// ats.Connector.
+type Connector struct {
+	cfg Config
+	hc  *http.Client
+}
+
+func New(cfg Config) *Connector {
+	return &Connector{
+		cfg: cfg,
+		hc:  &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+func (c *Connector) Type() string { return source }
+
+type ghBoard struct {
+	Jobs []ghJob `json:"jobs"`
+}
+
+type ghJob struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	AbsoluteURL string `json:"absolute_url"`
+	UpdatedAt   string `json:"updated_at"`
+	Content     string `json:"content"` // HTML description, present when content=true
+	Location    struct {
+		Name string `json:"name"`
+	} `json:"location"`
+}
+
+// ListJobs fetches company's full board in one call (content=true
+// returns the HTML description inline, so there's no separate detail
+// fetch for the common case - see FetchJobDetail). The response is
+// cached via cfg.Cache so an unchanged board costs a 304 next poll.
+func (c *Connector) ListJobs(ctx context.Context, company domain.Company) ([]domain.JobLead, error) {
+	slug := ats.SlugFromCareerURL(company.CareerURL)
+	if slug == "" {
+		return nil, fmt.Errorf("greenhouse: company %q has no board slug in CareerURL %q", company.Name, company.CareerURL)
+	}
+	apiURL := fmt.Sprintf("https://boards-api.greenhouse.io/v1/boards/%s/jobs?content=true", slug)
+
+	body, err := ats.FetchConditional(ctx, c.hc, c.cfg.Limiter, c.cfg.Cache, source, slug, apiURL,
+		map[string]string{"User-Agent": "JobHunt/1.0 (+local)"})
+	if err != nil {
+		return nil, fmt.Errorf("greenhouse list jobs: %w", err)
+	}
+
+	var board ghBoard
+	if err := json.Unmarshal(body, &board); err != nil {
+		return nil, fmt.Errorf("greenhouse decode: %w", err)
+	}
+
+	out := make([]domain.JobLead, 0, len(board.Jobs))
+	for _, j := range board.Jobs {
+		title := strings.TrimSpace(j.Title)
+		if j.ID == 0 || title == "" || j.AbsoluteURL == "" {
+			continue
+		}
+
+		t := time.Now()
+		if j.UpdatedAt != "" {
+			if parsed, err := time.Parse(time.RFC3339, j.UpdatedAt); err == nil {
+				t = parsed
+			}
+		}
+
+		loc := util.NormalizeLocation(j.Location.Name)
+		mode := util.InferWorkModeFromText(loc, title, j.Content)
+
+		out = append(out, domain.JobLead{
+			CompanyName:     company.Name,
+			Title:           title,
+			LocationRaw:     loc,
+			WorkMode:        mode,
+			URL:             j.AbsoluteURL,
+			PostedAt:        &t,
+			Description:     j.Content,
+			FirstSeenSource: source,
+			ATSJobID:        fmt.Sprintf("greenhouse:%s:%d", slug, j.ID),
+		})
+	}
+	return out, nil
+}
+
+// FetchJobDetail is a no-op: ListJobs already requested content=true,
+// so every lead's Description is already fully populated.
+func (c *Connector) FetchJobDetail(ctx context.Context, company domain.Company, lead domain.JobLead) (domain.JobLead, error) {
+	return lead, nil
+}
 
-// TODO: implement later. For now returns nothing.
-func (c Connector) ListJobs(ctx context.Context, company domain.Company) ([]domain.JobLead, error) {
-	return nil, nil
+// HealthCheck reports whether boards-api.greenhouse.io is reachable.
+func (c *Connector) HealthCheck(ctx context.Context) error {
+	return ats.PingHost(ctx, c.hc, "https://boards-api.greenhouse.io/v1/boards")
 }
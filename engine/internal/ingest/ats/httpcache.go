@@ -0,0 +1,114 @@
+package ats
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"jobhunt-engine/internal/scrape/util"
+)
+
+// maxConnectorBody caps how much of a board listing response
+// FetchConditional will buffer, protecting memory against a
+// misbehaving or unexpectedly huge tenant.
+const maxConnectorBody = 4 << 20 // 4MB
+
+// FetchConditional issues a GET to rawURL, sending If-None-Match /
+// If-Modified-Since from cache's last entry for (connectorType,
+// cacheKey) if one exists. On a fresh 200 it caches the new
+// ETag/Last-Modified/body before returning it; on a 304 it returns the
+// previously cached body instead of an empty one. cache may be nil, in
+// which case every call is an unconditional GET and nothing is cached.
+func FetchConditional(ctx context.Context, hc *http.Client, limiter *util.HostLimiter, cache ConditionalCache, connectorType, cacheKey, rawURL string, reqHeaders map[string]string) ([]byte, error) {
+	var etag, lastModified string
+	if cache != nil {
+		var err error
+		etag, lastModified, _, _, err = cache.Get(ctx, connectorType, cacheKey)
+		if err != nil {
+			return nil, fmt.Errorf("ats: connector cache lookup: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range reqHeaders {
+		req.Header.Set(k, v)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	if limiter != nil {
+		if err := limiter.WaitURL(ctx, rawURL); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ats: get %s: %w", rawURL, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified {
+		if cache == nil {
+			return nil, fmt.Errorf("ats: got 304 with no cache configured for %s", rawURL)
+		}
+		_, _, body, found, err := cache.Get(ctx, connectorType, cacheKey)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("ats: got 304 but nothing cached for %s", cacheKey)
+		}
+		return body, nil
+	}
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("ats: %s status %d", rawURL, res.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxConnectorBody))
+	if err != nil {
+		return nil, err
+	}
+
+	if cache != nil {
+		if err := cache.Put(ctx, connectorType, cacheKey, res.Header.Get("ETag"), res.Header.Get("Last-Modified"), body); err != nil {
+			return nil, fmt.Errorf("ats: connector cache store: %w", err)
+		}
+	}
+	return body, nil
+}
+
+// PingHost performs a lightweight GET against rawURL and reports an
+// error only if the request fails outright or the server itself
+// errors (5xx) - a 4xx (e.g. an unknown slug on a healthcheck path)
+// still means the API is up, which is all HealthCheck callers care
+// about.
+func PingHost(ctx context.Context, hc *http.Client, rawURL string) error {
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(cctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("ats: ping %s: %w", rawURL, err)
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode >= 500 {
+		return fmt.Errorf("ats: ping %s: status %d", rawURL, res.StatusCode)
+	}
+	return nil
+}
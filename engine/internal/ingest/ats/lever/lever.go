@@ -0,0 +1,115 @@
+package lever
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/ingest/ats"
+	"jobhunt-engine/internal/scrape/util"
+)
+
+const source = "lever"
+
+// Config builds a Connector. Limiter and Cache are both optional: a
+// nil Limiter means no per-host rate limiting, a nil Cache means every
+// ListJobs call is an unconditional GET.
+type Config struct {
+	Limiter *util.HostLimiter
+	Cache   ats.ConditionalCache
+}
+
+// Connector talks to Lever's public postings API
+// (api.lever.co/v0/postings/{slug}) directly. It satisfies
+// ats.Connector.
+type Connector struct {
+	cfg Config
+	hc  *http.Client
+}
+
+func New(cfg Config) *Connector {
+	return &Connector{
+		cfg: cfg,
+		hc:  &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+func (c *Connector) Type() string { return source }
+
+type leverPosting struct {
+	ID         string `json:"id"`
+	Text       string `json:"text"` // title
+	HostedURL  string `json:"hostedUrl"`
+	CreatedAt  int64  `json:"createdAt"` // ms epoch
+	Categories struct {
+		Location string `json:"location"`
+	} `json:"categories"`
+	Description string `json:"description"` // html
+}
+
+// ListJobs fetches company's full posting list in one call; Lever's
+// postings API already returns the full description HTML, so there's
+// no separate detail fetch for the common case (see FetchJobDetail).
+// The response is cached via cfg.Cache so an unchanged board costs a
+// 304 next poll.
+func (c *Connector) ListJobs(ctx context.Context, company domain.Company) ([]domain.JobLead, error) {
+	slug := ats.SlugFromCareerURL(company.CareerURL)
+	if slug == "" {
+		return nil, fmt.Errorf("lever: company %q has no board slug in CareerURL %q", company.Name, company.CareerURL)
+	}
+	apiURL := fmt.Sprintf("https://api.lever.co/v0/postings/%s?mode=json", slug)
+
+	body, err := ats.FetchConditional(ctx, c.hc, c.cfg.Limiter, c.cfg.Cache, source, slug, apiURL,
+		map[string]string{"User-Agent": "JobHunt/1.0 (+local)"})
+	if err != nil {
+		return nil, fmt.Errorf("lever list jobs: %w", err)
+	}
+
+	var postings []leverPosting
+	if err := json.Unmarshal(body, &postings); err != nil {
+		return nil, fmt.Errorf("lever decode: %w", err)
+	}
+
+	out := make([]domain.JobLead, 0, len(postings))
+	for _, p := range postings {
+		title := strings.TrimSpace(p.Text)
+		if p.ID == "" || p.HostedURL == "" || title == "" {
+			continue
+		}
+
+		t := time.Now()
+		if p.CreatedAt > 0 {
+			t = time.UnixMilli(p.CreatedAt)
+		}
+		loc := util.NormalizeLocation(p.Categories.Location)
+		mode := util.InferWorkModeFromText(loc, title, p.Description)
+
+		out = append(out, domain.JobLead{
+			CompanyName:     company.Name,
+			Title:           title,
+			LocationRaw:     loc,
+			WorkMode:        mode,
+			URL:             p.HostedURL,
+			PostedAt:        &t,
+			Description:     p.Description,
+			FirstSeenSource: source,
+			ATSJobID:        fmt.Sprintf("lever:%s:%s", slug, p.ID),
+		})
+	}
+	return out, nil
+}
+
+// FetchJobDetail is a no-op: ListJobs's mode=json response already
+// includes the full description HTML for every posting.
+func (c *Connector) FetchJobDetail(ctx context.Context, company domain.Company, lead domain.JobLead) (domain.JobLead, error) {
+	return lead, nil
+}
+
+// HealthCheck reports whether api.lever.co is reachable.
+func (c *Connector) HealthCheck(ctx context.Context) error {
+	return ats.PingHost(ctx, c.hc, "https://api.lever.co/v0/postings")
+}
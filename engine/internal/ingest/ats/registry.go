@@ -0,0 +1,37 @@
+package ats
+
+import "sync"
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Connector{}
+)
+
+// Register adds (or replaces) a Connector in the package-level
+// connector registry, keyed by its Type(). Call once at startup, after
+// building each Connector from config.
+func Register(c Connector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[c.Type()] = c
+}
+
+// Get returns the registered Connector for atsType, or ok=false if
+// none has been registered.
+func Get(atsType string) (Connector, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	c, ok := registry[atsType]
+	return c, ok
+}
+
+// Connectors returns every currently registered Connector.
+func Connectors() []Connector {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	out := make([]Connector, 0, len(registry))
+	for _, c := range registry {
+		out = append(out, c)
+	}
+	return out
+}
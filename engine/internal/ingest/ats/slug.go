@@ -0,0 +1,22 @@
+package ats
+
+import (
+	"net/url"
+	"strings"
+)
+
+// SlugFromCareerURL extracts the board slug from a company's
+// CareerURL - e.g. "https://boards.greenhouse.io/acme" or
+// "https://jobs.lever.co/acme/" both yield "acme". Returns "" if
+// rawURL has no path segment to take one from.
+func SlugFromCareerURL(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return ""
+	}
+	segs := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segs) == 0 {
+		return ""
+	}
+	return segs[0]
+}
@@ -0,0 +1,262 @@
+package workday
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/ingest/ats"
+	"jobhunt-engine/internal/scrape/util"
+)
+
+const source = "workday"
+
+// Config builds a Connector. Limiter and Cache are both optional: a
+// nil Limiter means no per-host rate limiting. Cache is accepted so
+// Config has the same shape as the other connectors', but Connector
+// never reads it: every page request is an unconditional POST.
+type Config struct {
+	Limiter *util.HostLimiter
+	Cache   ats.ConditionalCache
+}
+
+// Connector talks to Workday's CXS jobs API
+// (/wday/cxs/{tenant}/{site}/jobs) directly, paging through results
+// via a numeric cursor Workday calls "offset". It satisfies
+// ats.Connector.
+type Connector struct {
+	cfg Config
+	hc  *http.Client
+}
+
+func New(cfg Config) *Connector {
+	return &Connector{
+		cfg: cfg,
+		hc:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *Connector) Type() string { return source }
+
+type board struct {
+	Scheme string
+	Host   string
+	Tenant string
+	Site   string
+}
+
+// parseBoardURL pulls {tenant}/{site} out of a full Workday board URL
+// like "https://acme.wd1.myworkdayjobs.com/en-US/External" - the
+// tenant is the subdomain's first label, the site is the path's final
+// segment (locale prefixes like "en-US" are skipped).
+func parseBoardURL(raw string) (board, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return board{}, fmt.Errorf("workday: invalid board url %q", raw)
+	}
+	if u.Scheme == "" {
+		u.Scheme = "https"
+	}
+
+	labels := strings.Split(u.Host, ".")
+	if len(labels) < 3 {
+		return board{}, fmt.Errorf("workday: unexpected host %q", u.Host)
+	}
+
+	segs := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segs) == 0 || segs[len(segs)-1] == "" {
+		return board{}, fmt.Errorf("workday: unexpected path %q", u.Path)
+	}
+
+	return board{
+		Scheme: u.Scheme,
+		Host:   u.Host,
+		Tenant: labels[0],
+		Site:   segs[len(segs)-1],
+	}, nil
+}
+
+func (b board) jobsEndpoint() string {
+	return fmt.Sprintf("%s://%s/wday/cxs/%s/%s/jobs", b.Scheme, b.Host, b.Tenant, b.Site)
+}
+
+func (b board) jobDetailEndpoint(externalPath string) string {
+	return fmt.Sprintf("%s://%s/wday/cxs/%s/%s/job%s", b.Scheme, b.Host, b.Tenant, b.Site, externalPath)
+}
+
+type wdListRequest struct {
+	AppliedFacets map[string]any `json:"appliedFacets"`
+	Limit         int            `json:"limit"`
+	Offset        int            `json:"offset"`
+	SearchText    string         `json:"searchText"`
+}
+
+type wdListResponse struct {
+	Total       int         `json:"total"`
+	JobPostings []wdPosting `json:"jobPostings"`
+}
+
+type wdPosting struct {
+	Title         string `json:"title"`
+	ExternalPath  string `json:"externalPath"`
+	LocationsText string `json:"locationsText"`
+	PostedOn      string `json:"postedOn"`
+	JobReqID      string `json:"bulletFields"`
+}
+
+type wdJobDetail struct {
+	JobPostingInfo struct {
+		JobDescription string `json:"jobDescription"`
+		PostedOn       string `json:"postedOn"`
+	} `json:"jobPostingInfo"`
+}
+
+const (
+	wdPageSize = 20
+	wdMaxPages = 50 // backstop: 1000 postings is far past any tenant this connector targets
+)
+
+// ListJobs pages through board.jobsEndpoint via the numeric cursor
+// Workday's API calls "offset", accumulating every posting until a
+// page comes back empty, the offset reaches the reported total, or
+// wdMaxPages is hit. Listing responses don't
+// include the job description - FetchJobDetail fills that in per
+// lead.
+func (c *Connector) ListJobs(ctx context.Context, company domain.Company) ([]domain.JobLead, error) {
+	b, err := parseBoardURL(company.CareerURL)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := b.jobsEndpoint()
+
+	var out []domain.JobLead
+	cursor := 0
+	for page := 0; page < wdMaxPages; page++ {
+		reqBody, _ := json.Marshal(wdListRequest{
+			AppliedFacets: map[string]any{},
+			Limit:         wdPageSize,
+			Offset:        cursor,
+		})
+
+		if c.cfg.Limiter != nil {
+			if err := c.cfg.Limiter.WaitURL(ctx, endpoint); err != nil {
+				return out, err
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(reqBody))
+		if err != nil {
+			return out, err
+		}
+		req.Header.Set("User-Agent", "JobHunt/1.0 (+local)")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+
+		res, err := c.hc.Do(req)
+		if err != nil {
+			return out, fmt.Errorf("workday list jobs: %w", err)
+		}
+		data, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return out, err
+		}
+		if res.StatusCode >= 400 {
+			return out, fmt.Errorf("workday list jobs: status %d", res.StatusCode)
+		}
+
+		var lr wdListResponse
+		if err := json.Unmarshal(data, &lr); err != nil {
+			return out, fmt.Errorf("workday decode: %w", err)
+		}
+		if len(lr.JobPostings) == 0 {
+			break
+		}
+
+		for _, p := range lr.JobPostings {
+			title := strings.TrimSpace(p.Title)
+			if title == "" || p.ExternalPath == "" {
+				continue
+			}
+			loc := util.NormalizeLocation(p.LocationsText)
+			mode := util.InferWorkModeFromText(loc, title, "")
+			out = append(out, domain.JobLead{
+				CompanyName:     company.Name,
+				Title:           title,
+				LocationRaw:     loc,
+				WorkMode:        mode,
+				URL:             fmt.Sprintf("%s://%s%s", b.Scheme, b.Host, p.ExternalPath),
+				FirstSeenSource: source,
+				ATSJobID:        fmt.Sprintf("workday:%s:%s:%s", b.Tenant, b.Site, p.ExternalPath),
+			})
+		}
+
+		cursor += wdPageSize
+		if cursor >= lr.Total {
+			break
+		}
+	}
+	return out, nil
+}
+
+// FetchJobDetail GETs lead's job detail page (listing responses don't
+// include a description) and fills in Description. When the detail
+// has a postedOn value and lead.PostedAt is unset, PostedAt is set to
+// the current time; postedOn itself is not parsed.
+func (c *Connector) FetchJobDetail(ctx context.Context, company domain.Company, lead domain.JobLead) (domain.JobLead, error) {
+	b, err := parseBoardURL(company.CareerURL)
+	if err != nil {
+		return lead, err
+	}
+
+	externalPath := strings.TrimPrefix(lead.URL, fmt.Sprintf("%s://%s", b.Scheme, b.Host))
+	endpoint := b.jobDetailEndpoint(externalPath)
+
+	if c.cfg.Limiter != nil {
+		if err := c.cfg.Limiter.WaitURL(ctx, endpoint); err != nil {
+			return lead, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return lead, err
+	}
+	req.Header.Set("User-Agent", "JobHunt/1.0 (+local)")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.hc.Do(req)
+	if err != nil {
+		return lead, fmt.Errorf("workday job detail: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 400 {
+		return lead, fmt.Errorf("workday job detail: status %d", res.StatusCode)
+	}
+
+	var detail wdJobDetail
+	if err := json.NewDecoder(res.Body).Decode(&detail); err != nil {
+		return lead, fmt.Errorf("workday job detail decode: %w", err)
+	}
+
+	lead.Description = detail.JobPostingInfo.JobDescription
+	if detail.JobPostingInfo.PostedOn != "" && lead.PostedAt == nil {
+		t := time.Now()
+		lead.PostedAt = &t
+	}
+	return lead, nil
+}
+
+// HealthCheck reports whether https://www.myworkday.com is reachable.
+// Workday is multi-tenant with no global status endpoint, and this
+// does not check any tenant's own board host.
+func (c *Connector) HealthCheck(ctx context.Context) error {
+	return ats.PingHost(ctx, c.hc, "https://www.myworkday.com")
+}
@@ -0,0 +1,361 @@
+// Package jmap_scrape mirrors email_scrape.RunEmailScrapeOnce but talks
+// JMAP (RFC 8620/8621) directly over HTTPS instead of IMAP: providers
+// like Fastmail and Stalwart increasingly don't support IMAP app
+// passwords, and JMAP's Email/changes lets a poll ask "what changed
+// since state X" instead of re-running Email/query against the whole
+// mailbox every time. It reuses email_scrape's RFC822 parsing, LinkedIn
+// job-alert parser and jobs-table sink so both backends dedupe into the
+// same rows.
+package jmap_scrape
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"time"
+
+	"jobhunt-engine/internal/config"
+	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/events"
+	"jobhunt-engine/internal/rank"
+	emailscrape "jobhunt-engine/internal/scrape/email"
+	"jobhunt-engine/internal/secrets"
+	"jobhunt-engine/internal/store"
+
+	"git.sr.ht/~rockorager/go-jmap"
+	"git.sr.ht/~rockorager/go-jmap/mail"
+	"git.sr.ht/~rockorager/go-jmap/mail/email"
+	"git.sr.ht/~rockorager/go-jmap/mail/mailbox"
+)
+
+const (
+	defaultMaxMessages = 200
+	maxAdds            = 100
+)
+
+// rawMessage is one Email/get hit plus its downloaded raw blob, enough
+// to run through the same parsing RunEmailScrapeOnce uses.
+type rawMessage struct {
+	id      string
+	from    string
+	subject string
+	date    time.Time
+	raw     []byte
+}
+
+// RunJMAPScrapeOnce authenticates against cfg.Sources.JMAP, lists new
+// mail in the configured mailbox (a full Email/query on the first run,
+// an Email/changes diff on every run after), extracts job leads from
+// LinkedIn-style alerts, scores and inserts them, and persists the new
+// JMAP state so the next run only sees what changed. bus may be nil.
+func RunJMAPScrapeOnce(db *sql.DB, cfg config.Config, bus *events.Bus) (added int, err error) {
+	jcfg := cfg.Sources.JMAP
+	if !jcfg.Enabled {
+		return 0, nil
+	}
+	if db == nil {
+		return 0, fmt.Errorf("jmap_scrape: db is nil")
+	}
+	if jcfg.SessionEndpoint == "" {
+		return 0, fmt.Errorf("jmap_scrape: missing sources.jmap.session_endpoint")
+	}
+
+	token, err := secrets.Resolve(cfg)
+	if err != nil {
+		return 0, fmt.Errorf("resolve jmap access token: %w", err)
+	}
+
+	client := &jmap.Client{SessionEndpoint: jcfg.SessionEndpoint}
+	client.WithAccessToken(token)
+	if err := client.Authenticate(); err != nil {
+		return 0, fmt.Errorf("jmap authenticate: %w", err)
+	}
+	accountID, ok := client.Session.PrimaryAccounts[mail.URI]
+	if !ok {
+		return 0, fmt.Errorf("jmap: session has no primary mail account")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	mailboxName := jcfg.Mailbox
+	if mailboxName == "" {
+		mailboxName = "inbox"
+	}
+	mailboxID, err := resolveMailbox(ctx, client, accountID, mailboxName)
+	if err != nil {
+		return 0, err
+	}
+
+	prevState, err := store.GetJMAPState(ctx, db, jcfg.SessionEndpoint)
+	if err != nil {
+		return 0, fmt.Errorf("load jmap state: %w", err)
+	}
+
+	ids, newState, err := fetchIDs(ctx, client, accountID, mailboxID, jcfg, prevState)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		if newState != "" && newState != prevState {
+			if err := store.SetJMAPState(ctx, db, jcfg.SessionEndpoint, newState); err != nil {
+				return 0, fmt.Errorf("save jmap state: %w", err)
+			}
+		}
+		return 0, nil
+	}
+
+	msgs, err := fetchMessages(ctx, client, accountID, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	scorer := rank.YAMLScorer{Cfg: cfg}
+	processed := make([]string, 0, len(msgs))
+
+runLoop:
+	for _, m := range msgs {
+		_, _, htmlBody, subj := emailscrape.ParseRFC822(m.raw, m.subject)
+		subj = emailscrape.DecodeRFC2047(subj)
+
+		liJobs, perr := emailscrape.ParseLinkedInJobAlertHTML(htmlBody)
+		if perr != nil || len(liJobs) == 0 {
+			processed = append(processed, m.id)
+			continue
+		}
+
+		for _, lj := range liJobs {
+			sid := lj.SourceID
+			if sid == "" {
+				sid = emailscrape.MakeSourceID(m.id, lj.URL, subj, m.from)
+			}
+			if sid == "" {
+				continue
+			}
+
+			workMode := emailscrape.InferWorkMode(lj.Location, subj)
+			lead := domain.JobLead{
+				CompanyName:     lj.Company,
+				Title:           lj.Title,
+				URL:             lj.URL,
+				LocationRaw:     lj.Location,
+				WorkMode:        workMode,
+				PostedAt:        &m.date,
+				FirstSeenSource: "jmap",
+			}
+			score, tags := scorer.Score(lead)
+
+			row := emailscrape.JobRow{
+				Company:    lj.Company,
+				Title:      lj.Title,
+				Location:   lj.Location,
+				WorkMode:   workMode,
+				URL:        lj.URL,
+				Score:      score,
+				Tags:       tags,
+				ReceivedAt: m.date.Local(),
+				SourceID:   sid,
+			}
+
+			id, inserted, ierr := emailscrape.InsertJobIfNew(ctx, db, row)
+			if ierr != nil {
+				continue
+			}
+			if inserted {
+				added++
+				if bus != nil {
+					bus.Publish(ctx, events.JobInserted{
+						ID:       id,
+						Company:  row.Company,
+						Title:    row.Title,
+						URL:      row.URL,
+						Score:    row.Score,
+						SourceID: row.SourceID,
+					})
+				}
+				if added >= maxAdds {
+					processed = append(processed, m.id)
+					break runLoop
+				}
+			}
+		}
+
+		processed = append(processed, m.id)
+	}
+
+	if len(processed) > 0 {
+		if err := markSeen(ctx, client, accountID, processed); err != nil {
+			return added, fmt.Errorf("mark processed: %w", err)
+		}
+	}
+
+	if newState != "" {
+		if err := store.SetJMAPState(ctx, db, jcfg.SessionEndpoint, newState); err != nil {
+			return added, fmt.Errorf("save jmap state: %w", err)
+		}
+	}
+
+	if bus != nil {
+		bus.Publish(ctx, events.ScrapeRunCompleted{Added: added, Mailbox: mailboxName})
+	}
+
+	return added, nil
+}
+
+// resolveMailbox looks up a mailbox by name, falling back to role,
+// since Email/query filters on a mailbox ID rather than a name.
+func resolveMailbox(ctx context.Context, client *jmap.Client, accountID jmap.ID, name string) (jmap.ID, error) {
+	req := &jmap.Request{Context: ctx}
+	req.Invoke(&mailbox.Get{Account: accountID})
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("jmap mailbox get: %w", err)
+	}
+
+	for _, inv := range resp.Responses {
+		get, ok := inv.Args.(*mailbox.GetResponse)
+		if !ok {
+			continue
+		}
+		for _, mb := range get.List {
+			if mb.Name == name || string(mb.Role) == name {
+				return mb.ID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("jmap: no mailbox named %q", name)
+}
+
+// jmapSubjectFilter builds an AND(InMailbox, OR(subject...)) filter,
+// the JMAP equivalent of pushing SearchSubjectAny down into the query
+// the way email_scrape.jmapFilter does for the "jmap" email backend.
+func jmapSubjectFilter(mailboxID jmap.ID, subjectAny []string) email.Filter {
+	and := &email.FilterOperator{Operator: jmap.OperatorAND}
+	and.Conditions = append(and.Conditions, &email.FilterCondition{InMailbox: mailboxID})
+
+	if len(subjectAny) > 0 {
+		or := &email.FilterOperator{Operator: jmap.OperatorOR}
+		for _, s := range subjectAny {
+			or.Conditions = append(or.Conditions, &email.FilterCondition{Subject: s})
+		}
+		and.Conditions = append(and.Conditions, or)
+	}
+
+	return and
+}
+
+// fetchIDs returns the Email IDs to process plus the JMAP state to
+// persist afterward. With no prevState (first run, or a server that's
+// expired our state) it runs a full Email/query capped at MaxMessages;
+// otherwise it diffs via Email/changes and returns only what's new.
+func fetchIDs(ctx context.Context, client *jmap.Client, accountID, mailboxID jmap.ID, jcfg config.JMAPSourceConfig, prevState string) (ids []jmap.ID, newState string, err error) {
+	if prevState == "" {
+		limit := uint64(jcfg.MaxMessages)
+		if limit == 0 {
+			limit = defaultMaxMessages
+		}
+
+		req := &jmap.Request{Context: ctx}
+		req.Invoke(&email.Query{
+			Account: accountID,
+			Filter:  jmapSubjectFilter(mailboxID, jcfg.SubjectAny),
+			Sort:    []*email.SortComparator{{Property: "receivedAt", IsAscending: false}},
+			Limit:   limit,
+		})
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, "", fmt.Errorf("jmap query: %w", err)
+		}
+		for _, inv := range resp.Responses {
+			q, ok := inv.Args.(*email.QueryResponse)
+			if !ok {
+				continue
+			}
+			ids = append(ids, q.IDs...)
+			newState = q.QueryState
+		}
+		return ids, newState, nil
+	}
+
+	req := &jmap.Request{Context: ctx}
+	req.Invoke(&email.Changes{Account: accountID, SinceState: prevState})
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("jmap changes: %w", err)
+	}
+	for _, inv := range resp.Responses {
+		c, ok := inv.Args.(*email.ChangesResponse)
+		if !ok {
+			continue
+		}
+		ids = append(ids, c.Created...)
+		ids = append(ids, c.Updated...)
+		newState = c.NewState
+	}
+	return ids, newState, nil
+}
+
+// fetchMessages fetches each id's Email object and downloads its raw
+// RFC822 blob (Email.BlobID is the whole message, RFC 8621 section
+// 4.1.1).
+func fetchMessages(ctx context.Context, client *jmap.Client, accountID jmap.ID, ids []jmap.ID) ([]rawMessage, error) {
+	req := &jmap.Request{Context: ctx}
+	req.Invoke(&email.Get{Account: accountID, IDs: ids})
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("jmap get: %w", err)
+	}
+
+	var out []rawMessage
+	for _, inv := range resp.Responses {
+		get, ok := inv.Args.(*email.GetResponse)
+		if !ok {
+			continue
+		}
+		for _, e := range get.List {
+			rc, err := client.DownloadWithContext(ctx, accountID, e.BlobID)
+			if err != nil {
+				return nil, fmt.Errorf("jmap download %s: %w", e.ID, err)
+			}
+			raw, rerr := io.ReadAll(rc)
+			rc.Close()
+			if rerr != nil {
+				return nil, fmt.Errorf("jmap download %s: %w", e.ID, rerr)
+			}
+
+			m := rawMessage{id: string(e.ID), subject: e.Subject, raw: raw}
+			if e.ReceivedAt != nil {
+				m.date = *e.ReceivedAt
+			}
+			if len(e.From) > 0 && e.From[0] != nil {
+				m.from = e.From[0].Email
+			}
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+const seenKeyword = "$seen"
+
+// markSeen sets the $seen keyword on each processed email ID, the JMAP
+// equivalent of IMAP's \Seen.
+func markSeen(ctx context.Context, client *jmap.Client, accountID jmap.ID, ids []string) error {
+	update := make(map[jmap.ID]jmap.Patch, len(ids))
+	for _, id := range ids {
+		update[jmap.ID(id)] = jmap.Patch{"keywords/" + seenKeyword: true}
+	}
+
+	req := &jmap.Request{Context: ctx}
+	req.Invoke(&email.Set{Account: accountID, Update: update})
+
+	if _, err := client.Do(req); err != nil {
+		return fmt.Errorf("jmap mark seen: %w", err)
+	}
+	return nil
+}
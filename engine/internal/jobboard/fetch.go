@@ -0,0 +1,76 @@
+package jobboard
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"jobhunt-engine/internal/fetch"
+	"jobhunt-engine/internal/jobboard/pagecache"
+	"jobhunt-engine/internal/metrics"
+)
+
+// DefaultCacheTTL is how long a fetched page stays fresh in
+// responseCache once EnableMemoryCache/EnableDiskCache is called with
+// ttl <= 0.
+const DefaultCacheTTL = pagecache.DefaultTTL
+
+// responseCache backs fetchPage for every Source. It's nil (caching
+// off) until EnableMemoryCache or EnableDiskCache is called.
+var responseCache pagecache.Cache
+
+// EnableMemoryCache turns on an in-memory, TTL'd cache (see
+// pagecache.NewMemory) for fetchPage. ttl <= 0 uses pagecache.DefaultTTL
+// (5 minutes); capacity <= 0 uses its own default.
+func EnableMemoryCache(capacity int, ttl time.Duration) {
+	responseCache = pagecache.NewMemory(capacity, ttl)
+}
+
+// EnableDiskCache is EnableMemoryCache plus persistence under dir
+// (~/.cache/jobhunt if dir == "") so a cached page survives between
+// `jobhunt search` invocations — handy for re-parsing after a
+// selector change without refetching.
+func EnableDiskCache(dir string, capacity int, ttl time.Duration) error {
+	c, err := pagecache.NewDisk(dir, capacity, ttl)
+	if err != nil {
+		return err
+	}
+	responseCache = c
+	return nil
+}
+
+// fetchPage GETs rawURL through f (rate limiting, backoff and the
+// User-Agent pool are its job — see internal/fetch), serving a cached
+// body when responseCache is warm for it and populating the cache on
+// a live fetch. source labels metrics/errors the same way each
+// Source.Fetch already does for its own requests.
+func fetchPage(ctx context.Context, f *fetch.Fetcher, rawURL, source string) ([]byte, error) {
+	if responseCache != nil {
+		if body, ok := responseCache.Get(rawURL); ok {
+			return body, nil
+		}
+	}
+
+	start := time.Now()
+	res, err := f.Get(ctx, rawURL)
+	if err != nil {
+		metrics.ScrapeErrorsTotal.WithLabelValues(source, "http").Inc()
+		return nil, fmt.Errorf("%s fetch: %w", source, err)
+	}
+	defer res.Body.Close()
+	metrics.ScrapeDurationSeconds.WithLabelValues(source, "").Observe(time.Since(start).Seconds())
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("%s fetch status %d", source, res.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 10<<20))
+	if err != nil {
+		return nil, fmt.Errorf("%s fetch: read body: %w", source, err)
+	}
+
+	if responseCache != nil {
+		responseCache.Set(rawURL, body)
+	}
+	return body, nil
+}
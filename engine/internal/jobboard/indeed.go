@@ -0,0 +1,112 @@
+package jobboard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/fetch"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const indeedSource = "indeed"
+
+// indeedPageSize is how many results kr.indeed.com returns per page;
+// Fetch walks &start=0, &start=indeedPageSize, ... until a page comes
+// back empty or maxPages is hit.
+const (
+	indeedPageSize = 15
+	indeedMaxPages = 5
+)
+
+type indeedScraper struct {
+	fetcher *fetch.Fetcher
+	host    string // e.g. "kr.indeed.com"
+}
+
+// NewIndeed scrapes host, Indeed's country-specific site (e.g.
+// "kr.indeed.com" for South Korea, "www.indeed.com" for the US).
+func NewIndeed(host string) Source {
+	return &indeedScraper{fetcher: fetch.New(), host: host}
+}
+
+func (s *indeedScraper) Name() string { return indeedSource }
+
+func (s *indeedScraper) Match(u *url.URL) bool {
+	return hostMatches(u, "indeed.com")
+}
+
+// Canonicalize keeps only the jk (job key) query param, Indeed's
+// stable per-posting identifier; everything else (tk, from, session
+// tracking) varies per visit.
+func (s *indeedScraper) Canonicalize(u *url.URL) string {
+	jk := u.Query().Get("jk")
+	if jk == "" {
+		return "https://" + u.Host + u.Path
+	}
+	return fmt.Sprintf("https://%s/viewjob?jk=%s", u.Host, jk)
+}
+
+func (s *indeedScraper) Fetch(ctx context.Context, query string) ([]domain.JobLead, error) {
+	var out []domain.JobLead
+
+	for page := 0; page < indeedMaxPages; page++ {
+		searchURL := fmt.Sprintf("https://%s/jobs?%s", s.host, url.Values{
+			"q":     {query},
+			"start": {fmt.Sprint(page * indeedPageSize)},
+		}.Encode())
+
+		body, err := fetchPage(ctx, s.fetcher, searchURL, indeedSource)
+		if err != nil {
+			return out, fmt.Errorf("indeed search page %d: %w", page, err)
+		}
+
+		doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+		if err != nil {
+			return out, fmt.Errorf("indeed parse search results: %w", err)
+		}
+
+		found := 0
+		doc.Find("div.job_seen_beacon").Each(func(_ int, card *goquery.Selection) {
+			href, _ := card.Find("h2.jobTitle a").Attr("href")
+			if href == "" {
+				return
+			}
+			abs, err := url.Parse("https://" + s.host + href)
+			if err != nil {
+				return
+			}
+			found++
+			out = append(out, domain.JobLead{
+				CompanyName:     strings.TrimSpace(card.Find("span.companyName").Text()),
+				Title:           strings.TrimSpace(card.Find("h2.jobTitle").Text()),
+				LocationRaw:     strings.TrimSpace(card.Find("div.companyLocation").Text()),
+				URL:             s.Canonicalize(abs),
+				FirstSeenSource: indeedSource,
+			})
+		})
+
+		if found == 0 {
+			break
+		}
+	}
+
+	return out, nil
+}
+
+func (s *indeedScraper) Parse(html string) (domain.JobLead, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return domain.JobLead{}, fmt.Errorf("indeed parse job page: %w", err)
+	}
+	return domain.JobLead{
+		Title:           strings.TrimSpace(doc.Find("h1.jobsearch-JobInfoHeader-title").First().Text()),
+		CompanyName:     strings.TrimSpace(doc.Find("div[data-company-name] a").First().Text()),
+		Description:     strings.TrimSpace(doc.Find("div#jobDescriptionText").Text()),
+		FirstSeenSource: indeedSource,
+	}, nil
+}
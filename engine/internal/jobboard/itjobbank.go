@@ -0,0 +1,88 @@
+package jobboard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/fetch"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const itjobbankSource = "itjobbank"
+
+type itjobbankScraper struct {
+	fetcher *fetch.Fetcher
+}
+
+func NewITJobBank() Source {
+	return &itjobbankScraper{fetcher: fetch.New()}
+}
+
+func (s *itjobbankScraper) Name() string { return itjobbankSource }
+
+func (s *itjobbankScraper) Match(u *url.URL) bool {
+	return hostMatches(u, "itjobbank.co.kr")
+}
+
+// Canonicalize keeps only the idx query param, ITJobBank's per-posting
+// identifier.
+func (s *itjobbankScraper) Canonicalize(u *url.URL) string {
+	idx := u.Query().Get("idx")
+	if idx == "" {
+		return "https://" + u.Host + u.Path
+	}
+	return "https://www.itjobbank.co.kr/recruit/rs_view?idx=" + idx
+}
+
+func (s *itjobbankScraper) Fetch(ctx context.Context, query string) ([]domain.JobLead, error) {
+	searchURL := "https://www.itjobbank.co.kr/recruit/rs_search?" + url.Values{"sc_word": {query}}.Encode()
+
+	body, err := fetchPage(ctx, s.fetcher, searchURL, itjobbankSource)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("itjobbank parse search results: %w", err)
+	}
+
+	var out []domain.JobLead
+	doc.Find("li.list_item").Each(func(_ int, card *goquery.Selection) {
+		href, _ := card.Find("a.company_link").Attr("href")
+		if href == "" {
+			return
+		}
+		abs, err := url.Parse("https://www.itjobbank.co.kr" + href)
+		if err != nil {
+			return
+		}
+		out = append(out, domain.JobLead{
+			CompanyName:     strings.TrimSpace(card.Find("span.company_name").Text()),
+			Title:           strings.TrimSpace(card.Find("span.tit").Text()),
+			LocationRaw:     strings.TrimSpace(card.Find("span.area").Text()),
+			URL:             s.Canonicalize(abs),
+			FirstSeenSource: itjobbankSource,
+		})
+	})
+
+	return out, nil
+}
+
+func (s *itjobbankScraper) Parse(html string) (domain.JobLead, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return domain.JobLead{}, fmt.Errorf("itjobbank parse job page: %w", err)
+	}
+	return domain.JobLead{
+		Title:           strings.TrimSpace(doc.Find("h3.recruit_tit").First().Text()),
+		CompanyName:     strings.TrimSpace(doc.Find("span.co_name").First().Text()),
+		Description:     strings.TrimSpace(doc.Find("div.recruit_detail").Text()),
+		FirstSeenSource: itjobbankSource,
+	}, nil
+}
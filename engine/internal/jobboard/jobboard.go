@@ -0,0 +1,93 @@
+// Package jobboard scrapes general job-search sites (LinkedIn, Indeed,
+// Saramin, ITJobBank, TheHub) by query, as opposed to internal/scrape's
+// ATS board scrapers which walk one company's career page. URL
+// canonicalization used to live as LinkedIn-only logic inline in the
+// email scraper; Source.Canonicalize generalizes it per site.
+package jobboard
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+
+	"jobhunt-engine/internal/domain"
+)
+
+// Source is one job board: Match/Canonicalize let callers normalize an
+// arbitrary job URL (e.g. for dedup) without knowing which site it came
+// from, while Fetch/Parse drive `jobhunt search`.
+type Source interface {
+	Name() string
+
+	// Match reports whether u belongs to this source, e.g. by hostname.
+	Match(u *url.URL) bool
+
+	// Canonicalize strips tracking params and alternate path forms down
+	// to the stable identifier this source's URLs carry (a job ID, a
+	// normalized path, ...). u must already satisfy Match.
+	Canonicalize(u *url.URL) string
+
+	// Fetch runs query against the source's search endpoint and returns
+	// whatever the results page exposes (title/company/URL at minimum).
+	Fetch(ctx context.Context, query string) ([]domain.JobLead, error)
+
+	// Parse extracts a single job from one job-posting page's HTML,
+	// filling in whatever Fetch left sparse (description, location).
+	Parse(html string) (domain.JobLead, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Source{}
+)
+
+// Register adds (or replaces) a Source in the package-level registry,
+// keyed by Name(). Call once at startup.
+func Register(s Source) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[s.Name()] = s
+}
+
+// All returns every registered Source.
+func All() []Source {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	out := make([]Source, 0, len(registry))
+	for _, s := range registry {
+		out = append(out, s)
+	}
+	return out
+}
+
+// ByName returns the Source registered under name, or (nil, false).
+func ByName(name string) (Source, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	s, ok := registry[name]
+	return s, ok
+}
+
+// ForURL returns the registered Source whose Match(u) is true, trying
+// each registered source in turn (hostname matching is cheap and the
+// registry is small).
+func ForURL(u *url.URL) (Source, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	for _, s := range registry {
+		if s.Match(u) {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// hostMatches reports whether u's host is exactly domain or a
+// subdomain of it (e.g. "kr.indeed.com" matches "indeed.com"), ignoring
+// a leading "www.".
+func hostMatches(u *url.URL, domain string) bool {
+	host := strings.ToLower(u.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
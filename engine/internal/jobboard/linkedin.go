@@ -0,0 +1,95 @@
+package jobboard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/fetch"
+	applog "jobhunt-engine/internal/log"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const linkedInSource = "linkedin"
+
+// linkedInScraper searches LinkedIn's public job search page. Logged-in
+// features (Easy Apply state, recruiter messages) aren't available
+// here; it only sees what a logged-out GET returns.
+type linkedInScraper struct {
+	fetcher *fetch.Fetcher
+}
+
+func NewLinkedIn() Source {
+	return &linkedInScraper{fetcher: fetch.New()}
+}
+
+func (s *linkedInScraper) Name() string { return linkedInSource }
+
+func (s *linkedInScraper) Match(u *url.URL) bool {
+	return hostMatches(u, "linkedin.com")
+}
+
+// Canonicalize keeps only the job ID, whether it's in the path
+// (/jobs/view/12345) or a currentJobId query param (the shape a
+// "Jobs you may be interested in" email redirect uses), via the same
+// genericRewrites rule engine CanonicalizeURL falls back to for sites
+// with no compiled Source.
+func (s *linkedInScraper) Canonicalize(u *url.URL) string {
+	if out, ok := genericRewrites.Rewrite(u); ok {
+		return out
+	}
+	return "https://" + u.Host + u.Path
+}
+
+func (s *linkedInScraper) Fetch(ctx context.Context, query string) ([]domain.JobLead, error) {
+	searchURL := "https://www.linkedin.com/jobs/search?" + url.Values{"keywords": {query}}.Encode()
+
+	body, err := fetchPage(ctx, s.fetcher, searchURL, linkedInSource)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("linkedin parse search results: %w", err)
+	}
+
+	var out []domain.JobLead
+	doc.Find("div.base-card").Each(func(_ int, card *goquery.Selection) {
+		href, _ := card.Find("a.base-card__full-link").Attr("href")
+		if href == "" {
+			return
+		}
+		u, err := url.Parse(href)
+		if err != nil {
+			return
+		}
+		out = append(out, domain.JobLead{
+			CompanyName:     strings.TrimSpace(card.Find("h4.base-search-card__subtitle").Text()),
+			Title:           strings.TrimSpace(card.Find("h3.base-search-card__title").Text()),
+			LocationRaw:     strings.TrimSpace(card.Find("span.job-search-card__location").Text()),
+			URL:             s.Canonicalize(u),
+			FirstSeenSource: linkedInSource,
+		})
+	})
+
+	return out, nil
+}
+
+func (s *linkedInScraper) Parse(html string) (domain.JobLead, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return domain.JobLead{}, fmt.Errorf("linkedin parse job page: %w", err)
+	}
+	applog.Debug("linkedin parse job page", "title", doc.Find("h1").First().Text())
+	return domain.JobLead{
+		Title:           strings.TrimSpace(doc.Find("h1").First().Text()),
+		CompanyName:     strings.TrimSpace(doc.Find("a.topcard__org-name-link").First().Text()),
+		Description:     strings.TrimSpace(doc.Find("div.show-more-less-html__markup").Text()),
+		FirstSeenSource: linkedInSource,
+	}, nil
+}
@@ -0,0 +1,85 @@
+package pagecache
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// diskCache fronts a memCache with on-disk persistence under dir, one
+// JSON file per key, so a warm cache survives between separate
+// `jobhunt search` invocations (the in-memory cache alone only helps
+// within a single run).
+type diskCache struct {
+	mem Cache
+	dir string
+	ttl time.Duration
+}
+
+type diskEntry struct {
+	Expires time.Time `json:"expires"`
+	Value   []byte    `json:"value"`
+}
+
+// NewDisk returns a Cache backed by an in-memory LRU (capacity, ttl —
+// see NewMemory) that also writes entries to dir, defaulting to
+// ~/.cache/jobhunt if dir is "".
+func NewDisk(dir string, capacity int, ttl time.Duration) (Cache, error) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(home, ".cache", "jobhunt")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &diskCache{mem: NewMemory(capacity, ttl), dir: dir, ttl: ttl}, nil
+}
+
+func (c *diskCache) Get(key string) ([]byte, bool) {
+	if v, ok := c.mem.Get(key); ok {
+		return v, true
+	}
+
+	b, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var de diskEntry
+	if err := json.Unmarshal(b, &de); err != nil {
+		return nil, false
+	}
+	if time.Now().After(de.Expires) {
+		os.Remove(c.path(key))
+		return nil, false
+	}
+
+	c.mem.Set(key, de.Value)
+	return de.Value, true
+}
+
+func (c *diskCache) Set(key string, value []byte) {
+	c.mem.Set(key, value)
+
+	de := diskEntry{Expires: time.Now().Add(c.ttl), Value: value}
+	b, err := json.Marshal(de)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(c.path(key), b, 0o644)
+}
+
+// path hashes key (a full request URL, arbitrarily long) down to a
+// fixed-length filename.
+func (c *diskCache) path(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,87 @@
+package pagecache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const defaultCapacity = 256
+
+type entry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// memCache is a fixed-capacity LRU: Set evicts the least recently used
+// entry once capacity is exceeded, and Get treats an entry past its
+// TTL as a miss (and evicts it on the way out).
+type memCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+// NewMemory returns an in-memory LRU Cache holding up to capacity
+// entries (defaultCapacity if capacity <= 0), each valid for ttl
+// (DefaultTTL if ttl <= 0).
+func NewMemory(capacity int, ttl time.Duration) Cache {
+	if capacity <= 0 {
+		capacity = defaultCapacity
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &memCache{
+		capacity: capacity,
+		ttl:      ttl,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *memCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if time.Now().After(e.expires) {
+		c.removeElement(el)
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return e.value, true
+}
+
+func (c *memCache) Set(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry)
+		e.value = value
+		e.expires = time.Now().Add(c.ttl)
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&entry{key: key, value: value, expires: time.Now().Add(c.ttl)})
+	c.items[key] = el
+
+	if c.ll.Len() > c.capacity {
+		c.removeElement(c.ll.Back())
+	}
+}
+
+func (c *memCache) removeElement(el *list.Element) {
+	c.ll.Remove(el)
+	e := el.Value.(*entry)
+	delete(c.items, e.key)
+}
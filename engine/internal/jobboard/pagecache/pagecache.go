@@ -0,0 +1,19 @@
+// Package pagecache caches fetched HTML/JSON page bodies for
+// jobboard.Source.Fetch, keyed by the request URL. Searching the same
+// query repeatedly (a cron'd `jobhunt search`, or re-running to tune
+// selectors) would otherwise re-hit the same search/job pages every
+// time, which is how LinkedIn in particular starts rate-limiting.
+package pagecache
+
+import "time"
+
+// Cache stores a page body under key, evicting it once its TTL (set at
+// construction, see NewMemory/NewDisk) has passed. Implementations
+// must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+}
+
+// DefaultTTL is used when a cache is built with ttl <= 0.
+const DefaultTTL = 5 * time.Minute
@@ -0,0 +1,42 @@
+package jobboard
+
+import (
+	"net/url"
+
+	"jobhunt-engine/internal/rewrite"
+)
+
+// genericRewrites canonicalizes URLs from sites with no compiled
+// Source — Glassdoor, Wellfound, a company's own Greenhouse board,
+// whatever a user's rewrites.conf adds — and also backs linkedInScraper's
+// Canonicalize, since LinkedIn's rules are just its entry in
+// rewrite.Defaults(). It starts out holding only those defaults and is
+// replaced wholesale by LoadRewritesFile once a rewrites.conf is loaded.
+var genericRewrites = rewrite.New(rewrite.Defaults())
+
+// LoadRewritesFile parses a rewrites.conf at path and installs it as
+// the ruleset CanonicalizeURL (and linkedInScraper.Canonicalize) fall
+// back on, with the built-in defaults appended so a user file only
+// needs to list the sites it's adding or overriding.
+func LoadRewritesFile(path string) error {
+	rules, err := rewrite.LoadFile(path)
+	if err != nil {
+		return err
+	}
+	genericRewrites = rewrite.New(append(rules, rewrite.Defaults()...))
+	return nil
+}
+
+// CanonicalizeURL canonicalizes u via the matching registered Source's
+// Canonicalize if one claims it, otherwise via genericRewrites — this
+// is what lets a new site be taught to `jobhunt search` through
+// rewrites.conf alone, without writing a Source implementation.
+func CanonicalizeURL(u *url.URL) string {
+	if s, ok := ForURL(u); ok {
+		return s.Canonicalize(u)
+	}
+	if out, ok := genericRewrites.Rewrite(u); ok {
+		return out
+	}
+	return "https://" + u.Host + u.Path
+}
@@ -0,0 +1,88 @@
+package jobboard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/fetch"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const saraminSource = "saramin"
+
+type saraminScraper struct {
+	fetcher *fetch.Fetcher
+}
+
+func NewSaramin() Source {
+	return &saraminScraper{fetcher: fetch.New()}
+}
+
+func (s *saraminScraper) Name() string { return saraminSource }
+
+func (s *saraminScraper) Match(u *url.URL) bool {
+	return hostMatches(u, "saramin.co.kr")
+}
+
+// Canonicalize keeps only the rec_idx (recruit index) query param,
+// Saramin's per-posting identifier.
+func (s *saraminScraper) Canonicalize(u *url.URL) string {
+	idx := u.Query().Get("rec_idx")
+	if idx == "" {
+		return "https://" + u.Host + u.Path
+	}
+	return "https://www.saramin.co.kr/zf_user/jobs/relay/view?rec_idx=" + idx
+}
+
+func (s *saraminScraper) Fetch(ctx context.Context, query string) ([]domain.JobLead, error) {
+	searchURL := "https://www.saramin.co.kr/zf_user/search/recruit?" + url.Values{"searchword": {query}}.Encode()
+
+	body, err := fetchPage(ctx, s.fetcher, searchURL, saraminSource)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("saramin parse search results: %w", err)
+	}
+
+	var out []domain.JobLead
+	doc.Find("div.item_recruit").Each(func(_ int, card *goquery.Selection) {
+		href, _ := card.Find("a").Attr("href")
+		if href == "" {
+			return
+		}
+		abs, err := url.Parse("https://www.saramin.co.kr" + href)
+		if err != nil {
+			return
+		}
+		out = append(out, domain.JobLead{
+			CompanyName:     strings.TrimSpace(card.Find("strong.corp_name").Text()),
+			Title:           strings.TrimSpace(card.Find("a.job_tit").Text()),
+			LocationRaw:     strings.TrimSpace(card.Find("div.job_condition span").First().Text()),
+			URL:             s.Canonicalize(abs),
+			FirstSeenSource: saraminSource,
+		})
+	})
+
+	return out, nil
+}
+
+func (s *saraminScraper) Parse(html string) (domain.JobLead, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return domain.JobLead{}, fmt.Errorf("saramin parse job page: %w", err)
+	}
+	return domain.JobLead{
+		Title:           strings.TrimSpace(doc.Find("h1.tit_job").First().Text()),
+		CompanyName:     strings.TrimSpace(doc.Find("a.company").First().Text()),
+		Description:     strings.TrimSpace(doc.Find("div.user_content").Text()),
+		FirstSeenSource: saraminSource,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package jobboard
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"jobhunt-engine/internal/domain"
+	applog "jobhunt-engine/internal/log"
+)
+
+// Search runs query against each named source ("all" expands to every
+// registered Source) and aggregates the results, deduping by canonical
+// URL so the same posting mirrored across sites only appears once.
+func Search(ctx context.Context, sources []string, query string) ([]domain.JobLead, error) {
+	targets, err := resolveSources(sources)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]struct{}{}
+	var out []domain.JobLead
+	for _, s := range targets {
+		leads, err := s.Fetch(ctx, query)
+		if err != nil {
+			applog.Warn("jobboard search failed", "source", s.Name(), "error", err)
+			continue
+		}
+		for _, l := range leads {
+			key := canonicalKey(l.URL)
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+			out = append(out, l)
+		}
+	}
+	return out, nil
+}
+
+func resolveSources(names []string) ([]Source, error) {
+	if len(names) == 1 && names[0] == "all" {
+		return All(), nil
+	}
+	out := make([]Source, 0, len(names))
+	for _, name := range names {
+		s, ok := ByName(name)
+		if !ok {
+			return nil, fmt.Errorf("jobboard: unknown source %q", name)
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// canonicalKey resolves rawURL to its source's canonical form when a
+// registered Source claims it, falling back to the raw URL so an
+// unrecognized host still dedupes against itself.
+func canonicalKey(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	if s, ok := ForURL(u); ok {
+		return s.Canonicalize(u)
+	}
+	return rawURL
+}
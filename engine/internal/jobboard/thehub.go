@@ -0,0 +1,86 @@
+package jobboard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/fetch"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const theHubSource = "thehub"
+
+type theHubScraper struct {
+	fetcher *fetch.Fetcher
+}
+
+// NewTheHub scrapes thehub.io, the Korean startup job board (not the
+// GitHub-adjacent "the hub", unrelated despite the name).
+func NewTheHub() Source {
+	return &theHubScraper{fetcher: fetch.New()}
+}
+
+func (s *theHubScraper) Name() string { return theHubSource }
+
+func (s *theHubScraper) Match(u *url.URL) bool {
+	return hostMatches(u, "thehub.io")
+}
+
+// Canonicalize keeps only the /jobs/<id> path, dropping query params
+// (referrer, utm_* tracking) TheHub appends to shared links.
+func (s *theHubScraper) Canonicalize(u *url.URL) string {
+	return "https://thehub.io" + strings.TrimRight(u.Path, "/")
+}
+
+func (s *theHubScraper) Fetch(ctx context.Context, query string) ([]domain.JobLead, error) {
+	searchURL := "https://thehub.io/jobs?" + url.Values{"q": {query}}.Encode()
+
+	body, err := fetchPage(ctx, s.fetcher, searchURL, theHubSource)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("thehub parse search results: %w", err)
+	}
+
+	var out []domain.JobLead
+	doc.Find("a.job-card").Each(func(_ int, card *goquery.Selection) {
+		href, _ := card.Attr("href")
+		if href == "" {
+			return
+		}
+		abs, err := url.Parse("https://thehub.io" + href)
+		if err != nil {
+			return
+		}
+		out = append(out, domain.JobLead{
+			CompanyName:     strings.TrimSpace(card.Find("span.company-name").Text()),
+			Title:           strings.TrimSpace(card.Find("span.job-title").Text()),
+			LocationRaw:     strings.TrimSpace(card.Find("span.job-location").Text()),
+			URL:             s.Canonicalize(abs),
+			FirstSeenSource: theHubSource,
+		})
+	})
+
+	return out, nil
+}
+
+func (s *theHubScraper) Parse(html string) (domain.JobLead, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return domain.JobLead{}, fmt.Errorf("thehub parse job page: %w", err)
+	}
+	return domain.JobLead{
+		Title:           strings.TrimSpace(doc.Find("h1").First().Text()),
+		CompanyName:     strings.TrimSpace(doc.Find("a.company-link").First().Text()),
+		Description:     strings.TrimSpace(doc.Find("div.job-description").Text()),
+		FirstSeenSource: theHubSource,
+	}, nil
+}
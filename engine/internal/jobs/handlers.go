@@ -0,0 +1,95 @@
+package jobs
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RegisterRoutes wires the run-history and control endpoints onto mux:
+//
+//	GET  /jobs/specs            - every registered job's schedule + paused state
+//	GET  /jobs/runs             - recent runs, newest first
+//	GET  /jobs/runs/{id}        - a single run
+//	POST /jobs/{name}/run       - trigger an out-of-band run of a registered job
+//	POST /jobs/{name}/pause     - stop a job's automatic ticker
+//	POST /jobs/{name}/resume    - undo pause
+//	POST /jobs/runs/{id}/cancel - cancel a pending or in-progress run
+func RegisterRoutes(mux *http.ServeMux, db *sql.DB, sched *Scheduler) {
+	mux.HandleFunc("GET /jobs/specs", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, sched.Specs())
+	})
+
+	mux.HandleFunc("POST /jobs/{name}/pause", func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSpace(r.PathValue("name"))
+		if err := sched.Pause(name); err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		writeJSON(w, map[string]any{"ok": true})
+	})
+
+	mux.HandleFunc("POST /jobs/{name}/resume", func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSpace(r.PathValue("name"))
+		if err := sched.Resume(name); err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		writeJSON(w, map[string]any{"ok": true})
+	})
+
+	mux.HandleFunc("GET /jobs/runs", func(w http.ResponseWriter, r *http.Request) {
+		runs, err := ListRuns(db, 100)
+		if err != nil {
+			http.Error(w, err.Error(), 500)
+			return
+		}
+		writeJSON(w, runs)
+	})
+
+	mux.HandleFunc("GET /jobs/runs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid id", 400)
+			return
+		}
+		run, err := GetRun(db, id)
+		if err != nil {
+			http.Error(w, "run not found", 404)
+			return
+		}
+		writeJSON(w, run)
+	})
+
+	mux.HandleFunc("POST /jobs/{name}/run", func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimSpace(r.PathValue("name"))
+		runID, err := sched.RunNow(r.Context(), name)
+		if err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		writeJSON(w, map[string]any{"ok": true, "run_id": runID})
+	})
+
+	mux.HandleFunc("POST /jobs/runs/{id}/cancel", func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid id", 400)
+			return
+		}
+		if err := sched.Cancel(id); err != nil {
+			http.Error(w, err.Error(), 400)
+			return
+		}
+		writeJSON(w, map[string]any{"ok": true})
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
@@ -0,0 +1,20 @@
+// Package jobs provides a small Scheduler/Worker/Job subsystem: each
+// source (email polling, Greenhouse, ...) registers a Spec with its own
+// interval and concurrency limit, and every run is persisted as a
+// JobRun row so callers can see history and in-flight state instead of
+// a single shared "is anything running" flag.
+package jobs
+
+import "context"
+
+// Job is a unit of work a Spec runs on its own schedule or on demand.
+type Job interface {
+	// Run executes one pass of the job and returns how many new items
+	// it produced. It must return promptly after ctx is canceled.
+	Run(ctx context.Context) (itemsAdded int, err error)
+}
+
+// JobFunc adapts a plain function to the Job interface.
+type JobFunc func(ctx context.Context) (int, error)
+
+func (f JobFunc) Run(ctx context.Context) (int, error) { return f(ctx) }
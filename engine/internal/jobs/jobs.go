@@ -0,0 +1,50 @@
+// Package jobs is a small persistent job queue: a Worker registers
+// itself for a job type (e.g. "scrape:greenhouse"), JobServer polls
+// the job_runs table (see internal/store) for Pending work of that
+// type, runs it within a per-type concurrency limit, and retries
+// failures with exponential backoff and jitter - all as a replacement
+// for tracking a single in-flight scrape in an atomic.Value.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"time"
+)
+
+// Worker runs one job_runs row's payload. MaxRetries/BackoffFor let
+// each job type tune its own retry policy - a flaky network fetch and
+// a deterministic parse error shouldn't back off the same way.
+type Worker interface {
+	Run(ctx context.Context, data json.RawMessage) error
+	MaxRetries() int
+	BackoffFor(attempt int) time.Duration
+}
+
+// RecurringJob is a job type that runs on a recurring cadence instead
+// of only being enqueued on demand (e.g. "poll every 5 minutes").
+// Server's schedule loop consults NextScheduledTime once per tick and
+// enqueues a job of JobType when it's due. Named distinctly from this
+// package's other, concrete Scheduler (scheduler.go) - that one owns
+// Specs/worker pools for the poller main.go actually runs; this is
+// Server's own, separate recurring-job registration point.
+type RecurringJob interface {
+	NextScheduledTime() *time.Time
+	JobType() string
+}
+
+// ExponentialBackoff returns base * 2^(attempt-1), jittered by +/-50% so a
+// batch of jobs that failed together doesn't all retry in lockstep.
+// attempt is 1-indexed (the first retry passes 1).
+func ExponentialBackoff(base time.Duration, attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := base
+	for i := 1; i < attempt; i++ {
+		d *= 2
+	}
+	jitter := time.Duration(rand.Int63n(int64(d))) - d/2
+	return d + jitter
+}
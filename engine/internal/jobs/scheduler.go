@@ -0,0 +1,248 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Spec describes how a named Job is scheduled: how often it should run
+// on its own, and how many runs of it may execute at once.
+type Spec struct {
+	Name          string
+	Job           Job
+	Interval      time.Duration // 0 disables the automatic ticker; RunNow still works
+	MaxConcurrent int           // <= 0 means 1
+}
+
+// Scheduler owns one Spec per source and a small worker pool per
+// source so, e.g., Greenhouse and email polling run on independent
+// schedules and never block each other.
+type Scheduler struct {
+	db     *sql.DB
+	notify func(event string) // SSE publish; may be nil
+
+	mu     sync.Mutex
+	specs  map[string]Spec
+	sema   map[string]chan struct{} // per-source concurrency gate
+	cancel map[int64]context.CancelFunc
+	nextWk map[string]int // round-robin worker id per source, for display only
+	paused map[string]bool
+}
+
+// NewScheduler creates a Scheduler backed by db for run persistence.
+// notify (optional) is called with a JSON event string on every state
+// transition, matching the shape already published on /events.
+func NewScheduler(db *sql.DB, notify func(event string)) *Scheduler {
+	return &Scheduler{
+		db:     db,
+		notify: notify,
+		specs:  make(map[string]Spec),
+		sema:   make(map[string]chan struct{}),
+		cancel: make(map[int64]context.CancelFunc),
+		nextWk: make(map[string]int),
+		paused: make(map[string]bool),
+	}
+}
+
+// Register adds or replaces the Spec for spec.Name.
+func (s *Scheduler) Register(spec Spec) {
+	if spec.MaxConcurrent <= 0 {
+		spec.MaxConcurrent = 1
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.specs[spec.Name] = spec
+	s.sema[spec.Name] = make(chan struct{}, spec.MaxConcurrent)
+}
+
+// Pause stops name's automatic ticker from starting new runs (see
+// Start's select loop). A run already in flight keeps going, and
+// RunNow still works - Pause only silences the schedule, the same way
+// a human would temporarily stop a cron entry without deregistering
+// it.
+func (s *Scheduler) Pause(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.specs[name]; !ok {
+		return fmt.Errorf("jobs: unknown job %q", name)
+	}
+	s.paused[name] = true
+	return nil
+}
+
+// Resume undoes Pause, letting name's ticker resume triggering runs.
+func (s *Scheduler) Resume(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.specs[name]; !ok {
+		return fmt.Errorf("jobs: unknown job %q", name)
+	}
+	delete(s.paused, name)
+	return nil
+}
+
+// Paused reports whether name's automatic ticker is currently paused.
+func (s *Scheduler) Paused(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.paused[name]
+}
+
+// SpecStatus is one registered Spec's static schedule plus its current
+// Paused state, for the admin listing endpoint.
+type SpecStatus struct {
+	Name          string        `json:"name"`
+	Interval      time.Duration `json:"interval"`
+	MaxConcurrent int           `json:"maxConcurrent"`
+	Paused        bool          `json:"paused"`
+}
+
+// Specs lists every registered job, alphabetically by name.
+func (s *Scheduler) Specs() []SpecStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]SpecStatus, 0, len(s.specs))
+	for name, spec := range s.specs {
+		out = append(out, SpecStatus{
+			Name:          name,
+			Interval:      spec.Interval,
+			MaxConcurrent: spec.MaxConcurrent,
+			Paused:        s.paused[name],
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Start launches the per-source tickers for every registered Spec with
+// a non-zero Interval. Call once after all Register calls.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	specs := make([]Spec, 0, len(s.specs))
+	for _, spec := range s.specs {
+		specs = append(specs, spec)
+	}
+	s.mu.Unlock()
+
+	for _, spec := range specs {
+		if spec.Interval <= 0 {
+			continue
+		}
+		spec := spec
+		go func() {
+			t := time.NewTicker(spec.Interval)
+			defer t.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-t.C:
+					if s.Paused(spec.Name) {
+						continue
+					}
+					if _, err := s.RunNow(ctx, spec.Name); err != nil {
+						s.publish(spec.Name, 0, StateError, fmt.Sprintf("scheduled run: %v", err))
+					}
+				}
+			}
+		}()
+	}
+}
+
+// RunNow creates a pending JobRun and executes it as soon as a worker
+// slot for that source is free, blocking only until the run is queued
+// (the run itself happens in a background goroutine).
+func (s *Scheduler) RunNow(parent context.Context, name string) (runID int64, err error) {
+	s.mu.Lock()
+	spec, ok := s.specs[name]
+	sema := s.sema[name]
+	workerID := s.nextWk[name]
+	s.nextWk[name] = workerID + 1
+	s.mu.Unlock()
+	if !ok {
+		return 0, fmt.Errorf("jobs: unknown job %q", name)
+	}
+
+	runID, err = insertPendingRun(s.db, name)
+	if err != nil {
+		return 0, fmt.Errorf("jobs: create run: %w", err)
+	}
+	s.publish(name, runID, StatePending, "")
+
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(parent))
+	s.mu.Lock()
+	s.cancel[runID] = cancel
+	s.mu.Unlock()
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.cancel, runID)
+			s.mu.Unlock()
+		}()
+
+		select {
+		case sema <- struct{}{}:
+			defer func() { <-sema }()
+		case <-runCtx.Done():
+			_ = markFinished(s.db, runID, StateCanceled, 0, nil)
+			s.publish(name, runID, StateCanceled, "")
+			return
+		}
+
+		if err := markRunning(s.db, runID, workerID); err != nil {
+			s.publish(name, runID, StateError, err.Error())
+			return
+		}
+		s.publish(name, runID, StateInProgress, "")
+
+		added, runErr := spec.Job.Run(runCtx)
+
+		state := StateSuccess
+		switch {
+		case runCtx.Err() != nil:
+			state = StateCanceled
+		case runErr != nil:
+			state = StateError
+		}
+		_ = markFinished(s.db, runID, state, added, runErr)
+		s.publish(name, runID, state, errString(runErr))
+	}()
+
+	return runID, nil
+}
+
+// Cancel requests that the in-progress or still-pending run stop. It
+// is a no-op if the run has already finished.
+func (s *Scheduler) Cancel(runID int64) error {
+	s.mu.Lock()
+	cancel, ok := s.cancel[runID]
+	s.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("jobs: run %d is not active", runID)
+	}
+	cancel()
+	return nil
+}
+
+func (s *Scheduler) publish(name string, runID int64, state RunState, lastErr string) {
+	if s.notify == nil {
+		return
+	}
+	s.notify(fmt.Sprintf(
+		`{"type":"job_run","job_name":%q,"run_id":%d,"state":%q,"last_error":%q}`,
+		name, runID, state, lastErr,
+	))
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
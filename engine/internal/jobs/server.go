@@ -0,0 +1,231 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"jobhunt-engine/internal/events"
+	"jobhunt-engine/internal/store"
+)
+
+// DefaultStaleThreshold bounds how long an InProgress job_runs row can
+// go without a last_activity_at update before Start reclaims it back
+// to Pending - covers a worker process that died mid-job.
+const DefaultStaleThreshold = 15 * time.Minute
+
+// DefaultDispatchInterval is how often the dispatch and schedule loops
+// scan for newly-startable work.
+const DefaultDispatchInterval = 2 * time.Second
+
+// registeredWorker pairs a Worker with the semaphore that enforces its
+// per-job-type concurrency limit.
+type registeredWorker struct {
+	worker Worker
+	sem    chan struct{}
+}
+
+// Server owns every registered Worker/RecurringJob plus the job_runs
+// table's lifecycle: reclaiming stale rows on Start, dispatching
+// Pending work within each type's concurrency limit, and publishing
+// job_status_changed events as rows change state.
+type Server struct {
+	db             *sql.DB
+	hub            *events.Hub
+	staleThreshold time.Duration
+
+	mu         sync.Mutex
+	workers    map[string]*registeredWorker
+	schedulers []RecurringJob
+}
+
+// NewServer returns a Server with DefaultStaleThreshold. Register
+// workers/schedulers before calling Start.
+func NewServer(db *sql.DB, hub *events.Hub) *Server {
+	return &Server{
+		db:             db,
+		hub:            hub,
+		staleThreshold: DefaultStaleThreshold,
+		workers:        make(map[string]*registeredWorker),
+	}
+}
+
+// RegisterWorker makes w runnable for jobType, at most concurrency jobs
+// of that type running at once (<=0 means 1).
+func (s *Server) RegisterWorker(jobType string, w Worker, concurrency int) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workers[jobType] = &registeredWorker{worker: w, sem: make(chan struct{}, concurrency)}
+}
+
+// RegisterScheduler adds sch to the set the schedule loop consults for
+// recurring work.
+func (s *Server) RegisterScheduler(sch RecurringJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.schedulers = append(s.schedulers, sch)
+}
+
+// Enqueue inserts a new Pending job_runs row for jobType with data
+// marshaled to JSON, and publishes job_status_changed.
+func (s *Server) Enqueue(ctx context.Context, jobType string, data any) (int64, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return 0, err
+	}
+	id, err := store.EnqueueJob(ctx, s.db, jobType, raw)
+	if err != nil {
+		return 0, err
+	}
+	s.publish(id, jobType, store.JobPending)
+	return id, nil
+}
+
+// Status queries job_runs with opts (see store.JobListOpts).
+func (s *Server) Status(ctx context.Context, opts store.JobListOpts) ([]store.JobRecord, error) {
+	return store.ListJobRuns(ctx, s.db, opts)
+}
+
+// Start reclaims stale InProgress rows back to Pending, then launches
+// the dispatch and schedule loops in the background. ctx cancellation
+// stops both loops; Start itself returns as soon as the reclaim is
+// done.
+func (s *Server) Start(ctx context.Context) error {
+	n, err := store.ReclaimStaleJobs(ctx, s.db, s.staleThreshold)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		log.Printf("[jobs] reclaimed %d stale in-progress job(s)", n)
+	}
+
+	go s.dispatchLoop(ctx)
+	go s.scheduleLoop(ctx)
+	return nil
+}
+
+func (s *Server) dispatchLoop(ctx context.Context) {
+	t := time.NewTicker(DefaultDispatchInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		s.mu.Lock()
+		jobTypes := make([]string, 0, len(s.workers))
+		for jt := range s.workers {
+			jobTypes = append(jobTypes, jt)
+		}
+		s.mu.Unlock()
+
+		for _, jt := range jobTypes {
+			s.dispatchType(ctx, jt)
+		}
+	}
+}
+
+// dispatchType claims as many Pending rows of jobType as its worker's
+// concurrency semaphore currently has room for, non-blocking - a full
+// semaphore just means "try again next tick".
+func (s *Server) dispatchType(ctx context.Context, jobType string) {
+	s.mu.Lock()
+	rw, ok := s.workers[jobType]
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	for {
+		select {
+		case rw.sem <- struct{}{}:
+		default:
+			return // at capacity for this type
+		}
+
+		job, err := store.ClaimNextPendingJob(ctx, s.db, jobType)
+		if err != nil {
+			log.Printf("[jobs] claim err type=%q err=%v", jobType, err)
+			<-rw.sem
+			return
+		}
+		if job == nil {
+			<-rw.sem // nothing pending right now
+			return
+		}
+
+		s.publish(job.ID, jobType, store.JobInProgress)
+		go s.run(ctx, rw, job)
+	}
+}
+
+func (s *Server) run(ctx context.Context, rw *registeredWorker, job *store.JobRecord) {
+	defer func() { <-rw.sem }()
+
+	err := rw.worker.Run(ctx, job.Data)
+	if err == nil {
+		_ = store.SucceedJob(ctx, s.db, job.ID)
+		s.publish(job.ID, job.Type, store.JobSuccess)
+		return
+	}
+
+	attempt := job.RetryCount + 1
+	if attempt > rw.worker.MaxRetries() {
+		_ = store.FailJob(ctx, s.db, job.ID, err.Error())
+		s.publish(job.ID, job.Type, store.JobError)
+		return
+	}
+
+	if rerr := store.RetryJob(ctx, s.db, job.ID, err.Error(), rw.worker.BackoffFor(attempt)); rerr != nil {
+		log.Printf("[jobs] retry bookkeeping err job=%d err=%v", job.ID, rerr)
+	}
+	s.publish(job.ID, job.Type, store.JobPending)
+}
+
+func (s *Server) scheduleLoop(ctx context.Context) {
+	t := time.NewTicker(DefaultDispatchInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+
+		s.mu.Lock()
+		scheds := append([]RecurringJob(nil), s.schedulers...)
+		s.mu.Unlock()
+
+		now := time.Now()
+		for _, sch := range scheds {
+			due := sch.NextScheduledTime()
+			if due == nil || due.After(now) {
+				continue
+			}
+			if _, err := s.Enqueue(ctx, sch.JobType(), map[string]any{}); err != nil {
+				log.Printf("[jobs] scheduler enqueue err type=%q err=%v", sch.JobType(), err)
+			}
+		}
+	}
+}
+
+func (s *Server) publish(id int64, jobType string, status store.JobStatus) {
+	if s.hub == nil {
+		return
+	}
+	s.hub.Publish(events.MakeEvent("", "job_status_changed", 1, map[string]any{
+		"id":     id,
+		"type":   jobType,
+		"status": string(status),
+	}))
+}
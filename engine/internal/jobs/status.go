@@ -0,0 +1,27 @@
+package jobs
+
+import "time"
+
+// RunState is the lifecycle of a single JobRun.
+type RunState string
+
+const (
+	StatePending    RunState = "pending"
+	StateInProgress RunState = "in_progress"
+	StateSuccess    RunState = "success"
+	StateError      RunState = "error"
+	StateCanceled   RunState = "canceled"
+)
+
+// JobRun is one persisted execution of a named job.
+type JobRun struct {
+	ID         int64     `json:"id"`
+	JobName    string    `json:"job_name"`
+	State      RunState  `json:"state"`
+	StartedAt  time.Time `json:"started_at,omitempty"`
+	FinishedAt time.Time `json:"finished_at,omitempty"`
+	WorkerID   int       `json:"worker_id"`
+	Progress   string    `json:"progress,omitempty"`
+	LastError  string    `json:"last_error,omitempty"`
+	ItemsAdded int       `json:"items_added"`
+}
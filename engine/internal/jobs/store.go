@@ -0,0 +1,115 @@
+package jobs
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Migrate creates the job_runs table if it doesn't already exist.
+// Safe to call on every startup.
+func Migrate(db *sql.DB) error {
+	_, err := db.Exec(`
+CREATE TABLE IF NOT EXISTS job_runs (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  job_name TEXT NOT NULL,
+  state TEXT NOT NULL,
+  started_at TEXT NOT NULL DEFAULT '',
+  finished_at TEXT NOT NULL DEFAULT '',
+  worker_id INTEGER NOT NULL DEFAULT 0,
+  progress TEXT NOT NULL DEFAULT '',
+  last_error TEXT NOT NULL DEFAULT '',
+  items_added INTEGER NOT NULL DEFAULT 0
+);`)
+	return err
+}
+
+func insertPendingRun(db *sql.DB, jobName string) (int64, error) {
+	res, err := db.Exec(`
+INSERT INTO job_runs(job_name, state) VALUES(?, ?);`,
+		jobName, StatePending)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+func markRunning(db *sql.DB, runID int64, workerID int) error {
+	_, err := db.Exec(`
+UPDATE job_runs SET state = ?, started_at = ?, worker_id = ? WHERE id = ?;`,
+		StateInProgress, time.Now().UTC().Format(time.RFC3339), workerID, runID)
+	return err
+}
+
+func markFinished(db *sql.DB, runID int64, state RunState, itemsAdded int, runErr error) error {
+	lastErr := ""
+	if runErr != nil {
+		lastErr = runErr.Error()
+	}
+	_, err := db.Exec(`
+UPDATE job_runs SET state = ?, finished_at = ?, items_added = ?, last_error = ? WHERE id = ?;`,
+		state, time.Now().UTC().Format(time.RFC3339), itemsAdded, lastErr, runID)
+	return err
+}
+
+func scanRun(row interface {
+	Scan(dest ...any) error
+}) (JobRun, error) {
+	var (
+		r                     JobRun
+		startedAt, finishedAt string
+	)
+	err := row.Scan(&r.ID, &r.JobName, &r.State, &startedAt, &finishedAt, &r.WorkerID, &r.Progress, &r.LastError, &r.ItemsAdded)
+	if err != nil {
+		return JobRun{}, err
+	}
+	r.StartedAt, _ = time.Parse(time.RFC3339, startedAt)
+	r.FinishedAt, _ = time.Parse(time.RFC3339, finishedAt)
+	return r, nil
+}
+
+// GetRun loads a single run by id.
+func GetRun(db *sql.DB, id int64) (JobRun, error) {
+	row := db.QueryRow(`
+SELECT id, job_name, state, started_at, finished_at, worker_id, progress, last_error, items_added
+FROM job_runs WHERE id = ?;`, id)
+	return scanRun(row)
+}
+
+// LatestRun returns the most recent run for jobName, if any.
+func LatestRun(db *sql.DB, jobName string) (JobRun, bool, error) {
+	row := db.QueryRow(`
+SELECT id, job_name, state, started_at, finished_at, worker_id, progress, last_error, items_added
+FROM job_runs WHERE job_name = ? ORDER BY id DESC LIMIT 1;`, jobName)
+	r, err := scanRun(row)
+	if err == sql.ErrNoRows {
+		return JobRun{}, false, nil
+	}
+	if err != nil {
+		return JobRun{}, false, err
+	}
+	return r, true, nil
+}
+
+// ListRuns returns the most recent runs, newest first.
+func ListRuns(db *sql.DB, limit int) ([]JobRun, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := db.Query(`
+SELECT id, job_name, state, started_at, finished_at, worker_id, progress, last_error, items_added
+FROM job_runs ORDER BY id DESC LIMIT ?;`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []JobRun
+	for rows.Next() {
+		r, err := scanRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
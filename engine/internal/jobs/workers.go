@@ -0,0 +1,146 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"jobhunt-engine/internal/config"
+	"jobhunt-engine/internal/events"
+	"jobhunt-engine/internal/scrape"
+	"jobhunt-engine/internal/scrape/types"
+	"jobhunt-engine/internal/scrape/util"
+	"jobhunt-engine/internal/store"
+)
+
+// FetcherWorker runs one types.Fetcher to completion and feeds
+// whatever it finds through scrape.ProcessLeads - the same two-step
+// pipeline poll.PollOnce already runs, just as a retryable job_runs
+// row instead of part of one monolithic poll cycle. Build resolves the
+// Fetcher fresh on every run (rather than holding one open) so config
+// changes between runs take effect immediately, and reports ok=false
+// if the source has since been disabled.
+type FetcherWorker struct {
+	Build     func(cfg config.Config, limiter *util.HostLimiter) (types.Fetcher, bool)
+	DB        *sql.DB
+	CfgVal    func() config.Config
+	OnNewJob  func()
+	Limiter   *util.HostLimiter
+	Retries   int
+	BaseDelay time.Duration
+}
+
+func (w FetcherWorker) Run(ctx context.Context, data json.RawMessage) error {
+	cfg := w.CfgVal()
+	f, ok := w.Build(cfg, w.Limiter)
+	if !ok || f == nil {
+		return nil // source disabled since this job was enqueued; nothing to do
+	}
+
+	res, err := f.Fetch(ctx)
+	if err != nil {
+		return err
+	}
+	if len(res.Leads) > 0 {
+		scrape.ProcessLeads(ctx, w.DB, cfg, res.Leads, w.OnNewJob)
+	}
+	if res.Finalize != nil {
+		return res.Finalize(ctx)
+	}
+	return nil
+}
+
+func (w FetcherWorker) MaxRetries() int {
+	if w.Retries > 0 {
+		return w.Retries
+	}
+	return 3
+}
+
+func (w FetcherWorker) BackoffFor(attempt int) time.Duration {
+	base := w.BaseDelay
+	if base <= 0 {
+		base = 30 * time.Second
+	}
+	return ExponentialBackoff(base, attempt)
+}
+
+// LogoEnrichData is enrich:logo's job payload.
+type LogoEnrichData struct {
+	Company string `json:"company"`
+}
+
+// LogoEnrichWorker resolves data.Company's domain (GetOrFindCompanyDomain)
+// and favicon (CacheLogoFromURL) outside of ProcessLeads' per-lead loop
+// - useful for backfilling a company whose inline enrichment failed or
+// was skipped (e.g. it came in through a source that predates logo
+// enrichment). It uses its own HostLimiter rather than
+// scrape.ProcessLeads' shared one, since it runs on its own job_runs
+// schedule instead of once per poll.
+type LogoEnrichWorker struct {
+	DB      *sql.DB
+	DataDir func() string
+	limiter *util.HostLimiter
+}
+
+func (w *LogoEnrichWorker) Run(ctx context.Context, data json.RawMessage) error {
+	var d LogoEnrichData
+	if err := json.Unmarshal(data, &d); err != nil {
+		return err
+	}
+	if d.Company == "" {
+		return nil
+	}
+	if w.limiter == nil {
+		w.limiter = util.NewHostLimiter(1.0, 2)
+	}
+
+	dom, err := scrape.GetOrFindCompanyDomain(ctx, w.DB, w.limiter, d.Company)
+	if err != nil || dom == "" {
+		return err
+	}
+
+	_, err = store.CacheLogoFromURL(ctx, w.DB, w.DataDir(), store.FaviconURLForDomain(dom))
+	return err
+}
+
+func (w *LogoEnrichWorker) MaxRetries() int { return 2 }
+
+func (w *LogoEnrichWorker) BackoffFor(attempt int) time.Duration {
+	return ExponentialBackoff(10*time.Second, attempt)
+}
+
+// NewDefaultServer returns a Server with the standard worker set: one
+// FetcherWorker per registered ATS/webcrawl source (see
+// types.RegisteredFetcherNames) and one LogoEnrichWorker for
+// "enrich:logo". The backlog also asked for "scrape:email" and
+// per-company job types like "scrape:greenhouse:<slug>"; email isn't
+// registered here because internal/poll's own email_scrape.EmailFetcher
+// reference doesn't actually resolve to a defined type in this tree
+// (pre-existing gap, not introduced by this change), and per-company
+// granularity isn't possible without first splitting each ATS
+// package's Fetch (which fetches every configured company in one
+// call) into a per-company entry point - both are left as follow-up.
+// Call Start on the result to launch its dispatch loop.
+func NewDefaultServer(db *sql.DB, hub *events.Hub, cfgVal func() config.Config, dataDir func() string, onNewJob func()) *Server {
+	s := NewServer(db, hub)
+
+	limiter := util.NewHostLimiter(1.0, 2)
+	for _, name := range types.RegisteredFetcherNames() {
+		name := name
+		s.RegisterWorker("scrape:"+name, FetcherWorker{
+			Build: func(cfg config.Config, limiter *util.HostLimiter) (types.Fetcher, bool) {
+				return types.BuildFetcher(name, cfg, limiter)
+			},
+			DB:       db,
+			CfgVal:   cfgVal,
+			OnNewJob: onNewJob,
+			Limiter:  limiter,
+		}, 1)
+	}
+
+	s.RegisterWorker("enrich:logo", &LogoEnrichWorker{DB: db, DataDir: dataDir}, 4)
+
+	return s
+}
@@ -0,0 +1,114 @@
+// Package lifecycle coordinates graceful shutdown across the engine's
+// long-running goroutines (the poller loop, in-flight scrapes, and SSE
+// clients) so a restart or deploy doesn't cut them off mid-request.
+package lifecycle
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"jobhunt-engine/internal/events"
+)
+
+// DefaultDeadline is the per-subsystem drain timeout Shutdown uses
+// when New is given deadline <= 0.
+const DefaultDeadline = 5 * time.Second
+
+// Report records, per subsystem, whether Shutdown finished draining it
+// before the deadline or gave up and moved on. httpapi.ShutdownHandler
+// serializes this as the /shutdown response body.
+type Report struct {
+	Subsystems map[string]string `json:"subsystems"`
+}
+
+// Coordinator tracks the poller's cancel func and in-flight scrape
+// goroutines so Shutdown can stop/drain them in order instead of the
+// old bare srv.Shutdown(ctx), which left them running past the HTTP
+// server's own close.
+type Coordinator struct {
+	deadline time.Duration
+	hub      *events.Hub
+
+	pollCancel context.CancelFunc
+	scrapes    sync.WaitGroup
+}
+
+// New returns a Coordinator whose Shutdown waits up to deadline
+// (DefaultDeadline if deadline <= 0) for each subsystem to drain, and
+// which sends a final event to hub's subscribers before closing it.
+func New(deadline time.Duration, hub *events.Hub) *Coordinator {
+	if deadline <= 0 {
+		deadline = DefaultDeadline
+	}
+	return &Coordinator{deadline: deadline, hub: hub}
+}
+
+// SetPollCancel registers the cancel func for the poll.StartPoller
+// goroutine. Shutdown calls it first so no new poll cycle starts while
+// draining in-flight scrapes.
+func (c *Coordinator) SetPollCancel(cancel context.CancelFunc) {
+	c.pollCancel = cancel
+}
+
+// TrackScrape marks one in-flight ScrapeHandler.Run (or per-company
+// fetchCompany worker) as started; the caller must invoke the returned
+// func when it returns, typically via defer, so Shutdown's drain wait
+// is accurate.
+func (c *Coordinator) TrackScrape() func() {
+	c.scrapes.Add(1)
+	return c.scrapes.Done
+}
+
+// Shutdown cancels the poller, waits for in-flight scrapes to drain,
+// publishes a final {"type":"shutdown"} event and closes the SSE hub,
+// then flushes db. Each drain step is bounded by the Coordinator's
+// deadline; a step that doesn't finish in time is reported as
+// "timeout" rather than blocking the caller indefinitely.
+func (c *Coordinator) Shutdown(db *sql.DB) Report {
+	report := Report{Subsystems: map[string]string{}}
+
+	if c.pollCancel != nil {
+		c.pollCancel()
+	}
+	report.Subsystems["poller"] = "stopped"
+
+	if c.waitWithDeadline(&c.scrapes) {
+		report.Subsystems["scrapes"] = "drained"
+	} else {
+		report.Subsystems["scrapes"] = "timeout"
+	}
+
+	if c.hub != nil {
+		c.hub.Publish(events.MakeEvent("", "shutdown", 1, nil))
+		c.hub.Close()
+	}
+	report.Subsystems["sse"] = "closed"
+
+	if db != nil {
+		if err := db.Close(); err != nil {
+			report.Subsystems["db"] = "error: " + err.Error()
+		} else {
+			report.Subsystems["db"] = "flushed"
+		}
+	}
+
+	return report
+}
+
+// waitWithDeadline reports whether wg.Wait() returned before
+// c.deadline elapsed.
+func (c *Coordinator) waitWithDeadline(wg *sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(c.deadline):
+		return false
+	}
+}
@@ -0,0 +1,87 @@
+// Package log wraps log/slog with the engine's configured level,
+// format and output, plus a small in-memory tail buffer that
+// /logs/tail streams to the Tauri UI so scraper and IMAP failures can
+// be diagnosed without shelling into the data dir.
+package log
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"jobhunt-engine/internal/config"
+)
+
+var (
+	logger = slog.Default()
+	tail   = newRingBuffer(500)
+)
+
+// Init configures the package-level logger from cfg.Logging and
+// becomes the target of Debug/Info/Warn/Error/With/Fatal. Call once
+// at startup before anything else logs.
+func Init(cfg config.Config) (*slog.Logger, error) {
+	level := parseLevel(cfg.Logging.Level)
+
+	var out io.Writer = os.Stderr
+	if cfg.Logging.File != "" {
+		f, err := os.OpenFile(cfg.Logging.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("open log file: %w", err)
+		}
+		out = f
+	}
+
+	w := io.MultiWriter(out, tail)
+
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	if strings.EqualFold(cfg.Logging.Format, "text") {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+
+	logger = slog.New(handler)
+	slog.SetDefault(logger)
+	return logger, nil
+}
+
+func parseLevel(s string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func Debug(msg string, args ...any) { logger.Debug(msg, args...) }
+func Info(msg string, args ...any)  { logger.Info(msg, args...) }
+func Warn(msg string, args ...any)  { logger.Warn(msg, args...) }
+func Error(msg string, args ...any) { logger.Error(msg, args...) }
+
+// Fatal logs at error level, then exits the process with status 1 —
+// a drop-in for the stdlib log.Fatal/log.Fatalf calls it replaces.
+func Fatal(msg string, args ...any) {
+	logger.Error(msg, args...)
+	os.Exit(1)
+}
+
+// With returns a logger with the given contextual fields attached,
+// e.g. log.With("source", "greenhouse", "company", "acme").
+func With(args ...any) *slog.Logger { return logger.With(args...) }
+
+// Tail returns the most recently buffered log lines, oldest first.
+func Tail() []string { return tail.lines() }
+
+// Subscribe registers ch to receive every log line written from now
+// on; call the returned func to unsubscribe. Used by the /logs/tail
+// SSE endpoint.
+func Subscribe(ch chan string) (unsubscribe func()) { return tail.subscribe(ch) }
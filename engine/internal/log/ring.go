@@ -0,0 +1,59 @@
+package log
+
+import (
+	"strings"
+	"sync"
+)
+
+// ringBuffer is an io.Writer that keeps the last max lines written to
+// it and fans each one out to any subscribed channels, so /logs/tail
+// can stream new entries without re-reading the log file.
+type ringBuffer struct {
+	mu   sync.Mutex
+	max  int
+	buf  []string
+	subs map[chan string]struct{}
+}
+
+func newRingBuffer(max int) *ringBuffer {
+	return &ringBuffer{max: max, subs: map[chan string]struct{}{}}
+}
+
+func (r *ringBuffer) Write(p []byte) (int, error) {
+	line := strings.TrimRight(string(p), "\n")
+
+	r.mu.Lock()
+	r.buf = append(r.buf, line)
+	if len(r.buf) > r.max {
+		r.buf = r.buf[len(r.buf)-r.max:]
+	}
+	for ch := range r.subs {
+		select {
+		case ch <- line:
+		default:
+			// drop if the subscriber is slow; Tail() remains the source of truth
+		}
+	}
+	r.mu.Unlock()
+
+	return len(p), nil
+}
+
+func (r *ringBuffer) lines() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]string, len(r.buf))
+	copy(out, r.buf)
+	return out
+}
+
+func (r *ringBuffer) subscribe(ch chan string) func() {
+	r.mu.Lock()
+	r.subs[ch] = struct{}{}
+	r.mu.Unlock()
+	return func() {
+		r.mu.Lock()
+		delete(r.subs, ch)
+		r.mu.Unlock()
+	}
+}
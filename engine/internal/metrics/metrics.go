@@ -0,0 +1,156 @@
+// Package metrics holds the engine's Prometheus collectors so scrape
+// health can be alerted on instead of eyeballed from /scrape/status.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ScrapeRunsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jobhunt_scrape_runs_total",
+		Help: "Number of scrape runs per source, by outcome.",
+	}, []string{"source", "status"})
+
+	JobsIngestedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jobhunt_jobs_ingested_total",
+		Help: "Number of new job rows inserted, by source.",
+	}, []string{"source"})
+
+	JobsFetchedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jobhunt_scrape_jobs_fetched_total",
+		Help: "Number of candidate leads a scrape run returned, by source, before filtering/dedupe.",
+	}, []string{"source"})
+
+	ScrapeErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jobhunt_scrape_errors_total",
+		Help: "Number of scrape errors, by source and error kind.",
+	}, []string{"source", "kind"})
+
+	// ScrapeDurationSeconds times one scrape run, by source. mailbox is
+	// only meaningful for source="email" (which mailbox was swept);
+	// every other source passes "".
+	ScrapeDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "jobhunt_scrape_duration_seconds",
+		Help:    "Time to complete one scrape run, by source and (for email) mailbox.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"source", "mailbox"})
+
+	HydrateDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "jobhunt_hydrate_duration_seconds",
+		Help:    "Time to hydrate a single job posting page, by source.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"source"})
+
+	ScrapeLastSuccessTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jobhunt_scrape_last_success_timestamp_seconds",
+		Help: "Unix timestamp of the last successful scrape run, by source.",
+	}, []string{"source"})
+
+	SSEClients = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "jobhunt_sse_clients",
+		Help: "Number of currently connected /events SSE clients.",
+	})
+
+	EventsDroppedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "jobhunt_events_dropped_total",
+		Help: "Number of SSE subscribers disconnected for falling behind on published events.",
+	})
+
+	LogoCacheHitsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "jobhunt_logo_cache_hits_total",
+		Help: "Number of logo requests served from the on-disk cache without fetching upstream.",
+	})
+
+	LogoCacheMissesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "jobhunt_logo_cache_misses_total",
+		Help: "Number of logo requests that required a fresh upstream fetch.",
+	})
+
+	// EmailMessagesProcessedTotal counts every message
+	// email_scrape.RunEmailScrapeOnce looked at, by whether a
+	// scoring.pipeline rule routed it somewhere ("true") or it fell
+	// through untouched ("false").
+	EmailMessagesProcessedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jobhunt_email_messages_processed_total",
+		Help: "Email messages processed, by whether a pipeline rule matched.",
+	}, []string{"matched"})
+
+	// RuleHitsTotal counts scoring.title_rules/scoring.keyword_rules
+	// matches inside rank.YAMLScorer.Score, by the rule's tag.
+	RuleHitsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jobhunt_rule_hits_total",
+		Help: "scoring.title_rules / scoring.keyword_rules matches, by tag.",
+	}, []string{"tag"})
+
+	// ActiveConfigVersion increments every time httpapi.Deps.CfgVal is
+	// swapped with a freshly saved/reloaded config, so a dashboard can
+	// show a reload landing (or alert if one never does).
+	ActiveConfigVersion = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "jobhunt_active_config_version",
+		Help: "Incremented each time the active in-memory config is reloaded/replaced.",
+	})
+
+	// RateLimiterWaitSeconds times util.HostLimiter.WaitURL's blocking
+	// wait per host, so a saturated per-host rate limit shows up as
+	// rising latency rather than a silent slowdown.
+	RateLimiterWaitSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "jobhunt_rate_limiter_wait_seconds",
+		Help:    "Time spent waiting for a per-host rate limiter slot, by host.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"host"})
+
+	// JobsDuplicateTotal counts scrape.InsertJobIfNew calls that hit the
+	// jobs.source_id unique index instead of inserting a new row, by
+	// source, so dashboards can tell a quiet source apart from one
+	// that's only returning jobs already ingested.
+	JobsDuplicateTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jobhunt_jobs_duplicate_total",
+		Help: "Number of scrape.InsertJobIfNew calls that found an existing row, by source.",
+	}, []string{"source"})
+
+	// TargetUp, TargetLastScrapeTimestamp and TargetLeadsTotal are
+	// populated by internal/scrape/targets.Record, one scrape target
+	// (an ATS source or an email account) per source/company pair.
+	// company is "" for a source that doesn't report per-company
+	// results at its fetch boundary (every built-in ATS scraper today
+	// bundles all its configured companies into one Fetch call).
+	TargetUp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jobhunt_target_up",
+		Help: "1 if a scrape target's last attempt succeeded, 0 if it's currently down, by source and company.",
+	}, []string{"source", "company"})
+
+	TargetLastScrapeTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jobhunt_last_scrape_timestamp_seconds",
+		Help: "Unix timestamp of the last scrape attempt (success or failure) for a target, by source and company.",
+	}, []string{"source", "company"})
+
+	TargetLeadsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jobhunt_scrape_leads_total",
+		Help: "Number of leads a scrape target's fetch returned, by source and company.",
+	}, []string{"source", "company"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		ScrapeRunsTotal,
+		JobsIngestedTotal,
+		JobsFetchedTotal,
+		ScrapeErrorsTotal,
+		ScrapeDurationSeconds,
+		HydrateDurationSeconds,
+		ScrapeLastSuccessTimestamp,
+		SSEClients,
+		EventsDroppedTotal,
+		LogoCacheHitsTotal,
+		LogoCacheMissesTotal,
+		EmailMessagesProcessedTotal,
+		RuleHitsTotal,
+		ActiveConfigVersion,
+		RateLimiterWaitSeconds,
+		JobsDuplicateTotal,
+		TargetUp,
+		TargetLastScrapeTimestamp,
+		TargetLeadsTotal,
+	)
+}
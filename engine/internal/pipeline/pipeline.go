@@ -0,0 +1,258 @@
+// Package pipeline interprets a small, Sieve-style rule script against
+// an incoming message: an ordered list of rules, each with a when
+// predicate and a then list of actions, turns today's hardcoded
+// LinkedIn-job-alert special-case into one rule among many that users
+// can extend (Indeed, Otta, Wellfound, ...) without recompiling. The
+// interpreter itself is pure - it only reads Input and returns which
+// rules fired plus the accumulated Result, with no DB or network
+// access, so it's safe to run from a dry-run endpoint as well as from
+// the real scrape path.
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Input is the subset of an email message a rule can match against or
+// extract from.
+type Input struct {
+	Subject  string
+	From     string
+	Domain   string // sender's domain, e.g. "jobalerts-noreply@linkedin.com" -> "linkedin.com"
+	BodyText string
+	HTMLBody string
+}
+
+// When is a rule's trigger predicate. Every non-empty field must match
+// (AND); a zero-value When always matches.
+type When struct {
+	SubjectRegex string
+	FromRegex    string
+	BodyRegex    string
+	Domain       string // case-insensitive substring match against Input.Domain
+}
+
+// Action is one parsed then entry, e.g. "set_company:LinkedIn" becomes
+// Action{Kind: "set_company", Arg: "LinkedIn"}.
+type Action struct {
+	Kind string
+	Arg  string
+}
+
+// Known action kinds. drop takes no argument; every other kind
+// requires one.
+const (
+	ActionSetCompany           = "set_company"
+	ActionSetTitleFromSelector = "set_title_from_selector"
+	ActionExtractURLsMatching  = "extract_urls_matching"
+	ActionApplyPenalty         = "apply_penalty"
+	ActionDrop                 = "drop"
+	ActionRouteTo              = "route_to"
+)
+
+var knownActionKinds = map[string]bool{
+	ActionSetCompany:           true,
+	ActionSetTitleFromSelector: true,
+	ActionExtractURLsMatching:  true,
+	ActionApplyPenalty:         true,
+	ActionDrop:                 true,
+	ActionRouteTo:              true,
+}
+
+// Rule is one compiled scoring.pipeline entry. Stop ends the
+// interpreter after Then runs, so later rules don't also fire.
+type Rule struct {
+	Name string
+	When When
+	Then []Action
+	Stop bool
+}
+
+// Result accumulates the effect of every fired rule's actions, in
+// order. RouteTo names which per-site parser (if any) should take over
+// from here, e.g. "linkedin"; a Result with no RouteTo and no Dropped
+// is left for the generic URL-extraction path.
+type Result struct {
+	Dropped      bool
+	Company      string
+	Title        string
+	URLs         []string
+	PenaltyTotal int
+	RouteTo      string
+}
+
+// ParseAction parses one then string ("set_company:Acme",
+// "route_to:linkedin", "drop") into an Action, validating the kind is
+// known, that non-drop kinds carry an argument, and that
+// apply_penalty/extract_urls_matching arguments are well-formed.
+func ParseAction(raw string) (Action, error) {
+	kind, arg, _ := strings.Cut(raw, ":")
+	kind = strings.TrimSpace(kind)
+	arg = strings.TrimSpace(arg)
+
+	if !knownActionKinds[kind] {
+		return Action{}, fmt.Errorf("pipeline: unknown action %q", raw)
+	}
+	if kind != ActionDrop && arg == "" {
+		return Action{}, fmt.Errorf("pipeline: action %q requires an argument", kind)
+	}
+	switch kind {
+	case ActionApplyPenalty:
+		if _, err := strconv.Atoi(arg); err != nil {
+			return Action{}, fmt.Errorf("pipeline: apply_penalty arg %q is not an integer", arg)
+		}
+	case ActionExtractURLsMatching:
+		if _, err := regexp.Compile(arg); err != nil {
+			return Action{}, fmt.Errorf("pipeline: extract_urls_matching: %w", err)
+		}
+	}
+	return Action{Kind: kind, Arg: arg}, nil
+}
+
+// ValidateWhen compiles w's regex fields (skipping empty ones),
+// returning the first compile error found.
+func ValidateWhen(w When) error {
+	if w.SubjectRegex != "" {
+		if _, err := regexp.Compile(w.SubjectRegex); err != nil {
+			return fmt.Errorf("pipeline: subject_regex: %w", err)
+		}
+	}
+	if w.FromRegex != "" {
+		if _, err := regexp.Compile(w.FromRegex); err != nil {
+			return fmt.Errorf("pipeline: from_regex: %w", err)
+		}
+	}
+	if w.BodyRegex != "" {
+		if _, err := regexp.Compile(w.BodyRegex); err != nil {
+			return fmt.Errorf("pipeline: body_regex: %w", err)
+		}
+	}
+	return nil
+}
+
+// CompileRule validates when and parses thenRaw into a Rule, prefixing
+// any error with the rule's name so config validation can report which
+// scoring.pipeline entry is broken.
+func CompileRule(name string, when When, thenRaw []string, stop bool) (Rule, error) {
+	if err := ValidateWhen(when); err != nil {
+		return Rule{}, fmt.Errorf("rule %q: %w", name, err)
+	}
+	actions := make([]Action, 0, len(thenRaw))
+	for _, raw := range thenRaw {
+		a, err := ParseAction(raw)
+		if err != nil {
+			return Rule{}, fmt.Errorf("rule %q: %w", name, err)
+		}
+		actions = append(actions, a)
+	}
+	return Rule{Name: name, When: when, Then: actions, Stop: stop}, nil
+}
+
+// Run evaluates rules against in, in order, returning the names of
+// every rule that fired and the Result their actions produced. A rule
+// whose When doesn't match is skipped; rules stop firing once one with
+// Stop: true matches.
+func Run(rules []Rule, in Input) (fired []string, result Result) {
+	for _, r := range rules {
+		if !matches(r.When, in) {
+			continue
+		}
+		fired = append(fired, r.Name)
+		for _, a := range r.Then {
+			apply(a, in, &result)
+		}
+		if r.Stop {
+			break
+		}
+	}
+	return fired, result
+}
+
+func matches(w When, in Input) bool {
+	if w.SubjectRegex != "" {
+		re, err := regexp.Compile(w.SubjectRegex)
+		if err != nil || !re.MatchString(in.Subject) {
+			return false
+		}
+	}
+	if w.FromRegex != "" {
+		re, err := regexp.Compile(w.FromRegex)
+		if err != nil || !re.MatchString(in.From) {
+			return false
+		}
+	}
+	if w.BodyRegex != "" {
+		re, err := regexp.Compile(w.BodyRegex)
+		if err != nil || !re.MatchString(in.BodyText) {
+			return false
+		}
+	}
+	if w.Domain != "" && !strings.Contains(strings.ToLower(in.Domain), strings.ToLower(w.Domain)) {
+		return false
+	}
+	return true
+}
+
+func apply(a Action, in Input, result *Result) {
+	switch a.Kind {
+	case ActionDrop:
+		result.Dropped = true
+	case ActionSetCompany:
+		result.Company = a.Arg
+	case ActionSetTitleFromSelector:
+		if in.HTMLBody == "" {
+			return
+		}
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(in.HTMLBody))
+		if err != nil {
+			return
+		}
+		if text := strings.TrimSpace(doc.Find(a.Arg).First().Text()); text != "" {
+			result.Title = text
+		}
+	case ActionExtractURLsMatching:
+		re, err := regexp.Compile(a.Arg)
+		if err != nil {
+			return
+		}
+		result.URLs = append(result.URLs, re.FindAllString(in.HTMLBody, -1)...)
+	case ActionApplyPenalty:
+		if n, err := strconv.Atoi(a.Arg); err == nil {
+			result.PenaltyTotal += n
+		}
+	case ActionRouteTo:
+		result.RouteTo = a.Arg
+	}
+}
+
+// DefaultRules reproduces today's hardcoded LinkedIn-job-alert
+// detection as a single built-in rule, so installs with an empty
+// scoring.pipeline keep routing LinkedIn mail the same way they always
+// have. It's equivalent to the old looksLikeLinkedInJobAlert(from,
+// subj, body) check: sender looks like LinkedIn's alert bot, or the
+// subject mentions a job alert/LinkedIn and the body links to a job
+// view page.
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			Name: "linkedin-job-alert",
+			When: When{FromRegex: `(?i)jobalerts-noreply`},
+			Then: []Action{{Kind: ActionRouteTo, Arg: "linkedin"}},
+			Stop: true,
+		},
+		{
+			Name: "linkedin-job-alert-subject",
+			When: When{
+				SubjectRegex: `(?i)(job alert|linkedin)`,
+				BodyRegex:    `(?i)linkedin\.com/(comm/)?jobs/view`,
+			},
+			Then: []Action{{Kind: ActionRouteTo, Arg: "linkedin"}},
+			Stop: true,
+		},
+	}
+}
@@ -6,8 +6,6 @@ import (
 	"jobhunt-engine/internal/config"
 	"jobhunt-engine/internal/scrape"
 	email_scrape "jobhunt-engine/internal/scrape/email"
-	"jobhunt-engine/internal/scrape/greenhouse"
-	"jobhunt-engine/internal/scrape/lever"
 	"jobhunt-engine/internal/scrape/types"
 	"jobhunt-engine/internal/scrape/util"
 	"log"
@@ -21,17 +19,11 @@ func PollOnce(db *sql.DB, cfg config.Config, onNewJob func()) (added int, err er
 
 	limiter := util.NewHostLimiter(1.0, 2)
 
-	// Build list based on enabled flags
-	var fetchers []types.Fetcher
-
-	if cfg.Sources.Greenhouse.Enabled {
-		gh := greenhouse.New(greenhouse.Config{Companies: scrape.MapGreenhouseCompanies(cfg.Sources.Greenhouse.Companies)}, limiter)
-		fetchers = append(fetchers, gh)
-	}
-	if cfg.Sources.Lever.Enabled {
-		lv := lever.New(lever.Config{Companies: scrape.MapLeverCompanies(cfg.Sources.Lever.Companies)}, limiter)
-		fetchers = append(fetchers, lv)
-	}
+	// Built-in ATS backends (Greenhouse, Lever, SmartRecruiters, ...)
+	// register themselves with internal/scrape/types; EnabledFetchers
+	// picks the ones whose config section is turned on, so wiring up a
+	// new backend no longer means editing this function.
+	fetchers := types.EnabledFetchers(cfg, limiter)
 	if cfg.Email.Enabled {
 		fetchers = append(fetchers, &email_scrape.EmailFetcher{Cfg: cfg})
 	}
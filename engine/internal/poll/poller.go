@@ -1,6 +1,7 @@
 package poll
 
 import (
+	"context"
 	"database/sql"
 	"log"
 	"sync/atomic"
@@ -8,16 +9,29 @@ import (
 
 	"jobhunt-engine/internal/config"
 	"jobhunt-engine/internal/events"
+	"jobhunt-engine/internal/metrics"
 	"jobhunt-engine/internal/scrape"
 )
 
-func StartPoller(db *sql.DB, cfgVal *atomic.Value, scrapeStatus *atomic.Value, hub *events.Hub) {
+// pollSource labels the metrics this loop emits around PollOnce, the
+// same "source" dimension per-ATS scrapers use.
+const pollSource = "poll"
+
+// StartPoller runs the poll loop until ctx is canceled (see
+// lifecycle.Coordinator.SetPollCancel), instead of running forever as
+// a bare goroutine with no way to stop it.
+func StartPoller(ctx context.Context, db *sql.DB, cfgVal *atomic.Value, scrapeStatus *atomic.Value, hub *events.Hub) {
 	// Simple ticker loop; you can expand to fast/normal lanes later.
 	go func() {
 		t := time.NewTicker(30 * time.Second)
 		defer t.Stop()
 
-		for range t.C {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
 			cfgAny := cfgVal.Load()
 			if cfgAny == nil {
 				continue
@@ -39,10 +53,12 @@ func StartPoller(db *sql.DB, cfgVal *atomic.Value, scrapeStatus *atomic.Value, h
 			st.LastRunAt = time.Now().Format(time.RFC3339)
 			scrapeStatus.Store(st)
 
+			pollStart := time.Now()
 			added, err := PollOnce(db, cfg, func() {
 				// SSE notify
 				hub.Publish(`{"type":"job_created"}`)
 			})
+			metrics.ScrapeDurationSeconds.WithLabelValues(pollSource, "").Observe(time.Since(pollStart).Seconds())
 
 			// Update status
 			stAny = scrapeStatus.Load()
@@ -56,10 +72,14 @@ func StartPoller(db *sql.DB, cfgVal *atomic.Value, scrapeStatus *atomic.Value, h
 			if err != nil {
 				st.LastError = err.Error()
 				log.Printf("[poll] error: %v", err)
+				metrics.ScrapeErrorsTotal.WithLabelValues(pollSource, "run").Inc()
+				metrics.ScrapeRunsTotal.WithLabelValues(pollSource, "error").Inc()
 			} else {
 				st.LastError = ""
 				st.LastOkAt = time.Now().Format(time.RFC3339)
 				log.Printf("[poll] ok added=%d", added)
+				metrics.ScrapeRunsTotal.WithLabelValues(pollSource, "success").Inc()
+				metrics.ScrapeLastSuccessTimestamp.WithLabelValues(pollSource).SetToCurrentTime()
 			}
 			scrapeStatus.Store(st)
 		}
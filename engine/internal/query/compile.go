@@ -0,0 +1,122 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Compile renders expr as a SQL boolean fragment suitable for
+// "WHERE <frag>" against the jobs table, plus its positional args in
+// the order their "?" placeholders appear. A nil expr (from
+// Parse("")) compiles to "1=1" so callers never need a special case
+// for an empty query.
+func Compile(expr Expr) (string, []any) {
+	if expr == nil {
+		return "1=1", nil
+	}
+	var b strings.Builder
+	var args []any
+	expr.compile(&b, &args)
+	return b.String(), args
+}
+
+func (e fieldTerm) compile(b *strings.Builder, args *[]any) {
+	switch e.field {
+	case "company":
+		b.WriteString(`company LIKE ? ESCAPE '\'`)
+		*args = append(*args, likePattern(e.value))
+	case "title":
+		b.WriteString(`title LIKE ? ESCAPE '\'`)
+		*args = append(*args, likePattern(e.value))
+	case "loc":
+		b.WriteString(`location LIKE ? ESCAPE '\'`)
+		*args = append(*args, likePattern(e.value))
+	case "mode":
+		values := strings.Split(e.value, "|")
+		placeholders := make([]string, len(values))
+		for i, v := range values {
+			placeholders[i] = "?"
+			*args = append(*args, v)
+		}
+		fmt.Fprintf(b, "work_mode IN (%s)", strings.Join(placeholders, ","))
+	case "tag":
+		// tags is a JSON array (see store.Job); matching `"golang"`
+		// with its quotes avoids "golang" also hitting "golanguage".
+		b.WriteString(`tags LIKE ? ESCAPE '\'`)
+		*args = append(*args, `%"`+escapeLike(e.value)+`"%`)
+	case "score":
+		n, _ := strconv.Atoi(e.value) // already validated by parseTerm
+		fmt.Fprintf(b, "score %s ?", e.op)
+		*args = append(*args, n)
+	case "after", "before":
+		op := ">="
+		if e.field == "before" {
+			op = "<"
+		}
+		if e.op != "=" {
+			op = e.op
+		}
+		frag, arg := dateBound(e.value)
+		fmt.Fprintf(b, "date %s %s", op, frag)
+		*args = append(*args, arg)
+	}
+}
+
+func (e freeText) compile(b *strings.Builder, args *[]any) {
+	// jobs has no stored description column; tags is the closest
+	// stand-in for body text a free-text token should also match.
+	b.WriteString(`(title LIKE ? ESCAPE '\' OR tags LIKE ? ESCAPE '\')`)
+	pattern := likePattern(e.value)
+	*args = append(*args, pattern, pattern)
+}
+
+func (e notExpr) compile(b *strings.Builder, args *[]any) {
+	b.WriteString("NOT (")
+	e.x.compile(b, args)
+	b.WriteString(")")
+}
+
+func (e andExpr) compile(b *strings.Builder, args *[]any) {
+	b.WriteString("(")
+	e.l.compile(b, args)
+	b.WriteString(" AND ")
+	e.r.compile(b, args)
+	b.WriteString(")")
+}
+
+func (e orExpr) compile(b *strings.Builder, args *[]any) {
+	b.WriteString("(")
+	e.l.compile(b, args)
+	b.WriteString(" OR ")
+	e.r.compile(b, args)
+	b.WriteString(")")
+}
+
+var relDurationRe = regexp.MustCompile(`^(\d+)(d|h|m)$`)
+
+// dateBound turns an after/before value into a SQL fragment plus the
+// one arg it needs: "?" with the literal date for an absolute value
+// like "2024-01-01" (jobs.date is an ISO8601/RFC3339 TEXT column, so
+// lexical comparison against a date prefix works), or
+// "datetime('now', ?)" with a SQLite modifier like "-7 days" for a
+// relative duration like "7d".
+func dateBound(value string) (frag string, arg any) {
+	if m := relDurationRe.FindStringSubmatch(value); m != nil {
+		unit := map[string]string{"d": "days", "h": "hours", "m": "minutes"}[m[2]]
+		return "datetime('now', ?)", fmt.Sprintf("-%s %s", m[1], unit)
+	}
+	return "?", value
+}
+
+// escapeLike backslash-escapes SQLite LIKE's wildcard characters so a
+// user's literal "%"/"_" can't widen the match.
+func escapeLike(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return r.Replace(s)
+}
+
+func likePattern(s string) string {
+	return "%" + escapeLike(s) + "%"
+}
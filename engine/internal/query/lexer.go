@@ -0,0 +1,43 @@
+package query
+
+import "fmt"
+
+// tokenize splits q into tokens: "(", ")", and everything else
+// (keywords, field:value terms, bare words), treating double-quoted
+// spans as a single token so `title:"staff eng"` survives as one
+// token instead of splitting on the space inside the quotes.
+func tokenize(q string) ([]string, error) {
+	var toks []string
+	var b []byte
+	inQuotes := false
+
+	flush := func() {
+		if len(b) > 0 {
+			toks = append(toks, string(b))
+			b = b[:0]
+		}
+	}
+
+	for i := 0; i < len(q); i++ {
+		c := q[i]
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+			b = append(b, c)
+		case inQuotes:
+			b = append(b, c)
+		case c == '(' || c == ')':
+			flush()
+			toks = append(toks, string(c))
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			flush()
+		default:
+			b = append(b, c)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("query: unterminated quote")
+	}
+	flush()
+	return toks, nil
+}
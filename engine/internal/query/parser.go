@@ -0,0 +1,217 @@
+// Package query parses a compact, aerc-style search grammar and
+// compiles it to a parameterized SQL WHERE clause against the jobs
+// table:
+//
+//	company:stripe title:"staff eng" loc:remote score:>=70 tag:golang
+//	mode:remote|hybrid after:2024-01-01 before:7d
+//	(golang OR rust) AND NOT loc:onsite
+//
+// Terms with no "field:" prefix are free text, matched against title
+// OR tags (jobs has no stored description column, so tags is the
+// closest stand-in for body text). Boolean composition is AND/OR/NOT
+// with parentheses for grouping; adjacent terms with no operator
+// between them are implicitly AND'd. Compile never string-concats a
+// user-supplied value into SQL - every value becomes a "?" arg.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// fields recognized as "field:value" prefixes, and the column/LIKE
+// semantics each compiles to. See compile.go for how each is rendered.
+var knownFields = map[string]bool{
+	"company": true,
+	"title":   true,
+	"loc":     true,
+	"score":   true,
+	"tag":     true,
+	"mode":    true,
+	"after":   true,
+	"before":  true,
+}
+
+// Expr is one node of a parsed query: a field term, a free-text term,
+// or a boolean combinator over sub-expressions.
+type Expr interface {
+	compile(b *strings.Builder, args *[]any)
+}
+
+type fieldTerm struct {
+	field string
+	op    string // "=", ">", ">=", "<", "<="; score/date fields only
+	value string
+}
+
+type freeText struct {
+	value string
+}
+
+type notExpr struct{ x Expr }
+type andExpr struct{ l, r Expr }
+type orExpr struct{ l, r Expr }
+
+// Parse parses q into an Expr ready for Compile. An empty or
+// whitespace-only q returns a nil Expr (Compile renders that as "no
+// filter").
+func Parse(q string) (Expr, error) {
+	toks, err := tokenize(q)
+	if err != nil {
+		return nil, err
+	}
+	if len(toks) == 0 {
+		return nil, nil
+	}
+
+	p := &parser{toks: toks}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.toks) {
+		return nil, fmt.Errorf("query: unexpected token %q", p.toks[p.pos])
+	}
+	return expr, nil
+}
+
+type parser struct {
+	toks []string
+	pos  int
+}
+
+func (p *parser) peek() (string, bool) {
+	if p.pos >= len(p.toks) {
+		return "", false
+	}
+	return p.toks[p.pos], true
+}
+
+func isKeyword(tok, kw string) bool { return strings.EqualFold(tok, kw) }
+
+// parseOr := parseAnd (OR parseAnd)*
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || !isKeyword(tok, "OR") {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{l: left, r: right}
+	}
+}
+
+// parseAnd := parseUnary ((AND)? parseUnary)*  -- AND is implicit
+// between two adjacent terms/groups, explicit "AND" is also accepted.
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.peek()
+		if !ok || isKeyword(tok, "OR") || tok == ")" {
+			return left, nil
+		}
+		if isKeyword(tok, "AND") {
+			p.pos++
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{l: left, r: right}
+	}
+}
+
+// parseUnary := NOT parseUnary | parsePrimary
+func (p *parser) parseUnary() (Expr, error) {
+	if tok, ok := p.peek(); ok && isKeyword(tok, "NOT") {
+		p.pos++
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary := "(" parseOr ")" | term
+func (p *parser) parsePrimary() (Expr, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("query: unexpected end of input")
+	}
+	if tok == "(" {
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		close, ok := p.peek()
+		if !ok || close != ")" {
+			return nil, fmt.Errorf("query: missing closing paren")
+		}
+		p.pos++
+		return expr, nil
+	}
+	if tok == ")" {
+		return nil, fmt.Errorf("query: unexpected %q", tok)
+	}
+	p.pos++
+	return parseTerm(tok)
+}
+
+// parseTerm turns one token into a fieldTerm or freeText node.
+// field:op:value terms: "field:value", with score/after/before values
+// optionally prefixed by a comparison operator (score:>=70) - see
+// splitOp.
+func parseTerm(tok string) (Expr, error) {
+	field, rest, hasField := strings.Cut(tok, ":")
+	field = strings.ToLower(field)
+	if !hasField || !knownFields[field] {
+		return freeText{value: unquote(tok)}, nil
+	}
+
+	op, value := splitOp(rest)
+	value = unquote(value)
+	if value == "" {
+		return nil, fmt.Errorf("query: %s: missing value", field)
+	}
+
+	if field == "score" {
+		if _, err := strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("query: score: %q is not an integer", value)
+		}
+	}
+
+	return fieldTerm{field: field, op: op, value: value}, nil
+}
+
+// splitOp peels a leading comparison operator (>=, <=, >, <, =) off
+// value, defaulting to "=" when none is present.
+func splitOp(value string) (op, rest string) {
+	for _, candidate := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(value, candidate) {
+			return candidate, strings.TrimPrefix(value, candidate)
+		}
+	}
+	return "=", value
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
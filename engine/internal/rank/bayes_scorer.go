@@ -0,0 +1,143 @@
+package rank
+
+import (
+	"math"
+	"strings"
+	"sync"
+
+	"jobhunt-engine/internal/domain"
+)
+
+// stopwords are dropped from Tokenize so common words don't dilute the
+// handful of tokens that actually carry signal about relevance.
+var stopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "has": true, "in": true,
+	"is": true, "it": true, "of": true, "on": true, "or": true, "that": true,
+	"the": true, "this": true, "to": true, "we": true, "will": true, "with": true,
+	"you": true, "your": true,
+}
+
+// Tokenize lowercases text, strips everything but letters, and splits
+// it into unigrams, dropping stopwords and anything shorter than 2
+// letters. Both training (BayesHandler.Feedback) and scoring
+// (BayesScorer.Score) use this so they agree on what a "token" is.
+func Tokenize(text string) []string {
+	text = strings.ToLower(text)
+
+	var out []string
+	var b strings.Builder
+	flush := func() {
+		if b.Len() == 0 {
+			return
+		}
+		w := b.String()
+		b.Reset()
+		if len(w) < 2 || stopwords[w] {
+			return
+		}
+		out = append(out, w)
+	}
+	for _, r := range text {
+		if r >= 'a' && r <= 'z' {
+			b.WriteRune(r)
+		} else {
+			flush()
+		}
+	}
+	flush()
+	return out
+}
+
+// BayesTokenStats mirrors store.BayesTokenStats, kept as a separate
+// type so rank doesn't need to import the store package just to hold
+// a snapshot of it.
+type BayesTokenStats struct {
+	Positive int64
+	Negative int64
+}
+
+// BayesScorer is a rank.Scorer that learns job relevance from user
+// feedback ("interested"/"applied" vs. "dismissed") instead of
+// hand-written rules. It holds an in-memory snapshot of bayes_tokens,
+// refreshed by Load (called right after a feedback write, and
+// periodically by httpapi.StartBayesRetrainLoop), so Score never
+// touches the DB itself.
+type BayesScorer struct {
+	mu            sync.RWMutex
+	tokens        map[string]BayesTokenStats
+	totalPositive int64
+	totalNegative int64
+}
+
+func NewBayesScorer() *BayesScorer {
+	return &BayesScorer{}
+}
+
+// Load replaces the scorer's token snapshot wholesale. A nil or empty
+// tokens map (e.g. right after /api/bayes/reset) makes Score return 0
+// for everything.
+func (b *BayesScorer) Load(tokens map[string]BayesTokenStats) {
+	var totalPos, totalNeg int64
+	for _, c := range tokens {
+		totalPos += c.Positive
+		totalNeg += c.Negative
+	}
+
+	b.mu.Lock()
+	b.tokens = tokens
+	b.totalPositive = totalPos
+	b.totalNegative = totalNeg
+	b.mu.Unlock()
+}
+
+// logOddsScale controls how many tokens' worth of evidence it takes
+// for Score's tanh squash to approach its +-100 cap.
+const logOddsScale = 8.0
+
+// Score tokenizes job's title and description (the only fields
+// domain.JobLead carries pre-insert; stored jobs are tokenized on
+// title+tags instead, since the jobs table doesn't persist
+// description) and returns a Laplace-smoothed naive-Bayes log-odds
+// estimate of relevance, squashed to -100..100. It never assigns tags;
+// that stays YAMLScorer's job. Returns (0, nil) until at least one
+// token has been trained.
+func (b *BayesScorer) Score(job domain.JobLead) (int, []string) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	vocab := len(b.tokens)
+	if vocab == 0 {
+		return 0, nil
+	}
+
+	text := job.Title + " " + job.Description
+	logOdds := 0.0
+	for _, tok := range Tokenize(text) {
+		c := b.tokens[tok]
+		pPos := float64(c.Positive+1) / float64(b.totalPositive+int64(vocab))
+		pNeg := float64(c.Negative+1) / float64(b.totalNegative+int64(vocab))
+		logOdds += math.Log(pPos) - math.Log(pNeg)
+	}
+
+	delta := math.Tanh(logOdds/logOddsScale) * 100
+	return int(math.Round(delta)), nil
+}
+
+// BlendedScorer combines YAML's rule-based score with Bayes's learned
+// relevance delta, weighted by Weight (config.Scoring.BayesWeight). A
+// nil Bayes or zero Weight makes this behave exactly like YAML alone.
+type BlendedScorer struct {
+	YAML   YAMLScorer
+	Bayes  *BayesScorer
+	Weight float64
+}
+
+func (s BlendedScorer) Score(job domain.JobLead) (int, []string) {
+	score, tags := s.YAML.Score(job)
+	if s.Bayes == nil || s.Weight == 0 {
+		return score, tags
+	}
+	delta, _ := s.Bayes.Score(job)
+	return score + int(s.Weight*float64(delta)), tags
+}
@@ -2,10 +2,12 @@
 package rank
 
 import (
+	"math"
 	"strings"
 
 	"jobhunt-engine/internal/config"
 	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/metrics"
 )
 
 type YAMLScorer struct {
@@ -15,7 +17,7 @@ type YAMLScorer struct {
 func (s YAMLScorer) Score(job domain.JobLead) (int, []string) {
 	text := strings.ToLower(job.Title + " " + job.Description)
 
-	score := 0
+	score := 0.0
 	var tags []string
 
 	applyRules := func(rules []config.Rule) {
@@ -25,6 +27,7 @@ func (s YAMLScorer) Score(job domain.JobLead) (int, []string) {
 				if strings.Contains(text, n) {
 					score += r.Weight
 					tags = append(tags, r.Tag)
+					metrics.RuleHitsTotal.WithLabelValues(r.Tag).Inc()
 					break
 				}
 			}
@@ -44,7 +47,7 @@ func (s YAMLScorer) Score(job domain.JobLead) (int, []string) {
 		}
 	}
 
-	return score, uniq(tags)
+	return int(math.Round(score)), uniq(tags)
 }
 
 func uniq(in []string) []string {
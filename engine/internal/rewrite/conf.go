@@ -0,0 +1,83 @@
+package rewrite
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Parse reads a rewrites.conf file from r: one Rule per non-blank,
+// non-comment ("#") line, each written like
+//
+//	host=linkedin.com path=/jobs/view/:id => https://www.linkedin.com/jobs/view/:id
+//	host=linkedin.com query=currentJobId => https://www.linkedin.com/jobs/view/:currentJobId
+//
+// in the order they should be tried — see Redirects.Match.
+func Parse(r io.Reader) ([]Rule, error) {
+	var rules []Rule
+	sc := bufio.NewScanner(r)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("rewrites.conf line %d: %w", lineNo, err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// LoadFile reads and parses the rewrites.conf at path.
+func LoadFile(path string) ([]Rule, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+func parseLine(line string) (Rule, error) {
+	lhs, template, ok := strings.Cut(line, "=>")
+	if !ok {
+		return Rule{}, fmt.Errorf("missing \"=>\" in %q", line)
+	}
+
+	var rule Rule
+	rule.Template = strings.TrimSpace(template)
+	if rule.Template == "" {
+		return Rule{}, fmt.Errorf("empty template in %q", line)
+	}
+
+	for _, field := range strings.Fields(lhs) {
+		k, v, ok := strings.Cut(field, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("malformed field %q", field)
+		}
+		switch k {
+		case "host":
+			rule.Host = v
+		case "path":
+			rule.Path = v
+		case "query":
+			rule.Query = v
+		default:
+			return Rule{}, fmt.Errorf("unknown field %q", k)
+		}
+	}
+	if rule.Host == "" {
+		return Rule{}, fmt.Errorf("rule has no host: %q", line)
+	}
+	if rule.Path != "" && rule.Query != "" {
+		return Rule{}, fmt.Errorf("rule has both path and query: %q", line)
+	}
+	return rule, nil
+}
@@ -0,0 +1,16 @@
+package rewrite
+
+// Defaults returns the rules the engine ships with out of the box:
+// LinkedIn's two job-URL shapes, the canonical /jobs/view/<id> path
+// and the "currentJobId" query param used by "Jobs you may be
+// interested in" redirect emails. A rewrites.conf loaded via LoadFile
+// is meant to be prepended to these (see jobboard.LoadRewritesFile),
+// not replace them, so a user only has to list the sites they're
+// adding or overriding.
+func Defaults() []Rule {
+	return []Rule{
+		{Host: "linkedin.com", Path: "/jobs/view/:id", Template: "https://www.linkedin.com/jobs/view/:id"},
+		{Host: "linkedin.com", Path: "/comm/jobs/view/:id", Template: "https://www.linkedin.com/jobs/view/:id"},
+		{Host: "linkedin.com", Query: "currentJobId", Template: "https://www.linkedin.com/jobs/view/:currentJobId"},
+	}
+}
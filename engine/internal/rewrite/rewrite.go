@@ -0,0 +1,134 @@
+// Package rewrite generalizes the LinkedIn-only URL canonicalization
+// that used to live inline in the email scraper into a small rule
+// engine, configured like a Netlify-style `_redirects` file, so a new
+// site (Glassdoor, Wellfound, a company's own Greenhouse board) can be
+// taught to the engine without a recompile.
+package rewrite
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Rule rewrites one matching URL to Template, either by extracting a
+// named segment from Path (":id" in "/jobs/view/:id") or a query
+// parameter named Query. Exactly one of Path/Query is set.
+type Rule struct {
+	Host     string // required host, e.g. "linkedin.com"; subdomains match too
+	Path     string // glob pattern with optional ":name" capture segments
+	Query    string // query param name to capture, used when Path == ""
+	Template string // output URL; ":name" is replaced with the captured value
+}
+
+// Redirects is an ordered collection of Rules: Match tries them in
+// order and returns the first one whose Host/Path/Query all match,
+// so earlier rules (e.g. user overrides listed first in rewrites.conf)
+// take precedence over later ones (the built-in defaults).
+type Redirects struct {
+	rules []Rule
+}
+
+// New returns a Redirects holding rules, in the precedence order given.
+func New(rules []Rule) *Redirects {
+	return &Redirects{rules: rules}
+}
+
+// Match finds the first Rule that applies to u and reports whether one
+// did; it does not return the captured value (Rewrite applies the rule).
+func (r *Redirects) Match(u *url.URL) (*Rule, bool) {
+	for i := range r.rules {
+		rule := &r.rules[i]
+		if rule.matches(u) {
+			return rule, true
+		}
+	}
+	return nil, false
+}
+
+// Rewrite finds the first matching Rule and applies it, returning ("",
+// false) if nothing matches.
+func (r *Redirects) Rewrite(u *url.URL) (string, bool) {
+	rule, ok := r.Match(u)
+	if !ok {
+		return "", false
+	}
+	return rule.apply(u), true
+}
+
+func (rule *Rule) matches(u *url.URL) bool {
+	if rule.Host != "" && !hostMatches(u, rule.Host) {
+		return false
+	}
+	if rule.Query != "" {
+		return u.Query().Has(rule.Query)
+	}
+	if rule.Path != "" {
+		_, ok := matchPath(rule.Path, u.Path)
+		return ok
+	}
+	return true
+}
+
+func (rule *Rule) apply(u *url.URL) string {
+	var name, value string
+	if rule.Query != "" {
+		name, value = rule.Query, u.Query().Get(rule.Query)
+	} else if rule.Path != "" {
+		captured, _ := matchPath(rule.Path, u.Path)
+		for k, v := range captured {
+			name, value = k, v
+			break
+		}
+	}
+	if name == "" {
+		return rule.Template
+	}
+	return strings.ReplaceAll(rule.Template, ":"+name, value)
+}
+
+// matchPath matches pattern against actual segment-by-segment: a
+// ":name" segment captures actual's segment unconditionally, anything
+// else is matched with path.Match so "*"/"?"/"[...]" globs still work.
+func matchPath(pattern, actual string) (map[string]string, bool) {
+	pSegs := strings.Split(strings.Trim(pattern, "/"), "/")
+	aSegs := strings.Split(strings.Trim(actual, "/"), "/")
+	if len(pSegs) != len(aSegs) {
+		return nil, false
+	}
+
+	var captured map[string]string
+	for i, p := range pSegs {
+		a := aSegs[i]
+		if name, ok := strings.CutPrefix(p, ":"); ok {
+			if captured == nil {
+				captured = make(map[string]string, 1)
+			}
+			captured[name] = a
+			continue
+		}
+		ok, err := path.Match(p, a)
+		if err != nil || !ok {
+			return nil, false
+		}
+	}
+	return captured, true
+}
+
+// hostMatches reports whether u's host is exactly domain or a
+// subdomain of it, ignoring a leading "www.".
+func hostMatches(u *url.URL, domain string) bool {
+	host := strings.ToLower(u.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+func (rule Rule) String() string {
+	switch {
+	case rule.Query != "":
+		return fmt.Sprintf("host=%s query=%s => %s", rule.Host, rule.Query, rule.Template)
+	default:
+		return fmt.Sprintf("host=%s path=%s => %s", rule.Host, rule.Path, rule.Template)
+	}
+}
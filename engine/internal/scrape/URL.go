@@ -1,55 +1,11 @@
 package scrape
 
 import (
-	"net/url"
-	"sort"
 	"strings"
-)
-
-func canonicalizeURL(raw string) string {
-	raw = strings.TrimSpace(raw)
-	if raw == "" {
-		return ""
-	}
-	u, err := url.Parse(raw)
-	if err != nil {
-		return raw
-	}
-
-	u.Scheme = strings.ToLower(u.Scheme)
-	u.Host = strings.ToLower(u.Host)
-	u.Fragment = ""
-
-	// drop common tracking params
-	q := u.Query()
-	for k := range q {
-		lk := strings.ToLower(k)
-		if strings.HasPrefix(lk, "utm_") ||
-			lk == "gclid" || lk == "fbclid" || lk == "msclkid" ||
-			lk == "mc_cid" || lk == "mc_eid" ||
-			lk == "mkt_tok" {
-			q.Del(k)
-		}
-	}
-
-	// keep only useful linkedin param currentJobId if present
-	if strings.Contains(u.Host, "linkedin.com") {
-		keep := url.Values{}
-		if v := q.Get("currentJobId"); v != "" {
-			keep.Set("currentJobId", v)
-		}
-		q = keep
-	}
 
-	// deterministic query
-	for k := range q {
-		vals := q[k]
-		sort.Strings(vals)
-		q[k] = vals
-	}
-	u.RawQuery = q.Encode()
-	return u.String()
-}
+	"jobhunt-engine/internal/config"
+	"jobhunt-engine/internal/scrape/util"
+)
 
 func scoreURL(u string) int {
 	lu := strings.ToLower(u)
@@ -108,3 +64,34 @@ func isObviousJunkURL(u string) bool {
 	}
 	return false
 }
+
+// LinkTag is ClassifyURL's verdict for a discovered link. It's an
+// alias for util.LinkTag (see internal/scrape/util/URL.go for the
+// actual classification logic) so that package, which email_scrape and
+// a future webcrawl-side crawler can import without the cycle back
+// through this package that importing scrape directly would create,
+// stays the single source of truth.
+type LinkTag = util.LinkTag
+
+const (
+	TagPrimary = util.TagPrimary
+	TagRelated = util.TagRelated
+	TagIgnore  = util.TagIgnore
+)
+
+// SetURLScope wires up config.Sources.URLScope's allow/deny rules for
+// ClassifyURL. Call once at startup, same lifecycle as SetDedupeFilter/
+// SetDB in fetchers_register.go.
+func SetURLScope(rules []config.URLScopeRule) {
+	out := make([]util.URLScopeRule, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, util.URLScopeRule{Pattern: r.Pattern, Tag: r.Tag})
+	}
+	util.SetURLScope(out)
+}
+
+// ClassifyURL tags u as TagPrimary/TagRelated/TagIgnore; see
+// util.ClassifyURL for the implementation.
+func ClassifyURL(u string) LinkTag {
+	return util.ClassifyURL(u)
+}
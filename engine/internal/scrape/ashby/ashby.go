@@ -0,0 +1,168 @@
+package ashby
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"jobhunt-engine/internal/domain"
+	applog "jobhunt-engine/internal/log"
+	"jobhunt-engine/internal/scrape/util"
+)
+
+type Config struct {
+	Companies []Company
+}
+
+type Company struct {
+	Slug string // jobs.ashbyhq.com/<slug>
+	Name string
+}
+
+type Scraper struct {
+	cfg Config
+	hc  *http.Client
+}
+
+func New(cfg Config) *Scraper {
+	return &Scraper{
+		cfg: cfg,
+		hc:  &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+func (s *Scraper) Name() string { return "ashby" }
+
+type ashbyBoard struct {
+	Jobs []ashbyJob `json:"jobs"`
+}
+
+type ashbyJob struct {
+	ID              string `json:"id"`
+	Title           string `json:"title"`
+	JobURL          string `json:"jobUrl"`
+	Location        string `json:"location"`
+	PublishedAt     string `json:"publishedAt"`
+	DescriptionHTML string `json:"descriptionHtml"`
+	IsRemote        bool   `json:"isRemote"`
+}
+
+// Fetch lists every open posting for each configured company. Ashby's
+// job-board API returns fully-populated JSON (title/location/desc in
+// one call), so there's no separate hydrate step.
+func (s *Scraper) Fetch(ctx context.Context) ([]domain.JobLead, error) {
+	const workers = 8
+
+	companies := s.cfg.Companies
+	jobsCh := make(chan []domain.JobLead, len(companies))
+	workCh := make(chan Company)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for co := range workCh {
+				cctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+				jobs, err := s.fetchCompany(cctx, co)
+				cancel()
+
+				if err != nil {
+					applog.With("source", "ashby", "company", co.Name, "slug", co.Slug).Warn("fetch company failed", "error", err)
+					continue
+				}
+				if len(jobs) > 0 {
+					jobsCh <- jobs
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(workCh)
+		for _, co := range companies {
+			select {
+			case <-ctx.Done():
+				return
+			case workCh <- co:
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(jobsCh)
+
+	var out []domain.JobLead
+	for batch := range jobsCh {
+		out = append(out, batch...)
+	}
+
+	applog.With("source", "ashby").Info("scrape finished", "processed", len(out))
+	return out, nil
+}
+
+func (s *Scraper) fetchCompany(ctx context.Context, co Company) ([]domain.JobLead, error) {
+	apiURL := fmt.Sprintf("https://api.ashbyhq.com/posting-api/job-board/%s", co.Slug)
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	req.Header.Set("User-Agent", "JobHunt/1.0 (+local)")
+
+	res, err := s.hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ashby get: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("ashby status %d", res.StatusCode)
+	}
+
+	var board ashbyBoard
+	if err := json.NewDecoder(res.Body).Decode(&board); err != nil {
+		return nil, fmt.Errorf("ashby decode: %w", err)
+	}
+
+	out := make([]domain.JobLead, 0, len(board.Jobs))
+	for _, j := range board.Jobs {
+		if j.ID == "" || j.JobURL == "" || strings.TrimSpace(j.Title) == "" {
+			continue
+		}
+
+		t := time.Now()
+		if j.PublishedAt != "" {
+			if parsed, err := time.Parse(time.RFC3339, j.PublishedAt); err == nil {
+				t = parsed
+			}
+		}
+
+		loc := util.NormalizeLocation(j.Location)
+		mode := util.InferWorkModeFromText(loc, j.Title, j.DescriptionHTML)
+		if j.IsRemote {
+			mode = "Remote"
+		}
+
+		out = append(out, domain.JobLead{
+			CompanyName:     co.Name,
+			Title:           strings.TrimSpace(j.Title),
+			LocationRaw:     loc,
+			WorkMode:        mode,
+			URL:             j.JobURL,
+			PostedAt:        &t,
+			Description:     j.DescriptionHTML,
+			FirstSeenSource: "ashby",
+			ATSJobID:        fmt.Sprintf("ashby:%s:%s", co.Slug, j.ID),
+		})
+	}
+
+	return out, nil
+}
+
+// Hydrate is a no-op: Ashby's job-board API already returns a fully
+// populated posting in Fetch. It satisfies scrape.Source.
+func (s *Scraper) Hydrate(ctx context.Context, j *domain.JobLead) error {
+	return nil
+}
@@ -0,0 +1,71 @@
+package challenge
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// cacheTTL bounds how long a cached Solve result is reused when its
+// cookies don't carry an explicit expiry of their own.
+const cacheTTL = 10 * time.Minute
+
+type cacheEntry struct {
+	cookies []*http.Cookie
+	expires time.Time
+}
+
+// CachingSolver wraps another Solver, remembering its last successful
+// result per host so a scraper doesn't pay for a full challenge solve
+// (FlareSolverr launches a real headless browser) on every blocked
+// request - only once the cached cookies' nearest expiry has passed.
+type CachingSolver struct {
+	inner Solver
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+func NewCachingSolver(inner Solver) *CachingSolver {
+	return &CachingSolver{inner: inner, cache: map[string]cacheEntry{}}
+}
+
+func (c *CachingSolver) Solve(ctx context.Context, req *http.Request, resp *http.Response) ([]*http.Cookie, error) {
+	host := req.URL.Host
+
+	c.mu.Lock()
+	if e, ok := c.cache[host]; ok && time.Now().Before(e.expires) {
+		c.mu.Unlock()
+		return e.cookies, nil
+	}
+	c.mu.Unlock()
+
+	cookies, err := c.inner.Solve(ctx, req, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	expires := time.Now().Add(cacheTTL)
+	for _, ck := range cookies {
+		if !ck.Expires.IsZero() && ck.Expires.Before(expires) {
+			expires = ck.Expires
+		}
+	}
+
+	c.mu.Lock()
+	c.cache[host] = cacheEntry{cookies: cookies, expires: expires}
+	c.mu.Unlock()
+
+	return cookies, nil
+}
+
+// UserAgentFor delegates to the wrapped Solver when it implements
+// UserAgentProvider, so a caller can type-assert a CachingSolver the
+// same way it would the solver it wraps.
+func (c *CachingSolver) UserAgentFor(host string) string {
+	if p, ok := c.inner.(UserAgentProvider); ok {
+		return p.UserAgentFor(host)
+	}
+	return ""
+}
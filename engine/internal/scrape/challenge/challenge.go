@@ -0,0 +1,39 @@
+// Package challenge abstracts solving a JavaScript/Cloudflare
+// challenge an ATS scraper's plain HTTP client can't pass on its own -
+// a Solver trades the request/response that triggered the challenge
+// for the cookies a retried request needs to get through.
+package challenge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Solver resolves a challenge triggered by req/resp, returning the
+// cookies (e.g. Cloudflare's cf_clearance) a retried req should carry.
+type Solver interface {
+	Solve(ctx context.Context, req *http.Request, resp *http.Response) (cookies []*http.Cookie, err error)
+}
+
+// ErrUnsupported is returned by a Solver that can't solve a challenge
+// at all (NoopSolver always returns it).
+var ErrUnsupported = errors.New("challenge: no solver configured")
+
+// NoopSolver is the default Solver: it declines every challenge,
+// so an unconfigured scraper fails with a clear error instead of
+// silently retrying forever.
+type NoopSolver struct{}
+
+func (NoopSolver) Solve(ctx context.Context, req *http.Request, resp *http.Response) ([]*http.Cookie, error) {
+	return nil, ErrUnsupported
+}
+
+// UserAgentProvider is satisfied by a Solver that can also report the
+// browser User-Agent its last successful Solve used (FlareSolverr
+// proxies the challenge through a real browser, so the UA its
+// cookies were issued to matters for the retried request too). Not
+// every Solver needs one - callers should type-assert for it.
+type UserAgentProvider interface {
+	UserAgentFor(host string) string
+}
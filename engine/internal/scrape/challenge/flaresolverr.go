@@ -0,0 +1,136 @@
+package challenge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// FlareSolverrConfig configures a FlareSolverr Solver.
+type FlareSolverrConfig struct {
+	// Endpoint is a running FlareSolverr instance's /v1 endpoint, e.g.
+	// "http://flaresolverr:8191/v1".
+	Endpoint string
+
+	// Timeout bounds how long FlareSolverr's own headless browser gets
+	// to solve one challenge (sent as its maxTimeout, in ms). Defaults
+	// to 60s when <= 0.
+	Timeout time.Duration
+}
+
+// FlareSolverr solves Cloudflare challenges by delegating to a
+// FlareSolverr instance (https://github.com/FlareSolverr/FlareSolverr):
+// it POSTs a "request.get" command and imports the cookies (and
+// User-Agent) its headless browser session came back with.
+type FlareSolverr struct {
+	cfg FlareSolverrConfig
+	hc  *http.Client
+
+	mu         sync.Mutex
+	userAgents map[string]string // host -> last solved session's User-Agent
+}
+
+func NewFlareSolverr(cfg FlareSolverrConfig) *FlareSolverr {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 60 * time.Second
+	}
+	return &FlareSolverr{
+		cfg:        cfg,
+		hc:         &http.Client{Timeout: cfg.Timeout + 10*time.Second},
+		userAgents: map[string]string{},
+	}
+}
+
+// UserAgentFor returns the User-Agent FlareSolverr's browser used the
+// last time it solved a challenge for host, or "" if it hasn't.
+// Satisfies challenge.UserAgentProvider.
+func (f *FlareSolverr) UserAgentFor(host string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.userAgents[host]
+}
+
+func (f *FlareSolverr) rememberUserAgent(req *http.Request, ua string) {
+	if ua == "" {
+		return
+	}
+	f.mu.Lock()
+	f.userAgents[req.URL.Host] = ua
+	f.mu.Unlock()
+}
+
+type flaresolverrRequest struct {
+	Cmd        string `json:"cmd"`
+	URL        string `json:"url"`
+	MaxTimeout int    `json:"maxTimeout"`
+}
+
+type flaresolverrCookie struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Domain string `json:"domain"`
+	Path   string `json:"path"`
+}
+
+type flaresolverrResponse struct {
+	Status   string `json:"status"`
+	Message  string `json:"message"`
+	Solution struct {
+		URL       string               `json:"url"`
+		Status    int                  `json:"status"`
+		UserAgent string               `json:"userAgent"`
+		Cookies   []flaresolverrCookie `json:"cookies"`
+	} `json:"solution"`
+}
+
+// Solve ignores resp (FlareSolverr re-fetches req.URL itself through
+// its own browser rather than replaying the original response) and
+// returns the cf_clearance and any other cookies its session ends up
+// with.
+func (f *FlareSolverr) Solve(ctx context.Context, req *http.Request, resp *http.Response) ([]*http.Cookie, error) {
+	body, err := json.Marshal(flaresolverrRequest{
+		Cmd:        "request.get",
+		URL:        req.URL.String(),
+		MaxTimeout: int(f.cfg.Timeout / time.Millisecond),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, f.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	res, err := f.hc.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("flaresolverr: %w", err)
+	}
+	defer res.Body.Close()
+
+	var out flaresolverrResponse
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("flaresolverr: decode response: %w", err)
+	}
+	if out.Status != "ok" {
+		return nil, fmt.Errorf("flaresolverr: %s", out.Message)
+	}
+
+	cookies := make([]*http.Cookie, 0, len(out.Solution.Cookies))
+	for _, c := range out.Solution.Cookies {
+		cookies = append(cookies, &http.Cookie{
+			Name:   c.Name,
+			Value:  c.Value,
+			Domain: c.Domain,
+			Path:   c.Path,
+		})
+	}
+
+	f.rememberUserAgent(req, out.Solution.UserAgent)
+	return cookies, nil
+}
@@ -0,0 +1,62 @@
+package scrape
+
+import (
+	"sync"
+	"time"
+
+	"jobhunt-engine/internal/scrape/util"
+)
+
+// deadlines holds one util.DeadlineTimer per ATS source currently (or
+// most recently) fetching, keyed by Source.Name(), so
+// /scrape/{source}/deadline and /scrape/{source}/cancel can reach an
+// in-flight runOneATSSource fetch by name instead of needing a direct
+// reference to its goroutine. Same "one shared instance, package-level
+// funcs forwarding to it" shape as Dedupe (run_scrape_once.go) and
+// DB/Limiter (fetchers_register.go).
+var (
+	deadlinesMu sync.Mutex
+	deadlines   = map[string]*util.DeadlineTimer{}
+)
+
+// armDeadline creates a fresh DeadlineTimer for source, replacing
+// whatever one a previous run left behind, and arms it for d from now.
+func armDeadline(source string, d time.Duration) *util.DeadlineTimer {
+	dt := util.NewDeadlineTimer()
+	dt.SetDeadline(time.Now().Add(d))
+
+	deadlinesMu.Lock()
+	deadlines[source] = dt
+	deadlinesMu.Unlock()
+
+	return dt
+}
+
+// SetDeadline moves source's in-flight fetch deadline to t, extending
+// or shortening whatever runOneATSSource armed it with. Reports false
+// if source has no fetch currently tracked (already finished, or
+// never started).
+func SetDeadline(source string, t time.Time) bool {
+	deadlinesMu.Lock()
+	dt := deadlines[source]
+	deadlinesMu.Unlock()
+	if dt == nil {
+		return false
+	}
+	dt.SetDeadline(t)
+	return true
+}
+
+// CancelFetch fires source's in-flight fetch deadline immediately, the
+// same as it arriving early. Reports false if source has no fetch
+// currently tracked.
+func CancelFetch(source string) bool {
+	deadlinesMu.Lock()
+	dt := deadlines[source]
+	deadlinesMu.Unlock()
+	if dt == nil {
+		return false
+	}
+	dt.Cancel()
+	return true
+}
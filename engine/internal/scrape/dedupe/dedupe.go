@@ -0,0 +1,159 @@
+// Package dedupe provides a persistent Bloom filter used to skip
+// already-seen job leads before the expensive parts of a scrape
+// (HTTP hydration, DB round-trips) run. SQLite's unique index on
+// source_id remains the source of truth; this filter only exists to
+// cheaply reject the common case ("we've definitely seen this one").
+package dedupe
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/bits-and-blooms/bloom/v3"
+)
+
+const fileName = "dedupe.bloom"
+
+// Filter is a file-backed Bloom filter keyed by job source_id.
+type Filter struct {
+	mu   sync.RWMutex
+	bf   *bloom.BloomFilter
+	path string
+}
+
+// Stats describes the current fill level of the filter, useful for
+// deciding when a rebuild (resize) is overdue.
+type Stats struct {
+	M              uint    `json:"m_bits"`
+	K              uint    `json:"k_hashes"`
+	FillRatio      float64 `json:"fill_ratio"`
+	EstimatedFPR   float64 `json:"estimated_fpr"`
+	ApproxElements uint32  `json:"approx_elements"`
+}
+
+// Open loads the filter from <dataDir>/dedupe.bloom if present, or
+// creates a new one sized for expectedJobs at the given false-positive
+// rate (see bloom.NewWithEstimates).
+func Open(dataDir string, expectedJobs uint, fpr float64) (*Filter, error) {
+	path := filepath.Join(dataDir, fileName)
+
+	f := &Filter{path: path}
+
+	file, err := os.Open(path)
+	if err == nil {
+		defer file.Close()
+		bf := &bloom.BloomFilter{}
+		if _, rerr := bf.ReadFrom(bufio.NewReader(file)); rerr == nil {
+			f.bf = bf
+			return f, nil
+		}
+		// fall through to a fresh filter if the on-disk copy is corrupt
+	}
+
+	f.bf = bloom.NewWithEstimates(expectedJobs, fpr)
+	return f, nil
+}
+
+// PopulateFromDB streams every existing source_id into the filter. Call
+// this once at startup after Open, before scrapers start calling Seen.
+func PopulateFromDB(db *sql.DB, f *Filter) (int, error) {
+	rows, err := db.Query(`SELECT source_id FROM jobs WHERE source_id != ''`)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		var sourceID string
+		if err := rows.Scan(&sourceID); err != nil {
+			return n, err
+		}
+		f.Add(sourceID)
+		n++
+	}
+	return n, rows.Err()
+}
+
+// Seen reports whether sourceID has probably already been ingested.
+// A false negative never happens; a false positive just means a
+// scraper falls back to the (authoritative) SQLite insert.
+func (f *Filter) Seen(sourceID string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.bf.TestString(sourceID)
+}
+
+// Add records sourceID as ingested.
+func (f *Filter) Add(sourceID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.bf.AddString(sourceID)
+}
+
+// Flush persists the filter to disk. Safe to call periodically or on
+// shutdown; it writes to a temp file and renames so a crash mid-write
+// can't corrupt the on-disk copy.
+func (f *Filter) Flush() error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	tmp := f.path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(file)
+	if _, err := f.bf.WriteTo(w); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("write bloom filter: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, f.path)
+}
+
+// Stats reports the filter's current fill ratio and estimated
+// false-positive rate at that fill level.
+func (f *Filter) Stats() Stats {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	m := f.bf.Cap()
+	k := f.bf.K()
+	n := f.bf.ApproximatedSize()
+
+	fillRatio := estimateFillRatio(m, k, n)
+
+	return Stats{
+		M:              m,
+		K:              k,
+		FillRatio:      fillRatio,
+		EstimatedFPR:   math.Pow(fillRatio, float64(k)),
+		ApproxElements: n,
+	}
+}
+
+// estimateFillRatio derives the fraction of set bits from the
+// estimated element count, inverting the standard Bloom filter
+// fill-ratio formula: 1 - (1 - 1/m)^(k*n) ≈ 1 - e^(-k*n/m).
+func estimateFillRatio(m, k uint, n uint32) float64 {
+	if m == 0 {
+		return 0
+	}
+	return 1 - math.Exp(-1*float64(k)*float64(n)/float64(m))
+}
@@ -0,0 +1,252 @@
+package scrape
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"jobhunt-engine/internal/ingest/ats"
+	"jobhunt-engine/internal/scrape/util"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	discoverMaxDepth = 2
+	discoverMaxPages = 40
+)
+
+// discoverLimiter rate-limits the careers-page crawl DiscoverATS runs;
+// separate from domainLimiter since it hits the company's own site
+// instead of search/infobox hosts.
+var discoverLimiter = util.NewHostLimiter(2.0, 4)
+
+// atsURLPattern matches a known ATS hosting URL and captures the
+// company's slug on that board.
+type atsURLPattern struct {
+	atsType string
+	re      *regexp.Regexp
+}
+
+var atsURLPatterns = []atsURLPattern{
+	{"greenhouse", regexp.MustCompile(`boards\.greenhouse\.io/([a-zA-Z0-9_-]+)`)},
+	{"lever", regexp.MustCompile(`jobs\.lever\.co/([a-zA-Z0-9_-]+)`)},
+	{"workday", regexp.MustCompile(`([a-zA-Z0-9_-]+)\.myworkdayjobs\.com`)},
+	{"ashby", regexp.MustCompile(`jobs\.ashbyhq\.com/([a-zA-Z0-9_-]+)`)},
+	{"smartrecruiters", regexp.MustCompile(`([a-zA-Z0-9_-]+)\.smartrecruiters\.com`)},
+}
+
+// DiscoverATS crawls domain's own site looking for a link into a known
+// ATS (Greenhouse, Lever, Workday, Ashby, SmartRecruiters), so a new
+// company no longer needs its board slug hand-added to config. The
+// crawl starts at domain's homepage, /careers, /jobs and
+// careers.<domain>, then follows same-registered-domain links up to
+// discoverMaxDepth hops, stopping after discoverMaxPages fetches.
+// robots.txt is honored and every host hit is rate limited via
+// discoverLimiter.
+func DiscoverATS(ctx context.Context, domain string) ([]ats.Discovered, error) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		return nil, nil
+	}
+
+	seeds := []string{
+		"https://" + domain + "/",
+		"https://" + domain + "/careers",
+		"https://" + domain + "/jobs",
+		"https://careers." + domain + "/",
+	}
+
+	type queued struct {
+		url   string
+		depth int
+	}
+	queue := make([]queued, 0, len(seeds))
+	for _, s := range seeds {
+		queue = append(queue, queued{url: s, depth: 0})
+	}
+
+	rc := newRobotsCache()
+	visited := map[string]bool{}
+	found := map[string]ats.Discovered{} // keyed by atsType+":"+slug
+	pages := 0
+
+	for len(queue) > 0 && pages < discoverMaxPages {
+		cur := queue[0]
+		queue = queue[1:]
+
+		if visited[cur.url] {
+			continue
+		}
+		visited[cur.url] = true
+
+		if !rc.allowed(ctx, cur.url) {
+			continue
+		}
+		if err := discoverLimiter.WaitURL(ctx, cur.url); err != nil {
+			return discoveredList(found), err
+		}
+
+		doc, err := fetchDocument(ctx, cur.url)
+		pages++
+		if err != nil || doc == nil {
+			continue
+		}
+
+		doc.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+			href, _ := a.Attr("href")
+			href = strings.TrimSpace(href)
+			if href == "" {
+				return
+			}
+			abs := resolveAgainst(cur.url, href)
+			if abs == "" {
+				return
+			}
+
+			if d, ok := matchATSURL(abs, cur.depth); ok {
+				key := d.ATSType + ":" + d.Slug
+				if existing, seen := found[key]; !seen || d.Confidence > existing.Confidence {
+					found[key] = d
+				}
+				return
+			}
+
+			if cur.depth >= discoverMaxDepth || visited[abs] {
+				return
+			}
+			if sameRegisteredDomain(abs, domain) {
+				queue = append(queue, queued{url: abs, depth: cur.depth + 1})
+			}
+		})
+	}
+
+	return discoveredList(found), nil
+}
+
+func discoveredList(found map[string]ats.Discovered) []ats.Discovered {
+	if len(found) == 0 {
+		return nil
+	}
+	out := make([]ats.Discovered, 0, len(found))
+	for _, d := range found {
+		out = append(out, d)
+	}
+	return out
+}
+
+// matchATSURL checks raw against every known ATS host pattern,
+// returning the discovered slug with a confidence that favors matches
+// found closer to the seed pages.
+func matchATSURL(raw string, depth int) (ats.Discovered, bool) {
+	for _, p := range atsURLPatterns {
+		m := p.re.FindStringSubmatch(raw)
+		if m == nil {
+			continue
+		}
+		confidence := 90
+		if depth > 0 {
+			confidence = 70
+		}
+		return ats.Discovered{ATSType: p.atsType, Slug: m[1], Confidence: confidence}, true
+	}
+	return ats.Discovered{}, false
+}
+
+// resolveAgainst resolves href relative to base, returning "" for
+// anything that isn't a fetchable http(s) link (mailto:, javascript:, ...).
+func resolveAgainst(base, href string) string {
+	b, err := url.Parse(base)
+	if err != nil {
+		return ""
+	}
+	u, err := b.Parse(href)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	u.Fragment = ""
+	return u.String()
+}
+
+// sameRegisteredDomain reports whether raw's host is domain itself or
+// a subdomain of it (crude eTLD+1 comparison; good enough to keep the
+// crawl from wandering off-site).
+func sameRegisteredDomain(raw, domain string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	host := strings.ToLower(u.Host)
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// robotsCache fetches and caches each host's robots.txt, applying only
+// the crude "User-agent: * / Disallow: <prefix>" rules most sites rely
+// on; anything fancier (wildcards, Allow overrides) is treated as
+// permissive rather than blocking a legitimate crawl.
+type robotsCache struct {
+	disallow map[string][]string
+}
+
+func newRobotsCache() *robotsCache {
+	return &robotsCache{disallow: make(map[string][]string)}
+}
+
+func (rc *robotsCache) allowed(ctx context.Context, raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return true
+	}
+
+	rules, ok := rc.disallow[u.Host]
+	if !ok {
+		rules = fetchRobotsDisallow(ctx, u.Scheme, u.Host)
+		rc.disallow[u.Host] = rules
+	}
+
+	for _, prefix := range rules {
+		if prefix != "" && strings.HasPrefix(u.Path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+func fetchRobotsDisallow(ctx context.Context, scheme, host string) []string {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, scheme+"://"+host+"/robots.txt", nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+
+	resp, err := domainHTTPClient.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil
+	}
+
+	var rules []string
+	inStarGroup := false
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(strings.ToLower(line), "user-agent:"):
+			agent := strings.TrimSpace(line[len("user-agent:"):])
+			inStarGroup = agent == "*"
+		case inStarGroup && strings.HasPrefix(strings.ToLower(line), "disallow:"):
+			path := strings.TrimSpace(line[len("disallow:"):])
+			rules = append(rules, path)
+		}
+	}
+	return rules
+}
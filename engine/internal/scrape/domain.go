@@ -3,14 +3,12 @@ package scrape
 import (
 	"context"
 	"database/sql"
-	"fmt"
-	"jobhunt-engine/internal/store"
-	"net/http"
 	"net/url"
 	"strings"
-	"time"
+	"sync"
 
-	"github.com/PuerkitoBio/goquery"
+	"jobhunt-engine/internal/scrape/util"
+	"jobhunt-engine/internal/store"
 )
 
 var domainBlocklist = []string{
@@ -38,8 +36,23 @@ var domainBlocklist = []string{
 	"applytojob.com",
 }
 
-func GetOrFindCompanyDomain(ctx context.Context, db *sql.DB, company string) (string, error) {
-	// 1) cached?
+// domainResolvers is the provider panel GetOrFindCompanyDomain consults
+// when a company has no cached domain, in priority order. Order also
+// breaks ties when two candidate hosts end up with equal vote weight.
+var domainResolvers = []DomainResolver{
+	clearbitResolver{},
+	wikipediaResolver{},
+	ddgResolver{},
+	bingResolver{},
+	suffixResolver{},
+}
+
+// GetOrFindCompanyDomain returns company's cached domain, or resolves
+// one by running every domainResolvers provider concurrently (rate
+// limited per host via hl), recording each provider's vote in
+// company_domain_candidates and the weighted-majority winner in
+// company_domains.
+func GetOrFindCompanyDomain(ctx context.Context, db *sql.DB, hl *util.HostLimiter, company string) (string, error) {
 	d, err := store.GetCompanyDomain(ctx, db, company)
 	if err != nil {
 		return "", err
@@ -48,82 +61,119 @@ func GetOrFindCompanyDomain(ctx context.Context, db *sql.DB, company string) (st
 		return d, nil
 	}
 
-	// 2) search
-	found, err := FindCompanyDomainDDG(ctx, company)
-	if err != nil {
+	winner, provider, candidates := resolveCompanyDomain(ctx, hl, company, domainResolvers)
+
+	if err := store.InsertCompanyDomainCandidates(ctx, db, company, candidates); err != nil {
 		return "", err
 	}
-	if found == "" {
-		return "", nil
-	}
 
-	if isBlockedDomain(found) {
+	if winner == "" {
 		return "", nil
 	}
 
-	// 3) store
-	if err := store.UpsertCompanyDomain(ctx, db, company, found); err != nil {
+	if err := store.UpsertCompanyDomain(ctx, db, company, winner, provider); err != nil {
 		return "", err
 	}
-	return found, nil
+	return winner, nil
 }
 
-func FindCompanyDomainDDG(ctx context.Context, company string) (string, error) {
-	company = strings.TrimSpace(company)
-	if company == "" {
-		return "", nil
-	}
-
-	// Make query less noisy
-	q := sanitizeCompanyForSearch(company)
-	query := fmt.Sprintf("%s official website", q)
-
-	u := "https://duckduckgo.com/html/?q=" + url.QueryEscape(query)
+// RefreshCompanyDomain re-runs domainResolvers for company regardless
+// of what's already cached, overwriting company_domains with whatever
+// wins this time. Unlike GetOrFindCompanyDomain, which treats any
+// cached row as final, this is what a scheduled staleness sweep (see
+// store.StaleCompanyDomains) uses to notice a company that's since
+// moved to a new domain.
+func RefreshCompanyDomain(ctx context.Context, db *sql.DB, company string) (string, error) {
+	winner, provider, candidates := resolveCompanyDomain(ctx, domainLimiter, company, domainResolvers)
 
-	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
-	req.Header.Set("User-Agent", "Mozilla/5.0")
-
-	client := &http.Client{Timeout: 12 * time.Second}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", nil
+	if err := store.InsertCompanyDomainCandidates(ctx, db, company, candidates); err != nil {
+		return "", err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+	if winner == "" {
 		return "", nil
 	}
-
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return "", nil
+	if err := store.UpsertCompanyDomain(ctx, db, company, winner, provider); err != nil {
+		return "", err
 	}
+	return winner, nil
+}
 
-	var best string
+// domainVote is one provider's opinion, kept at its resolver's index in
+// domainResolvers so ties can be broken by earliest provider order.
+type domainVote struct {
+	provider string
+	host     string
+	weight   int
+}
 
-	// DDG HTML results: <a class="result__a" href="...">
-	doc.Find("a.result__a").EachWithBreak(func(_ int, a *goquery.Selection) bool {
-		href, ok := a.Attr("href")
-		if !ok || strings.TrimSpace(href) == "" {
-			return true
+// resolveCompanyDomain runs resolvers concurrently, tallies weighted
+// votes by normalized host, and returns the majority winner (with the
+// provider that first proposed it) plus every candidate considered,
+// for company_domain_candidates.
+func resolveCompanyDomain(ctx context.Context, hl *util.HostLimiter, company string, resolvers []DomainResolver) (winner, winnerProvider string, candidates []store.CompanyDomainCandidate) {
+	company = strings.TrimSpace(company)
+	if company == "" {
+		return "", "", nil
+	}
+
+	votes := make([]domainVote, len(resolvers))
+	var wg sync.WaitGroup
+	for i, r := range resolvers {
+		i, r := i, r
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			host, err := r.Resolve(ctx, hl, company)
+			if err != nil || host == "" {
+				return
+			}
+			host = strings.ToLower(strings.TrimPrefix(host, "www."))
+			if isBlockedDomain(host) {
+				return
+			}
+			votes[i] = domainVote{provider: r.Name(), host: host, weight: r.Weight()}
+		}()
+	}
+	wg.Wait()
+
+	type tally struct {
+		weight   int
+		firstIdx int
+	}
+	tallies := make(map[string]*tally)
+
+	for i, v := range votes {
+		if v.host == "" {
+			continue
 		}
+		candidates = append(candidates, store.CompanyDomainCandidate{Provider: v.provider, Domain: v.host, Weight: v.weight})
 
-		target := decodeDDGRedirect(href)
-		host := hostFromURL(target)
-		if host == "" {
-			return true
+		t, ok := tallies[v.host]
+		if !ok {
+			t = &tally{firstIdx: i}
+			tallies[v.host] = t
 		}
+		t.weight += v.weight
+	}
 
-		host = strings.ToLower(strings.TrimPrefix(host, "www."))
-		if isBlockedDomain(host) {
-			return true
+	var bestHost string
+	var best *tally
+	for host, t := range tallies {
+		if best == nil || t.weight > best.weight || (t.weight == best.weight && t.firstIdx < best.firstIdx) {
+			best, bestHost = t, host
 		}
+	}
+	if bestHost == "" {
+		return "", "", candidates
+	}
 
-		best = host
-		return false // stop at first good domain
-	})
-
-	return best, nil
+	for _, v := range votes {
+		if v.host == bestHost {
+			winnerProvider = v.provider
+			break
+		}
+	}
+	return bestHost, winnerProvider, candidates
 }
 
 func decodeDDGRedirect(href string) string {
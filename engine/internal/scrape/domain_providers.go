@@ -0,0 +1,273 @@
+package scrape
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"jobhunt-engine/internal/scrape/util"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// DomainResolver is one vote source GetOrFindCompanyDomain consults
+// when a company has no cached domain. Resolve returns ("", nil) for
+// "no opinion" (rate-limited, no result, page didn't match) so one
+// flaky provider never blocks the others or poisons the vote.
+type DomainResolver interface {
+	Name() string
+	Weight() int
+	Resolve(ctx context.Context, hl *util.HostLimiter, company string) (string, error)
+}
+
+var domainHTTPClient = &http.Client{Timeout: 12 * time.Second}
+
+// ---- DuckDuckGo HTML (the original, single-shot implementation) ----
+
+type ddgResolver struct{}
+
+func (ddgResolver) Name() string { return "ddg" }
+func (ddgResolver) Weight() int  { return 3 }
+
+func (ddgResolver) Resolve(ctx context.Context, hl *util.HostLimiter, company string) (string, error) {
+	u := "https://duckduckgo.com/html/?q=" + url.QueryEscape(sanitizeCompanyForSearch(company)+" official website")
+	if err := hl.WaitURL(ctx, u); err != nil {
+		return "", err
+	}
+
+	doc, err := fetchDocument(ctx, u)
+	if err != nil || doc == nil {
+		return "", nil
+	}
+
+	var best string
+	doc.Find("a.result__a").EachWithBreak(func(_ int, a *goquery.Selection) bool {
+		href, ok := a.Attr("href")
+		if !ok || strings.TrimSpace(href) == "" {
+			return true
+		}
+		if host := hostFromURL(decodeDDGRedirect(href)); host != "" {
+			best = host
+			return false
+		}
+		return true
+	})
+	return best, nil
+}
+
+// ---- Bing HTML ----
+
+type bingResolver struct{}
+
+func (bingResolver) Name() string { return "bing" }
+func (bingResolver) Weight() int  { return 3 }
+
+func (bingResolver) Resolve(ctx context.Context, hl *util.HostLimiter, company string) (string, error) {
+	u := "https://www.bing.com/search?q=" + url.QueryEscape(sanitizeCompanyForSearch(company)+" official website")
+	if err := hl.WaitURL(ctx, u); err != nil {
+		return "", err
+	}
+
+	doc, err := fetchDocument(ctx, u)
+	if err != nil || doc == nil {
+		return "", nil
+	}
+
+	var best string
+	doc.Find("li.b_algo h2 a").EachWithBreak(func(_ int, a *goquery.Selection) bool {
+		href, ok := a.Attr("href")
+		if !ok || strings.TrimSpace(href) == "" {
+			return true
+		}
+		if host := hostFromURL(href); host != "" {
+			best = host
+			return false
+		}
+		return true
+	})
+	return best, nil
+}
+
+// ---- Wikipedia OpenSearch + infobox "Website" row ----
+
+type wikipediaResolver struct{}
+
+func (wikipediaResolver) Name() string { return "wikipedia" }
+func (wikipediaResolver) Weight() int  { return 4 }
+
+func (wikipediaResolver) Resolve(ctx context.Context, hl *util.HostLimiter, company string) (string, error) {
+	searchURL := "https://en.wikipedia.org/w/api.php?action=opensearch&format=json&limit=1&search=" + url.QueryEscape(company)
+	if err := hl.WaitURL(ctx, searchURL); err != nil {
+		return "", err
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, searchURL, nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+	resp, err := domainHTTPClient.Do(req)
+	if err != nil {
+		return "", nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", nil
+	}
+
+	// OpenSearch shape: [query, [titles], [descriptions], [pageURLs]]
+	var parsed []json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil || len(parsed) < 4 {
+		return "", nil
+	}
+	var pageURLs []string
+	if err := json.Unmarshal(parsed[3], &pageURLs); err != nil || len(pageURLs) == 0 {
+		return "", nil
+	}
+
+	pageURL := pageURLs[0]
+	if err := hl.WaitURL(ctx, pageURL); err != nil {
+		return "", err
+	}
+	doc, err := fetchDocument(ctx, pageURL)
+	if err != nil || doc == nil {
+		return "", nil
+	}
+
+	var best string
+	doc.Find(".infobox tr").EachWithBreak(func(_ int, row *goquery.Selection) bool {
+		label := strings.ToLower(strings.TrimSpace(row.Find("th").First().Text()))
+		if label != "website" {
+			return true
+		}
+		href, ok := row.Find("td a").First().Attr("href")
+		if !ok {
+			return true
+		}
+		best = hostFromURL(href)
+		return false
+	})
+	return best, nil
+}
+
+// ---- Clearbit Autocomplete ----
+
+type clearbitResolver struct{}
+
+func (clearbitResolver) Name() string { return "clearbit" }
+func (clearbitResolver) Weight() int  { return 5 }
+
+func (clearbitResolver) Resolve(ctx context.Context, hl *util.HostLimiter, company string) (string, error) {
+	u := "https://autocomplete.clearbit.com/v1/companies/suggest?query=" + url.QueryEscape(company)
+	if err := hl.WaitURL(ctx, u); err != nil {
+		return "", err
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	resp, err := domainHTTPClient.Do(req)
+	if err != nil {
+		return "", nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", nil
+	}
+
+	var suggestions []struct {
+		Name   string `json:"name"`
+		Domain string `json:"domain"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&suggestions); err != nil || len(suggestions) == 0 {
+		return "", nil
+	}
+	return strings.ToLower(strings.TrimSpace(suggestions[0].Domain)), nil
+}
+
+// ---- Common-suffix heuristic: <slug>.com/.io/.ai, HEAD probe + title match ----
+
+type suffixResolver struct{}
+
+func (suffixResolver) Name() string { return "suffix" }
+func (suffixResolver) Weight() int  { return 1 }
+
+var commonDomainSuffixes = []string{".com", ".io", ".ai"}
+
+func (suffixResolver) Resolve(ctx context.Context, hl *util.HostLimiter, company string) (string, error) {
+	slug := companySlug(company)
+	if slug == "" {
+		return "", nil
+	}
+
+	for _, suffix := range commonDomainSuffixes {
+		host := slug + suffix
+		candidate := "https://" + host
+
+		if err := hl.WaitURL(ctx, candidate); err != nil {
+			return "", err
+		}
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodHead, candidate, nil)
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		resp, err := domainHTTPClient.Do(req)
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+			continue
+		}
+
+		if titleMentionsCompany(ctx, candidate, company) {
+			return host, nil
+		}
+	}
+	return "", nil
+}
+
+// companySlug lowercases company and strips anything but letters/digits,
+// e.g. "Acme, Inc." -> "acme".
+func companySlug(company string) string {
+	s := strings.ToLower(sanitizeCompanyForSearch(company))
+	var b strings.Builder
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// titleMentionsCompany does a cheap sanity check that candidate is
+// actually about company, not a squatted/parked/unrelated domain that
+// happens to share its slug.
+func titleMentionsCompany(ctx context.Context, candidate, company string) bool {
+	doc, err := fetchDocument(ctx, candidate)
+	if err != nil || doc == nil {
+		return false
+	}
+
+	words := strings.Fields(strings.ToLower(sanitizeCompanyForSearch(company)))
+	if len(words) == 0 {
+		return false
+	}
+	title := strings.ToLower(doc.Find("title").First().Text())
+	return strings.Contains(title, words[0])
+}
+
+func fetchDocument(ctx context.Context, u string) (*goquery.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+
+	resp, err := domainHTTPClient.Do(req)
+	if err != nil {
+		return nil, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, nil
+	}
+	return goquery.NewDocumentFromReader(resp.Body)
+}
@@ -0,0 +1,196 @@
+// Package emailcache is a persistent per-message cache for the IMAP
+// email backend, so FetchWithCache doesn't have to re-download the
+// full RFC822 body of a message it's already seen just because
+// MarkSeen silently failed (or the user read the mailbox from
+// somewhere else that doesn't set \Seen). Modeled on aerc's
+// initCacheDb: one LevelDB database, keyed by
+// "<account>/<uidvalidity>/<uid>", so a UIDVALIDITY rollover can never
+// make a stale entry look like it still describes the same message.
+package emailcache
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// uidValidityPrefix namespaces the per-account "last known UIDVALIDITY"
+// marker away from the "<account>/<uidvalidity>/<uid>" entry keys -
+// "\x00" can't appear in an account/mailbox name, so it never collides.
+const uidValidityPrefix = "\x00uidvalidity\x00"
+
+// Entry is what Store caches per message. ContentHash lets CheckUIDValidity's
+// caller (or a future caller with access to the parsed lead set) detect a
+// UID the server reused for different content without a UIDVALIDITY bump -
+// rare, but cheaper to guard against here than to debug later.
+type Entry struct {
+	Subject     string    `json:"subject"`
+	From        string    `json:"from"`
+	Date        time.Time `json:"date"`
+	ContentHash string    `json:"content_hash"`
+	CachedAt    time.Time `json:"cached_at"`
+}
+
+// Store is the LevelDB-backed cache. A single process should only
+// ever have one Store open on a given dir - LevelDB itself enforces
+// this with a lock file.
+type Store struct {
+	db *leveldb.DB
+
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// Open opens (creating if absent) the LevelDB database at dir.
+func Open(dir string) (*Store, error) {
+	db, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		return nil, fmt.Errorf("email cache: open %s: %w", dir, err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func entryKey(account string, uidValidity, uid uint32) []byte {
+	return []byte(fmt.Sprintf("%s/%d/%d", account, uidValidity, uid))
+}
+
+// Get returns uid's cached Entry, if any, bumping the hit/miss counter
+// Stats reports.
+func (s *Store) Get(account string, uidValidity, uid uint32) (Entry, bool) {
+	b, err := s.db.Get(entryKey(account, uidValidity, uid), nil)
+	if err != nil {
+		s.misses.Add(1)
+		return Entry{}, false
+	}
+	var e Entry
+	if err := json.Unmarshal(b, &e); err != nil {
+		s.misses.Add(1)
+		return Entry{}, false
+	}
+	s.hits.Add(1)
+	return e, true
+}
+
+// Put stores e under account/uidValidity/uid, stamping CachedAt so
+// Clean can later evict it.
+func (s *Store) Put(account string, uidValidity, uid uint32, e Entry) error {
+	e.CachedAt = time.Now()
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(entryKey(account, uidValidity, uid), b, nil)
+}
+
+// Reset wipes every entry cached for account, regardless of
+// uidvalidity. Called by CheckUIDValidity when the server reports a
+// different UIDVALIDITY than last time - IMAP's signal that every UID
+// in the mailbox now refers to something else.
+func (s *Store) Reset(account string) error {
+	iter := s.db.NewIterator(util.BytesPrefix([]byte(account+"/")), nil)
+	defer iter.Release()
+
+	batch := new(leveldb.Batch)
+	for iter.Next() {
+		batch.Delete(append([]byte(nil), iter.Key()...))
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return s.db.Write(batch, nil)
+}
+
+// CheckUIDValidity compares current against the UIDVALIDITY last seen
+// for account (from a prior CheckUIDValidity call) and, if it changed,
+// wipes account's whole bucket via Reset before recording current as
+// the new baseline. Callers should call this right after SELECT,
+// before consulting Get for any UID in the mailbox.
+func (s *Store) CheckUIDValidity(account string, current uint32) error {
+	key := []byte(uidValidityPrefix + account)
+	b, err := s.db.Get(key, nil)
+	if err == nil {
+		if prev, perr := strconv.ParseUint(string(b), 10, 32); perr == nil && uint32(prev) == current {
+			return nil
+		}
+	}
+	if err := s.Reset(account); err != nil {
+		return err
+	}
+	return s.db.Put(key, []byte(strconv.FormatUint(uint64(current), 10)), nil)
+}
+
+// Stats reports cumulative hit/miss counts since Open.
+type Stats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+func (s *Store) Stats() Stats {
+	return Stats{Hits: s.hits.Load(), Misses: s.misses.Load()}
+}
+
+// DefaultCleanInterval is used when StartCleaner is given an interval
+// <= 0.
+const DefaultCleanInterval = time.Hour
+
+// Clean evicts every entry (across all accounts) cached more than
+// maxAge ago and returns how many were removed. UIDVALIDITY markers
+// aren't touched - they're small, and CheckUIDValidity keeps them
+// fresh on its own.
+func (s *Store) Clean(maxAge time.Duration) (int, error) {
+	cutoff := time.Now().Add(-maxAge)
+	iter := s.db.NewIterator(nil, nil)
+	defer iter.Release()
+
+	batch := new(leveldb.Batch)
+	n := 0
+	for iter.Next() {
+		var e Entry
+		if err := json.Unmarshal(iter.Value(), &e); err != nil {
+			continue // not an Entry row (e.g. a uidvalidity marker)
+		}
+		if e.CachedAt.Before(cutoff) {
+			batch.Delete(append([]byte(nil), iter.Key()...))
+			n++
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return 0, err
+	}
+	if n > 0 {
+		if err := s.db.Write(batch, nil); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// StartCleaner launches a background goroutine that runs
+// Clean(maxAge) every interval (DefaultCleanInterval if interval <=
+// 0), the same ticker-loop shape as store.StartKVCachePurger.
+func (s *Store) StartCleaner(interval, maxAge time.Duration) {
+	if interval <= 0 {
+		interval = DefaultCleanInterval
+	}
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for range t.C {
+			if n, err := s.Clean(maxAge); err != nil {
+				log.Printf("[email-cache] clean error: %v", err)
+			} else if n > 0 {
+				log.Printf("[email-cache] evicted %d stale entries", n)
+			}
+		}
+	}()
+}
@@ -0,0 +1,72 @@
+package email_scrape
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"jobhunt-engine/internal/config"
+	emailcache "jobhunt-engine/internal/scrape/email/cache"
+)
+
+// defaultCacheMaxAgeHours is used when cfg.Email.CacheMaxAgeHours <= 0.
+const defaultCacheMaxAgeHours = 24 * 30
+
+var (
+	sharedCacheOnce sync.Once
+	sharedCache     *emailcache.Store
+	sharedCacheErr  error
+)
+
+// messageCache lazily opens (and remembers, process-wide - LevelDB
+// only allows one open handle per directory) the email cache
+// configured by cfg.Email.CacheDir/CacheMaxAgeHours, starting its
+// background eviction sweep the first time it's opened. Every
+// newIMAPSource shares this one Store rather than each opening its
+// own, the same "open once at startup, reuse everywhere" shape as
+// scrape.DB.
+func messageCache(cfg config.Config) (*emailcache.Store, error) {
+	sharedCacheOnce.Do(func() {
+		dir := cfg.Email.CacheDir
+		if dir == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				sharedCacheErr = err
+				return
+			}
+			dir = filepath.Join(home, ".cache", "jobhunt", "email-cache")
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			sharedCacheErr = err
+			return
+		}
+
+		store, err := emailcache.Open(dir)
+		if err != nil {
+			sharedCacheErr = err
+			return
+		}
+
+		maxAgeHours := cfg.Email.CacheMaxAgeHours
+		if maxAgeHours <= 0 {
+			maxAgeHours = defaultCacheMaxAgeHours
+		}
+		store.StartCleaner(emailcache.DefaultCleanInterval, time.Duration(maxAgeHours)*time.Hour)
+
+		sharedCache = store
+	})
+	return sharedCache, sharedCacheErr
+}
+
+// CacheStats reports the shared email cache's cumulative hit/miss
+// counters, or (Stats{}, false) if it hasn't been opened yet (imap
+// backend never used, or email scraping disabled). Used by
+// httpapi.ScrapeHandler.Status to populate types.ScrapeStatus the
+// same way it surfaces ATS checkpoints.
+func CacheStats() (emailcache.Stats, bool) {
+	if sharedCache == nil {
+		return emailcache.Stats{}, false
+	}
+	return sharedCache.Stats(), true
+}
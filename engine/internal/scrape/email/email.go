@@ -38,20 +38,28 @@ func GmailTLSConfig() *tls.Config {
 
 // DialAndLoginIMAP connects over TLS and logs in.
 func DialAndLoginIMAP(ctx context.Context, addr, username, password string, tlsCfg *tls.Config) (*imapclient.Client, error) {
+	return DialAndLoginIMAPWithOptions(ctx, addr, username, password, &imapclient.Options{TLSConfig: tlsCfg})
+}
+
+// DialAndLoginIMAPWithOptions is DialAndLoginIMAP with the full
+// imapclient.Options exposed, for callers (IdleWatcher) that need to
+// set UnilateralDataHandler to learn about EXISTS/EXPUNGE updates - a
+// plain *tls.Config isn't enough to express that.
+func DialAndLoginIMAPWithOptions(ctx context.Context, addr, username, password string, opts *imapclient.Options) (*imapclient.Client, error) {
 	if addr == "" {
 		return nil, errors.New("imap addr is required")
 	}
 	if username == "" || password == "" {
 		return nil, errors.New("imap username/password is required")
 	}
-	if tlsCfg == nil {
-		tlsCfg = &tls.Config{MinVersion: tls.VersionTLS12}
+	if opts == nil {
+		opts = &imapclient.Options{}
+	}
+	if opts.TLSConfig == nil {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
 	}
 
-	// DialTLS expects *imapclient.Options, not *tls.Config.
-	c, err := imapclient.DialTLS(addr, &imapclient.Options{
-		TLSConfig: tlsCfg,
-	})
+	c, err := imapclient.DialTLS(addr, opts)
 	if err != nil {
 		return nil, fmt.Errorf("imap dial tls: %w", err)
 	}
@@ -83,32 +91,134 @@ func SelectInbox(c *imapclient.Client) error {
 	return nil
 }
 
-// FetchUnseen pulls up to max unseen messages (by UID), including Envelope + full raw RFC822 bytes.
-// Uses BODY.PEEK[] so it will NOT set \Seen.
-func FetchUnseen(ctx context.Context, c *imapclient.Client, max int) ([]EmailMessage, error) {
+// SearchCriteria is a structured IMAP search query, modeled on aerc's
+// unified search API. Compile turns it into a server-side SEARCH
+// (UNSEEN, FROM, SUBJECT, SINCE, BODY, KEYWORD/UNKEYWORD, ...) so
+// filtering happens on the IMAP server instead of after every message
+// has already been fetched.
+//
+// Fields within a SearchCriteria are ANDed together, matching
+// imap.SearchCriteria semantics: From/To/SubjectAll/BodyContains each
+// require all of their entries to match, while SubjectAny matches if
+// any one entry matches (aerc's "search this OR that" behavior).
+type SearchCriteria struct {
+	// From/To restrict to messages where the header contains every
+	// one of the given substrings (an AND across entries).
+	From []string
+	To   []string
+
+	// SubjectAny matches if the subject contains any of these
+	// substrings; SubjectAll requires all of them to be present.
+	SubjectAny []string
+	SubjectAll []string
+
+	// BodyContains requires the message body to contain all of these
+	// substrings (server-side BODY search).
+	BodyContains []string
+
+	// Since/Before bound the search by internal date. Only the date
+	// portion is significant; time and timezone are ignored by IMAP.
+	Since, Before time.Time
+
+	// WithFlags/WithoutFlags require/exclude the given flags, e.g.
+	// []imap.Flag{imap.FlagSeen} in WithoutFlags reproduces the old
+	// "unseen only" behavior (encoded as UNSEEN, not UNKEYWORD).
+	WithFlags, WithoutFlags []imap.Flag
+
+	// Mailboxes lists the folders/labels this criteria applies to.
+	// Fetch itself operates on whatever mailbox is currently selected;
+	// callers that want to sweep several mailboxes iterate this slice
+	// and re-select between calls (see RunEmailScrapeOnce).
+	Mailboxes []string
+
+	// MaxPerMailbox caps how many messages Fetch returns for a single
+	// mailbox. Zero means "use the package default" (50).
+	MaxPerMailbox int
+
+	// MinUID, if non-zero, restricts the SEARCH to UIDs greater than
+	// it - imapSource.List sets this from a mail_cursors high-water
+	// mark so a resumed run doesn't re-SEARCH messages it already
+	// listed. Backends without IMAP UIDs ignore it.
+	MinUID uint32
+}
+
+// compile turns crit into the imap.SearchCriteria Fetch sends over the
+// wire. It does not look at crit.Mailboxes or crit.MaxPerMailbox —
+// those are consumed by the caller, not the SEARCH command itself.
+func (crit SearchCriteria) compile() *imap.SearchCriteria {
+	out := &imap.SearchCriteria{
+		Since:   crit.Since,
+		Before:  crit.Before,
+		Flag:    crit.WithFlags,
+		NotFlag: crit.WithoutFlags,
+	}
+
+	for _, f := range crit.From {
+		out.Header = append(out.Header, imap.SearchCriteriaHeaderField{Key: "From", Value: f})
+	}
+	for _, t := range crit.To {
+		out.Header = append(out.Header, imap.SearchCriteriaHeaderField{Key: "To", Value: t})
+	}
+	for _, s := range crit.SubjectAll {
+		out.Header = append(out.Header, imap.SearchCriteriaHeaderField{Key: "Subject", Value: s})
+	}
+	out.Body = append(out.Body, crit.BodyContains...)
+
+	if any := orSubjectAny(crit.SubjectAny); any != nil {
+		out.And(any)
+	}
+
+	if crit.MinUID > 0 {
+		var uids imap.UIDSet
+		uids.AddRange(imap.UID(crit.MinUID)+1, 0)
+		out.UID = append(out.UID, uids)
+	}
+
+	return out
+}
+
+// orSubjectAny builds a SearchCriteria matching messages whose subject
+// contains any one of subjects, by folding pairwise OR nodes the way
+// imap.SearchCriteria.Or expects (it only combines two criteria at a
+// time). Returns nil if there's nothing to OR.
+func orSubjectAny(subjects []string) *imap.SearchCriteria {
+	if len(subjects) == 0 {
+		return nil
+	}
+	last := &imap.SearchCriteria{
+		Header: []imap.SearchCriteriaHeaderField{{Key: "Subject", Value: subjects[len(subjects)-1]}},
+	}
+	for i := len(subjects) - 2; i >= 0; i-- {
+		this := imap.SearchCriteria{
+			Header: []imap.SearchCriteriaHeaderField{{Key: "Subject", Value: subjects[i]}},
+		}
+		last = &imap.SearchCriteria{Or: [][2]imap.SearchCriteria{{this, *last}}}
+	}
+	return last
+}
+
+// searchUIDs runs crit's SEARCH against whatever mailbox is currently
+// selected on c and returns the matching UIDs, newest first, capped
+// at crit.MaxPerMailbox (default 50). Split out of Fetch so
+// imapSource.List can consult its cache between the search and the
+// (expensive) body download.
+func searchUIDs(ctx context.Context, c *imapclient.Client, crit SearchCriteria) ([]imap.UID, error) {
 	if c == nil {
 		return nil, errors.New("imap client is nil")
 	}
+	max := crit.MaxPerMailbox
 	if max <= 0 {
 		max = 50
 	}
 
-	// 3-month cutoff (emails older than this won't even be considered)
-	cutoff := time.Now().AddDate(0, -3, 0)
-
-	criteria := &imap.SearchCriteria{
-		NotFlag: []imap.Flag{imap.FlagSeen},
-		Since:   cutoff, // <-- IMPORTANT
-	}
-
-	searchData, err := c.UIDSearch(criteria, nil).Wait()
+	searchData, err := c.UIDSearch(crit.compile(), nil).Wait()
 	if err != nil {
-		return nil, fmt.Errorf("imap uid search unseen: %w", err)
+		return nil, fmt.Errorf("imap uid search: %w", err)
 	}
 
 	uids := searchData.AllUIDs()
 	if len(uids) == 0 {
-		return []EmailMessage{}, nil
+		return nil, nil
 	}
 
 	// Process newest first
@@ -120,6 +230,19 @@ func FetchUnseen(ctx context.Context, c *imapclient.Client, max int) ([]EmailMes
 	if len(uids) > max {
 		uids = uids[:max]
 	}
+	return uids, nil
+}
+
+// fetchUIDs downloads Envelope + full raw RFC822 bytes for exactly
+// the given uids, in whatever order the server returns them. Uses
+// BODY.PEEK[] so it will NOT set \Seen.
+func fetchUIDs(ctx context.Context, c *imapclient.Client, uids []imap.UID) ([]EmailMessage, error) {
+	if c == nil {
+		return nil, errors.New("imap client is nil")
+	}
+	if len(uids) == 0 {
+		return []EmailMessage{}, nil
+	}
 
 	uidSet := imap.UIDSetNum(uids...)
 
@@ -197,6 +320,19 @@ func FetchUnseen(ctx context.Context, c *imapclient.Client, max int) ([]EmailMes
 	return out, nil
 }
 
+// Fetch runs crit against whatever mailbox is currently selected on
+// c, pulling up to crit.MaxPerMailbox messages (by UID, newest
+// first), including Envelope + full raw RFC822 bytes. Uses
+// BODY.PEEK[] so it will NOT set \Seen. Equivalent to searchUIDs
+// followed by fetchUIDs with no cache in between.
+func Fetch(ctx context.Context, c *imapclient.Client, crit SearchCriteria) ([]EmailMessage, error) {
+	uids, err := searchUIDs(ctx, c, crit)
+	if err != nil {
+		return nil, err
+	}
+	return fetchUIDs(ctx, c, uids)
+}
+
 // MarkSeen sets the \Seen flag for a UID set.
 // NOTE: In go-imap v2, Store takes (numSet, storeFlags, options) and returns a *FetchCommand.
 // There is no Wait(); you Close() the command to get the final status.
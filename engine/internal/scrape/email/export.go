@@ -0,0 +1,40 @@
+package email_scrape
+
+// This file re-exports a handful of RunEmailScrapeOnce's internals for
+// internal/jmap_scrape, which talks JMAP directly instead of going
+// through a MailSource and needs the same RFC822 parsing, work-mode
+// inference and source ID scheme so both backends dedupe against the
+// same jobs rows.
+
+// ParseRFC822 parses raw into its message ID, plain-text body, HTML
+// body and subject (falling back to fallbackSubject if the header is
+// missing or empty).
+func ParseRFC822(raw []byte, fallbackSubject string) (messageID, bodyText, htmlBody, subject string) {
+	return parseRFC822(raw, fallbackSubject)
+}
+
+// DecodeRFC2047 decodes a MIME encoded-word header value (e.g.
+// "=?UTF-8?B?...?=") into plain text.
+func DecodeRFC2047(s string) string {
+	return decodeRFC2047(s)
+}
+
+// MakeSourceID builds the same dedupe key RunEmailScrapeOnce uses, so
+// other backends land in the jobs table under a source_id an IMAP run
+// would have produced for the same message/link.
+func MakeSourceID(messageID, urlStr, subject, from string) string {
+	return makeSourceID(messageID, urlStr, subject, from)
+}
+
+// InferWorkMode guesses remote/hybrid/onsite/unknown from a subject
+// line the same way RunEmailScrapeOnce does.
+func InferWorkMode(location, subject string) string {
+	return inferWorkMode(location, subject)
+}
+
+// ParseFromHeader reads just the From header out of a raw RFC822
+// message, for callers (the pipeline dry-run endpoint) that only have
+// the raw blob, not a MailSource Message's already-split From.
+func ParseFromHeader(raw []byte) string {
+	return parseFromHeader(raw)
+}
@@ -0,0 +1,229 @@
+package email_scrape
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+
+	"jobhunt-engine/internal/config"
+	"jobhunt-engine/internal/scrape/types"
+	"jobhunt-engine/internal/scrape/util"
+
+	"github.com/emersion/go-imap/v2"
+	"github.com/emersion/go-imap/v2/imapclient"
+)
+
+// idleNoopInterval is how often pollFallback NOOPs a server that
+// doesn't advertise the IDLE capability. imapclient.Client.Idle already
+// reissues IDLE itself every ~28 minutes to stay under Gmail's
+// 29-minute limit (see imapclient/idle.go), so that etiquette doesn't
+// need reimplementing here - only the no-IDLE fallback does.
+const idleNoopInterval = 25 * time.Minute
+
+// IdleWatcher sits blocked on IMAP IDLE against one mailbox and fires
+// Signal() every time the server reports the mailbox changed
+// (EXISTS/EXPUNGE), so a caller can run RunEmailScrapeOnce right away
+// instead of waiting for the next scheduler.Every tick. Falls back to
+// a periodic NOOP if the server doesn't support IDLE, and reconnects
+// with exponential backoff - throttled through limiter, the same
+// HostLimiter the ATS fetchers use, so a flaky IMAP host doesn't get
+// hammered by a reconnect storm - if the connection drops.
+type IdleWatcher struct {
+	cfg     config.Config
+	mailbox string
+	limiter *util.HostLimiter
+
+	signal chan struct{}
+}
+
+// NewIdleWatcher builds a watcher for cfg's imap backend, watching
+// mailbox (cfg.Email.Mailbox, then "INBOX", if empty). limiter is
+// typically a low, bursty rate (reconnect attempts, not page fetches).
+func NewIdleWatcher(cfg config.Config, mailbox string, limiter *util.HostLimiter) *IdleWatcher {
+	if mailbox == "" {
+		mailbox = cfg.Email.Mailbox
+	}
+	if mailbox == "" {
+		mailbox = "INBOX"
+	}
+	if limiter == nil {
+		limiter = util.NewHostLimiter(0.2, 1)
+	}
+	return &IdleWatcher{cfg: cfg, mailbox: mailbox, limiter: limiter, signal: make(chan struct{}, 1)}
+}
+
+// Signal fires whenever the watched mailbox changes. A burst of
+// EXISTS/EXPUNGE updates collapses to a single pending signal - the
+// consumer only needs to know "something changed, go look", not how
+// many times.
+func (w *IdleWatcher) Signal() <-chan struct{} {
+	return w.signal
+}
+
+func (w *IdleWatcher) notify() {
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+// Run reconnects and re-IDLEs until ctx is canceled. Each failed
+// attempt doubles the backoff, capped at 5 minutes. It is reset to one
+// second only when runOnce returns nil; a connection that was up for a
+// while and then drops with an error still doubles it.
+func (w *IdleWatcher) Run(ctx context.Context) {
+	const maxBackoff = 5 * time.Minute
+	backoff := time.Second
+
+	for ctx.Err() == nil {
+		if err := w.limiter.WaitURL(ctx, "https://"+w.cfg.Email.IMAPHost); err != nil {
+			return
+		}
+
+		err := w.runOnce(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Printf("[email-idle] connection error, retrying in %s: %v", backoff, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			continue
+		}
+		backoff = time.Second
+	}
+}
+
+// runOnce opens one connection, selects w.mailbox and either IDLEs
+// (closing the command only when ctx is canceled, the standard
+// long-poll shape) or - if the server lacks the IDLE capability -
+// falls back to pollFallback's periodic NOOP. Returns once the
+// connection drops or ctx is canceled.
+func (w *IdleWatcher) runOnce(ctx context.Context) error {
+	// IdleWatcher only needs src for its connection credentials (addr/
+	// username/password) - it drives its own imapclient.Client below
+	// rather than calling src.List, so there's no mail_cursors cursor
+	// to persist here.
+	src, err := newIMAPSource(w.cfg, nil)
+	if err != nil {
+		return err
+	}
+
+	c, err := DialAndLoginIMAPWithOptions(ctx, src.addr, src.username, src.password, &imapclient.Options{
+		TLSConfig: GmailTLSConfig(),
+		UnilateralDataHandler: &imapclient.UnilateralDataHandler{
+			Mailbox: func(data *imapclient.UnilateralDataMailbox) {
+				if data.NumMessages != nil {
+					w.notify()
+				}
+			},
+			Expunge: func(seqNum uint32) {
+				w.notify()
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer LogoutAndClose(c)
+
+	if _, err := c.Select(w.mailbox, &imap.SelectOptions{ReadOnly: true}).Wait(); err != nil {
+		return err
+	}
+
+	caps, err := c.Capability().Wait()
+	if err != nil {
+		return err
+	}
+	if !caps.Has(imap.CapIdle) {
+		return w.pollFallback(ctx, c)
+	}
+
+	idleCmd, err := c.Idle()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = idleCmd.Close()
+		case <-done:
+		}
+	}()
+	err = idleCmd.Wait()
+	close(done)
+	return err
+}
+
+// pollFallback stands in for servers that reject IDLE: a NOOP still
+// makes the server flush any pending untagged EXISTS/EXPUNGE (the same
+// unilateral data IDLE would've delivered), just on a coarser interval.
+func (w *IdleWatcher) pollFallback(ctx context.Context, c *imapclient.Client) error {
+	t := time.NewTicker(idleNoopInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+			if err := c.Noop().Wait(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// IdleEmailFetcher adapts IdleWatcher to types.Fetcher. Unlike every
+// other registered fetcher, which does one bounded unit of work per
+// Fetch call, this one starts its watcher (idempotently, via once) on
+// the first Fetch and returns immediately - the watcher and its
+// RunEmailScrapeOnce calls run for the rest of the process's lifetime,
+// not just for the duration of one poll tick.
+type IdleEmailFetcher struct {
+	Cfg     config.Config
+	DB      *sql.DB
+	Limiter *util.HostLimiter
+
+	once    sync.Once
+	watcher *IdleWatcher
+}
+
+func (f *IdleEmailFetcher) Name() string { return "email-idle" }
+
+func (f *IdleEmailFetcher) Fetch(ctx context.Context) (types.ScrapeResult, error) {
+	f.once.Do(func() {
+		f.watcher = NewIdleWatcher(f.Cfg, "", f.Limiter)
+		runCtx := context.Background()
+		go f.watcher.Run(runCtx)
+		go f.watchSignal(runCtx)
+	})
+	return types.ScrapeResult{Source: f.Name()}, nil
+}
+
+// watchSignal runs RunEmailScrapeOnce every time f.watcher reports the
+// mailbox changed. bus is nil (publish() in run_email.go is nil-safe)
+// since this fetcher, like every Fetcher, is reached through
+// poll.PollOnce/internal/jobs rather than holding its own event bus.
+func (f *IdleEmailFetcher) watchSignal(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.watcher.Signal():
+			if _, err := RunEmailScrapeOnce(f.DB, f.Cfg, nil); err != nil {
+				log.Printf("[email-idle] scrape err: %v", err)
+			}
+		}
+	}
+}
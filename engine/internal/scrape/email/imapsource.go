@@ -0,0 +1,286 @@
+package email_scrape
+
+import (
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"jobhunt-engine/internal/config"
+	emailcache "jobhunt-engine/internal/scrape/email/cache"
+	"jobhunt-engine/internal/secrets"
+	"jobhunt-engine/internal/store"
+
+	"github.com/emersion/go-imap/v2"
+	"github.com/emersion/go-imap/v2/imapclient"
+)
+
+// imapSource is the original MailSource: a single IMAP connection,
+// re-selecting whichever mailbox crit.Mailboxes names before running
+// Fetch against it.
+type imapSource struct {
+	addr     string
+	username string
+	password string
+
+	// cache, when non-nil, lets List skip re-downloading a message's
+	// body for a UID it already has an Entry for - see
+	// internal/scrape/email/cache. Opened lazily by messageCache, so
+	// it's nil only if that failed (logged, not fatal: List just runs
+	// uncached in that case).
+	cache *emailcache.Store
+
+	// db, when non-nil, lets List resume from the mail_cursors
+	// high-water mark persisted for a mailbox instead of re-SEARCHing
+	// the whole thing after every restart. nil (SourceFor called with
+	// no db) just means every run searches from the start, same as
+	// before mail_cursors existed.
+	db *sql.DB
+
+	c *imapclient.Client
+}
+
+func newIMAPSource(cfg config.Config, db *sql.DB) (*imapSource, error) {
+	if cfg.Email.IMAPHost == "" || cfg.Email.Username == "" {
+		return nil, fmt.Errorf("email backend imap: missing imap_host/username")
+	}
+
+	pass, err := secrets.Resolve(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("resolve imap password: %w", err)
+	}
+
+	addr := cfg.Email.IMAPHost
+	if cfg.Email.IMAPPort != 0 && !strings.Contains(addr, ":") {
+		addr = fmt.Sprintf("%s:%d", addr, cfg.Email.IMAPPort)
+	} else if !strings.Contains(addr, ":") {
+		addr += ":993"
+	}
+
+	// A cache failing to open (e.g. unwritable CacheDir) shouldn't
+	// block email scraping - cache is nil and List falls back to
+	// downloading every matched UID's body, same as before this cache
+	// existed.
+	cache, _ := messageCache(cfg)
+
+	return &imapSource{addr: addr, username: cfg.Email.Username, password: pass, cache: cache, db: db}, nil
+}
+
+func (s *imapSource) Open(ctx context.Context) error {
+	c, err := DialAndLoginIMAP(ctx, s.addr, s.username, s.password, GmailTLSConfig())
+	if err != nil {
+		return err
+	}
+	s.c = c
+	return nil
+}
+
+// List selects each mailbox in crit.Mailboxes in turn and runs crit
+// against it, since a single IMAP SEARCH only ever applies to the
+// currently selected mailbox. When s.cache is set, a UID already
+// cached for this mailbox's current UIDVALIDITY is skipped entirely -
+// no BODY.PEEK[] download, no RawMessage in the result - since it was
+// already downloaded and handed to the caller on an earlier List.
+func (s *imapSource) List(ctx context.Context, crit SearchCriteria) ([]RawMessage, error) {
+	mailboxes := crit.Mailboxes
+	if len(mailboxes) == 0 {
+		mailboxes = []string{"INBOX"}
+	}
+
+	var out []RawMessage
+	for _, mailbox := range mailboxes {
+		selData, err := s.c.Select(mailbox, &imap.SelectOptions{ReadOnly: false}).Wait()
+		if err != nil {
+			return nil, fmt.Errorf("imap select %q: %w", mailbox, err)
+		}
+
+		// account scopes the cache by mailbox too, not just username -
+		// UIDVALIDITY is only meaningful within one mailbox, so two
+		// mailboxes that happen to report the same value must still
+		// land in different buckets.
+		account := s.username + "/" + mailbox
+
+		if s.cache != nil {
+			if err := s.cache.CheckUIDValidity(account, selData.UIDValidity); err != nil {
+				return nil, fmt.Errorf("email cache uidvalidity %q: %w", mailbox, err)
+			}
+		}
+
+		mailboxCrit := crit
+		mailboxCrit.Mailboxes = nil
+		if s.db != nil {
+			mailboxCrit.MinUID = s.cursorMinUID(ctx, account, mailbox, selData.UIDValidity)
+		}
+
+		uids, err := searchUIDs(ctx, s.c, mailboxCrit)
+		if err != nil {
+			return nil, fmt.Errorf("imap search %q: %w", mailbox, err)
+		}
+
+		if s.db != nil && len(uids) > 0 {
+			s.saveCursor(ctx, account, mailbox, selData.UIDValidity, uids)
+		}
+
+		toFetch := uids
+		if s.cache != nil {
+			toFetch = make([]imap.UID, 0, len(uids))
+			for _, uid := range uids {
+				if _, ok := s.cache.Get(account, selData.UIDValidity, uint32(uid)); ok {
+					continue
+				}
+				toFetch = append(toFetch, uid)
+			}
+		}
+
+		msgs, err := fetchUIDs(ctx, s.c, toFetch)
+		if err != nil {
+			return nil, fmt.Errorf("imap fetch %q: %w", mailbox, err)
+		}
+
+		for _, m := range msgs {
+			if s.cache != nil {
+				_ = s.cache.Put(account, selData.UIDValidity, uint32(m.UID), emailcache.Entry{
+					Subject:     m.Subject,
+					From:        m.From,
+					Date:        m.Date,
+					ContentHash: contentHash(m.RawMessage),
+				})
+			}
+			out = append(out, RawMessage{
+				ID:      imapMessageID(mailbox, m.UID),
+				Mailbox: mailbox,
+				From:    m.From,
+				To:      m.To,
+				Subject: m.Subject,
+				Date:    m.Date,
+				Raw:     m.RawMessage,
+			})
+		}
+	}
+
+	return out, nil
+}
+
+// cursorMinUID returns the mail_cursors high-water mark to resume
+// account/mailbox's search from, or 0 (search everything) on its
+// first run or a logged lookup error. A UIDVALIDITY change means the
+// server has reassigned every UID in the mailbox, so the old
+// high-water mark no longer means anything and is discarded.
+func (s *imapSource) cursorMinUID(ctx context.Context, account, mailbox string, uidValidity uint32) uint32 {
+	prevValidity, lastUID, err := store.GetMailCursor(ctx, s.db, account, mailbox)
+	if err != nil {
+		log.Printf("[email] mail_cursors lookup %q: %v", mailbox, err)
+		return 0
+	}
+	if prevValidity != 0 && prevValidity != uidValidity {
+		return 0
+	}
+	return lastUID
+}
+
+// saveCursor persists uidValidity and the highest UID in uids as
+// account/mailbox's new mail_cursors high-water mark, so the next run
+// only searches what's newer. Errors are logged, not returned - a
+// failed save just means the next run re-searches a bit more than it
+// needs to, not data loss.
+func (s *imapSource) saveCursor(ctx context.Context, account, mailbox string, uidValidity uint32, uids []imap.UID) {
+	var maxUID imap.UID
+	for _, uid := range uids {
+		if uid > maxUID {
+			maxUID = uid
+		}
+	}
+	if err := store.SetMailCursor(ctx, s.db, account, mailbox, uidValidity, uint32(maxUID)); err != nil {
+		log.Printf("[email] mail_cursors save %q: %v", mailbox, err)
+	}
+}
+
+// MarkProcessed groups ids by mailbox (re-selecting each in turn, same
+// constraint as List) and stores \Seen for the UIDs in each.
+func (s *imapSource) MarkProcessed(ctx context.Context, ids []string) error {
+	byMailbox := map[string][]imap.UID{}
+	for _, id := range ids {
+		mailbox, uid, err := parseIMAPMessageID(id)
+		if err != nil {
+			return err
+		}
+		byMailbox[mailbox] = append(byMailbox[mailbox], uid)
+	}
+
+	for mailbox, uids := range byMailbox {
+		if _, err := s.c.Select(mailbox, &imap.SelectOptions{ReadOnly: false}).Wait(); err != nil {
+			return fmt.Errorf("imap select %q: %w", mailbox, err)
+		}
+		if err := MarkSeen(s.c, uids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveProcessed groups ids by mailbox (same constraint as
+// MarkProcessed) and moves each group into folder via MoveProcessed.
+func (s *imapSource) moveProcessed(ctx context.Context, ids []string, folder string) error {
+	byMailbox := map[string][]imap.UID{}
+	for _, id := range ids {
+		mailbox, uid, err := parseIMAPMessageID(id)
+		if err != nil {
+			return err
+		}
+		byMailbox[mailbox] = append(byMailbox[mailbox], uid)
+	}
+
+	for mailbox, uids := range byMailbox {
+		if _, err := s.c.Select(mailbox, &imap.SelectOptions{ReadOnly: false}).Wait(); err != nil {
+			return fmt.Errorf("imap select %q: %w", mailbox, err)
+		}
+		if err := MoveProcessed(s.c, uids, folder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expungeOldSeen selects mailbox and runs ExpungeOldSeen against it.
+func (s *imapSource) expungeOldSeen(ctx context.Context, mailbox string, olderThan time.Duration, trashFolder string) error {
+	if _, err := s.c.Select(mailbox, &imap.SelectOptions{ReadOnly: false}).Wait(); err != nil {
+		return fmt.Errorf("imap select %q: %w", mailbox, err)
+	}
+	return ExpungeOldSeen(s.c, olderThan, trashFolder)
+}
+
+func (s *imapSource) Close() error {
+	LogoutAndClose(s.c)
+	return nil
+}
+
+// imapMessageID packs a mailbox + UID into the opaque RawMessage.ID.
+func imapMessageID(mailbox string, uid imap.UID) string {
+	return mailbox + "\x1f" + strconv.FormatUint(uint64(uid), 10)
+}
+
+func parseIMAPMessageID(id string) (mailbox string, uid imap.UID, err error) {
+	mailbox, numStr, ok := strings.Cut(id, "\x1f")
+	if !ok {
+		return "", 0, fmt.Errorf("imap: malformed message id %q", id)
+	}
+	n, err := strconv.ParseUint(numStr, 10, 32)
+	if err != nil {
+		return "", 0, fmt.Errorf("imap: malformed message id %q: %w", id, err)
+	}
+	return mailbox, imap.UID(n), nil
+}
+
+// contentHash fingerprints raw so a cache Entry can flag the rare case
+// of a server reusing a UID for different content without bumping
+// UIDVALIDITY - not relied on for correctness today (Get doesn't
+// compare it), just recorded for that future/debugging use.
+func contentHash(raw []byte) string {
+	sum := sha1.Sum(raw)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,234 @@
+package email_scrape
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"jobhunt-engine/internal/config"
+	"jobhunt-engine/internal/secrets"
+
+	"git.sr.ht/~rockorager/go-jmap"
+	"git.sr.ht/~rockorager/go-jmap/mail"
+	"git.sr.ht/~rockorager/go-jmap/mail/email"
+	"git.sr.ht/~rockorager/go-jmap/mail/mailbox"
+)
+
+// seenKeyword is the JMAP analogue of IMAP's \Seen flag.
+// https://www.rfc-editor.org/rfc/rfc8621.html#section-4.1.1
+const seenKeyword = "$seen"
+
+// jmapSource talks JMAP Email/query + Email/get to providers like
+// Fastmail, instead of IMAP. crit is translated into an email.Filter;
+// the raw RFC822 message for each hit is pulled by downloading its
+// blob (Email.BlobID is the whole message, per RFC 8621 section 4.1.1).
+type jmapSource struct {
+	client    *jmap.Client
+	accountID jmap.ID
+}
+
+func newJMAPSource(cfg config.Config) (*jmapSource, error) {
+	if cfg.Email.JMAP.SessionEndpoint == "" {
+		return nil, fmt.Errorf("email backend jmap: missing email.jmap.session_endpoint")
+	}
+
+	token, err := secrets.Resolve(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("resolve jmap access token: %w", err)
+	}
+
+	c := &jmap.Client{SessionEndpoint: cfg.Email.JMAP.SessionEndpoint}
+	c.WithAccessToken(token)
+
+	return &jmapSource{client: c}, nil
+}
+
+func (s *jmapSource) Open(ctx context.Context) error {
+	if err := s.client.Authenticate(); err != nil {
+		return fmt.Errorf("jmap authenticate: %w", err)
+	}
+	id, ok := s.client.Session.PrimaryAccounts[mail.URI]
+	if !ok {
+		return fmt.Errorf("jmap: session has no primary mail account")
+	}
+	s.accountID = id
+	return nil
+}
+
+// List queries each mailbox in crit.Mailboxes by name (falling back to
+// the "inbox" role), fetches matching Email objects, and downloads
+// each one's raw blob.
+func (s *jmapSource) List(ctx context.Context, crit SearchCriteria) ([]RawMessage, error) {
+	mailboxes := crit.Mailboxes
+	if len(mailboxes) == 0 {
+		mailboxes = []string{"inbox"}
+	}
+
+	var out []RawMessage
+	for _, mb := range mailboxes {
+		mailboxID, err := s.resolveMailbox(ctx, mb)
+		if err != nil {
+			return nil, err
+		}
+
+		limit := uint64(crit.MaxPerMailbox)
+		if limit == 0 {
+			limit = 50
+		}
+
+		req := &jmap.Request{Context: ctx}
+		queryCall := req.Invoke(&email.Query{
+			Account: s.accountID,
+			Filter:  jmapFilter(mailboxID, crit),
+			Sort:    []*email.SortComparator{{Property: "receivedAt", IsAscending: false}},
+			Limit:   limit,
+		})
+		req.Invoke(&email.Get{
+			Account:             s.accountID,
+			FetchAllBodyValues:  false,
+			FetchTextBodyValues: false,
+			ReferenceIDs: &jmap.ResultReference{
+				ResultOf: queryCall,
+				Name:     "Email/query",
+				Path:     "/ids",
+			},
+		})
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("jmap query %q: %w", mb, err)
+		}
+
+		for _, inv := range resp.Responses {
+			get, ok := inv.Args.(*email.GetResponse)
+			if !ok {
+				continue
+			}
+			for _, e := range get.List {
+				raw, err := s.downloadRaw(ctx, e)
+				if err != nil {
+					return nil, fmt.Errorf("jmap download %s: %w", e.ID, err)
+				}
+				rm := rawMessageFromBytes(raw)
+				rm.ID = string(e.ID)
+				rm.Mailbox = mb
+				if e.ReceivedAt != nil {
+					rm.Date = *e.ReceivedAt
+				}
+				out = append(out, rm)
+			}
+		}
+	}
+
+	return out, nil
+}
+
+func (s *jmapSource) downloadRaw(ctx context.Context, e *email.Email) ([]byte, error) {
+	rc, err := s.client.DownloadWithContext(ctx, s.accountID, e.BlobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// resolveMailbox looks up a mailbox by name (falling back to role)
+// since email.Query filters on a mailbox ID, not a name.
+func (s *jmapSource) resolveMailbox(ctx context.Context, name string) (jmap.ID, error) {
+	req := &jmap.Request{Context: ctx}
+	req.Invoke(&mailbox.Get{Account: s.accountID})
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("jmap mailbox get: %w", err)
+	}
+
+	for _, inv := range resp.Responses {
+		get, ok := inv.Args.(*mailbox.GetResponse)
+		if !ok {
+			continue
+		}
+		for _, mb := range get.List {
+			if mb.Name == name || string(mb.Role) == name {
+				return mb.ID, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("jmap: no mailbox named %q", name)
+}
+
+// jmapFilter translates crit into the FilterCondition/FilterOperator
+// tree email.Query expects. SubjectAny (aerc's "OR across entries")
+// has no single FilterCondition equivalent, so it's built as a nested
+// OR of single-subject conditions.
+func jmapFilter(mailboxID jmap.ID, crit SearchCriteria) email.Filter {
+	and := &email.FilterOperator{Operator: jmap.OperatorAND}
+	and.Conditions = append(and.Conditions, &email.FilterCondition{InMailbox: mailboxID})
+
+	for _, f := range crit.WithoutFlags {
+		if f == "\\Seen" {
+			and.Conditions = append(and.Conditions, &email.FilterCondition{NotKeyword: seenKeyword})
+		}
+	}
+	for _, f := range crit.WithFlags {
+		if f == "\\Seen" {
+			and.Conditions = append(and.Conditions, &email.FilterCondition{HasKeyword: seenKeyword})
+		}
+	}
+
+	for _, f := range crit.From {
+		and.Conditions = append(and.Conditions, &email.FilterCondition{From: f})
+	}
+	for _, t := range crit.To {
+		and.Conditions = append(and.Conditions, &email.FilterCondition{To: t})
+	}
+	for _, s := range crit.SubjectAll {
+		and.Conditions = append(and.Conditions, &email.FilterCondition{Subject: s})
+	}
+	for _, s := range crit.BodyContains {
+		and.Conditions = append(and.Conditions, &email.FilterCondition{Body: s})
+	}
+	if !crit.Since.IsZero() {
+		since := crit.Since
+		and.Conditions = append(and.Conditions, &email.FilterCondition{After: &since})
+	}
+	if !crit.Before.IsZero() {
+		before := crit.Before
+		and.Conditions = append(and.Conditions, &email.FilterCondition{Before: &before})
+	}
+
+	if len(crit.SubjectAny) > 0 {
+		or := &email.FilterOperator{Operator: jmap.OperatorOR}
+		for _, s := range crit.SubjectAny {
+			or.Conditions = append(or.Conditions, &email.FilterCondition{Subject: s})
+		}
+		and.Conditions = append(and.Conditions, or)
+	}
+
+	return and
+}
+
+// MarkProcessed sets the $seen keyword on each email id, the JMAP
+// equivalent of IMAP's \Seen.
+func (s *jmapSource) MarkProcessed(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	update := make(map[jmap.ID]jmap.Patch, len(ids))
+	for _, id := range ids {
+		update[jmap.ID(id)] = jmap.Patch{"keywords/" + seenKeyword: true}
+	}
+
+	req := &jmap.Request{Context: ctx}
+	req.Invoke(&email.Set{Account: s.accountID, Update: update})
+
+	if _, err := s.client.Do(req); err != nil {
+		return fmt.Errorf("jmap mark seen: %w", err)
+	}
+	return nil
+}
+
+func (s *jmapSource) Close() error {
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"regexp"
 	"strings"
 
+	"jobhunt-engine/internal/scrape/util"
+
 	"github.com/PuerkitoBio/goquery"
 )
 
@@ -51,6 +53,14 @@ func ParseLinkedInJobAlertHTML(htmlBody string) ([]LinkedInJob, error) {
 			return
 		}
 
+		// /jobs/view/ is also where LinkedIn points "manage this job
+		// alert"/unsubscribe anchors in the same email - util.ClassifyURL
+		// catches those via isObviousJunkURL before this anchor's title
+		// gets a chance to produce a bogus JobLead.
+		if util.ClassifyURL(jobURL) != util.TagPrimary {
+			return
+		}
+
 		sourceID := linkedInSourceID(jobURL)
 		key := sourceID
 		if key == "" {
@@ -302,21 +312,6 @@ func looksLikeLinkedInJobURL(href string) bool {
 		(strings.Contains(h, "/jobs/view") || strings.Contains(h, "/comm/jobs/view"))
 }
 
-func looksLikeLinkedInJobAlert(from, subj, body string) bool {
-	f := strings.ToLower(from)
-	if strings.Contains(f, "jobalerts-noreply") {
-		return true
-	}
-	s := strings.ToLower(subj)
-	if strings.Contains(s, "job alert") || strings.Contains(s, "linkedin") {
-		// body check prevents false positives
-		b := strings.ToLower(body)
-		return strings.Contains(b, "linkedin.com/comm/jobs/view") ||
-			strings.Contains(b, "linkedin.com/jobs/view")
-	}
-	return false
-}
-
 func titleScore(s string) int {
 	orig := strings.TrimSpace(s)
 	if orig == "" {
@@ -0,0 +1,152 @@
+package email_scrape
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"jobhunt-engine/internal/config"
+
+	maildir "github.com/emersion/go-maildir"
+)
+
+// maildirSource reads from a local Maildir mirror kept in sync by
+// offlineimap/mbsync/isync instead of talking IMAP directly. There's
+// no server-side SEARCH here, so List filters every message in-process
+// against crit.
+type maildirSource struct {
+	root string
+}
+
+func newMaildirSource(cfg config.MaildirConfig) (*maildirSource, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("email backend maildir: missing email.maildir.path")
+	}
+	return &maildirSource{root: cfg.Path}, nil
+}
+
+// Open is a no-op: each List/MarkProcessed call opens the Maildir
+// subdirectory it needs, since a Dir is just a path.
+func (s *maildirSource) Open(ctx context.Context) error {
+	return nil
+}
+
+// dirFor resolves a configured mailbox name to its Maildir++ path:
+// "" or "INBOX" is the root, anything else is a "."-prefixed
+// subfolder, e.g. "Job Alerts" -> root/.Job Alerts.
+func (s *maildirSource) dirFor(mailbox string) maildir.Dir {
+	if mailbox == "" || strings.EqualFold(mailbox, "INBOX") {
+		return maildir.Dir(s.root)
+	}
+	return maildir.Dir(filepath.Join(s.root, "."+mailbox))
+}
+
+// List moves new mail into cur (Dir.Unseen, the Maildir-native "the
+// application has seen this file exists" step), then keeps whatever
+// matches crit: Unseen messages that still lack the S flag, plus
+// anything else crit asks for via From/To/SubjectAny/SubjectAll/
+// BodyContains/Since/Before, checked against the parsed headers/body.
+func (s *maildirSource) List(ctx context.Context, crit SearchCriteria) ([]RawMessage, error) {
+	mailboxes := crit.Mailboxes
+	if len(mailboxes) == 0 {
+		mailboxes = []string{""}
+	}
+
+	var out []RawMessage
+	for _, mailbox := range mailboxes {
+		dir := s.dirFor(mailbox)
+
+		if _, err := dir.Unseen(); err != nil {
+			return nil, fmt.Errorf("maildir unseen %q: %w", mailbox, err)
+		}
+
+		msgs, err := dir.Messages()
+		if err != nil {
+			return nil, fmt.Errorf("maildir messages %q: %w", mailbox, err)
+		}
+
+		kept := 0
+		for _, m := range msgs {
+			raw, err := readMaildirMessage(m)
+			if err != nil {
+				return nil, fmt.Errorf("maildir read %q: %w", m.Filename(), err)
+			}
+
+			rm := rawMessageFromBytes(raw)
+			rm.ID = m.Key()
+			rm.Mailbox = mailbox
+
+			if !matchesCriteria(crit, rm, hasMaildirFlag(m, maildir.FlagSeen)) {
+				continue
+			}
+			out = append(out, rm)
+			kept++
+
+			if crit.MaxPerMailbox > 0 && kept >= crit.MaxPerMailbox {
+				break
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// MarkProcessed adds the S(een) flag to each message, which is the
+// Maildir equivalent of IMAP's \Seen: the message stays in cur/ but a
+// later Unseen()/Messages() pass won't treat it as fresh again.
+func (s *maildirSource) MarkProcessed(ctx context.Context, ids []string) error {
+	for _, key := range ids {
+		// The key alone doesn't say which subfolder it's in, so try
+		// every mailbox we might have listed from; MessageByKey fails
+		// fast if the key isn't present.
+		msg, err := s.findByKey(key)
+		if err != nil {
+			return err
+		}
+		flags := append(append([]maildir.Flag(nil), msg.Flags()...), maildir.FlagSeen)
+		if err := msg.SetFlags(flags); err != nil {
+			return fmt.Errorf("maildir set flags %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func (s *maildirSource) findByKey(key string) (*maildir.Message, error) {
+	if msg, err := maildir.Dir(s.root).MessageByKey(key); err == nil {
+		return msg, nil
+	}
+	matches, err := filepath.Glob(filepath.Join(s.root, ".*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range matches {
+		if msg, err := maildir.Dir(sub).MessageByKey(key); err == nil {
+			return msg, nil
+		}
+	}
+	return nil, fmt.Errorf("maildir: message %q not found", key)
+}
+
+func (s *maildirSource) Close() error {
+	return nil
+}
+
+func hasMaildirFlag(m *maildir.Message, flag maildir.Flag) bool {
+	for _, f := range m.Flags() {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+func readMaildirMessage(m *maildir.Message) ([]byte, error) {
+	f, err := m.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
@@ -0,0 +1,154 @@
+package email_scrape
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"jobhunt-engine/internal/config"
+
+	"github.com/emersion/go-imap/v2"
+)
+
+// RawMessage is a backend-agnostic view of one message: whichever
+// MailSource produced it, the same parseRFC822/extraction pipeline in
+// run_email.go processes it identically.
+type RawMessage struct {
+	// ID opaquely identifies this message to the backend that produced
+	// it (a mailbox/UID pair for IMAP, a maildir key, a JMAP Email id,
+	// ...). Pass it back to MarkProcessed once it's been handled.
+	ID string
+
+	Mailbox string
+	From    string
+	To      string
+	Subject string
+	Date    time.Time
+
+	// Raw is the full RFC822 message (headers + body).
+	Raw []byte
+}
+
+// MailSource abstracts the mail backend RunEmailScrapeOnce scans, so
+// the same job-extraction pipeline can run against a hosted IMAP
+// server, an offline Maildir mirror (offlineimap/mbsync/isync), or a
+// JMAP provider like Fastmail without touching run_email.go.
+type MailSource interface {
+	// Open connects/authenticates. Called once before any List.
+	Open(ctx context.Context) error
+
+	// List runs crit against the source and returns the matching
+	// messages. crit.Mailboxes names the mailbox(es)/label(s) to
+	// search; implementations that have no notion of "unread" or
+	// "SEARCH" emulate crit by filtering fetched messages in-process.
+	List(ctx context.Context, crit SearchCriteria) ([]RawMessage, error)
+
+	// MarkProcessed marks the given RawMessage.ID values as handled,
+	// so a later run doesn't return them again (\Seen for IMAP, the
+	// cur/ S flag for Maildir, the $seen keyword for JMAP).
+	MarkProcessed(ctx context.Context, ids []string) error
+
+	// Close releases the connection. Safe to call even if Open failed.
+	Close() error
+}
+
+// postProcessor is implemented by a MailSource that can file
+// successfully-processed mail away and sweep old \Seen messages -
+// today just imapSource, via MoveProcessed/ExpungeOldSeen. Unexported
+// and checked with a type assertion in run_email.go rather than added
+// to MailSource itself, since maildir/jmap have no equivalent concept
+// of "another mailbox to move into".
+type postProcessor interface {
+	moveProcessed(ctx context.Context, ids []string, folder string) error
+	expungeOldSeen(ctx context.Context, mailbox string, olderThan time.Duration, trashFolder string) error
+}
+
+// SourceFor builds the MailSource selected by cfg.Email.Backend
+// ("imap", the default; "maildir"; or "jmap"), resolving whatever
+// credential it needs via internal/secrets. db is only used by the
+// imap backend, to persist its mail_cursors high-water mark; it may be
+// nil, in which case imapSource just searches the whole mailbox every
+// run the way it always has.
+func SourceFor(cfg config.Config, db *sql.DB) (MailSource, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Email.Backend)) {
+	case "maildir":
+		return newMaildirSource(cfg.Email.Maildir)
+	case "jmap":
+		return newJMAPSource(cfg)
+	case "", "imap":
+		return newIMAPSource(cfg, db)
+	default:
+		return nil, fmt.Errorf("email: unknown backend %q (want imap, maildir or jmap)", cfg.Email.Backend)
+	}
+}
+
+// rawMessageFromBytes parses just enough of raw (From/To/Subject/Date)
+// to populate a RawMessage for backends that don't already get an
+// envelope from the server (Maildir, JMAP's raw blob).
+func rawMessageFromBytes(raw []byte) RawMessage {
+	subj, from, to, date := parseHeadersFallback(raw)
+	return RawMessage{From: from, To: to, Subject: subj, Date: date, Raw: raw}
+}
+
+// matchesCriteria applies crit in-process, for backends with no
+// server-side SEARCH (Maildir, JMAP filters not modeled below). seen
+// reports whether the backend already considers rm read/flagged.
+func matchesCriteria(crit SearchCriteria, rm RawMessage, seen bool) bool {
+	for _, f := range crit.WithoutFlags {
+		if f == imap.FlagSeen && seen {
+			return false
+		}
+	}
+	for _, f := range crit.WithFlags {
+		if f == imap.FlagSeen && !seen {
+			return false
+		}
+	}
+
+	for _, f := range crit.From {
+		if !containsAnyCI(rm.From, []string{f}) {
+			return false
+		}
+	}
+	for _, t := range crit.To {
+		if !containsAnyCI(rm.To, []string{t}) {
+			return false
+		}
+	}
+	if len(crit.SubjectAny) > 0 && !containsAnyCI(rm.Subject, crit.SubjectAny) {
+		return false
+	}
+	for _, s := range crit.SubjectAll {
+		if !strings.Contains(strings.ToLower(rm.Subject), strings.ToLower(s)) {
+			return false
+		}
+	}
+	for _, s := range crit.BodyContains {
+		if !strings.Contains(strings.ToLower(string(rm.Raw)), strings.ToLower(s)) {
+			return false
+		}
+	}
+	if !crit.Since.IsZero() && rm.Date.Before(crit.Since) {
+		return false
+	}
+	if !crit.Before.IsZero() && rm.Date.After(crit.Before) {
+		return false
+	}
+
+	return true
+}
+
+func containsAnyCI(s string, any []string) bool {
+	if len(any) == 0 {
+		return false
+	}
+	ls := strings.ToLower(s)
+	for _, a := range any {
+		if strings.Contains(ls, strings.ToLower(a)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package email_scrape
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/emersion/go-imap/v2"
+	"github.com/emersion/go-imap/v2/imapclient"
+)
+
+// gmailCap is the capability Gmail's IMAP server advertises for its
+// label extensions (X-GM-LABELS, X-GM-THRID, ...). Not in the
+// go-imap/v2 registered-capabilities list since it's Google-specific,
+// not an IANA one.
+const gmailCap = imap.Cap("X-GM-EXT-1")
+
+// defaultGmailTrashFolder is used when cfg.Email.TrashFolder is unset
+// and the server is detected as Gmail.
+const defaultGmailTrashFolder = "[Gmail]/Trash"
+
+// isGmail reports whether c is talking to a server advertising
+// Gmail's IMAP extensions, the same signal GmailTLSConfig's callers
+// already assume when dialing.
+func isGmail(c *imapclient.Client) bool {
+	caps := c.Caps()
+	return caps != nil && caps.Has(gmailCap)
+}
+
+// MoveProcessed moves uids (already selected on whatever mailbox
+// they belong to) into folder. Uses imapclient.Client.Move, which
+// already falls back to COPY + STORE +FLAGS.SILENT \Deleted +
+// EXPUNGE for servers that don't advertise the MOVE extension, so
+// this is a thin wrapper rather than a bespoke fallback.
+func MoveProcessed(c *imapclient.Client, uids []imap.UID, folder string) error {
+	if c == nil {
+		return fmt.Errorf("imap client is nil")
+	}
+	if len(uids) == 0 || folder == "" {
+		return nil
+	}
+
+	set := imap.UIDSetNum(uids...)
+	if _, err := c.Move(set, folder).Wait(); err != nil {
+		return fmt.Errorf("imap move to %q: %w", folder, err)
+	}
+	return nil
+}
+
+// ExpungeOldSeen permanently removes \Seen messages older than
+// olderThan from whatever mailbox is currently selected on c,
+// modelled on the classic aerc/goimap cleanup pattern: UIDSearch
+// SEEN BEFORE <date>, then flag and reap the matches.
+//
+// Gmail has no \Deleted/EXPUNGE semantics of its own - deleting a
+// message there means moving it to its Trash label instead - so a
+// server detected via CAPABILITY as Gmail is moved to trashFolder
+// (defaultGmailTrashFolder if empty) rather than flagged \Deleted.
+// go-imap/v2 has no command builder for Gmail's X-GM-LABELS STORE
+// item, but Move already reproduces the same end state on Gmail
+// (drop the current mailbox's label, add the destination's) via its
+// own COPY + STORE + EXPUNGE fallback, so no raw STORE is needed.
+func ExpungeOldSeen(c *imapclient.Client, olderThan time.Duration, trashFolder string) error {
+	if c == nil {
+		return fmt.Errorf("imap client is nil")
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	crit := SearchCriteria{
+		WithFlags: []imap.Flag{imap.FlagSeen},
+		Before:    cutoff,
+	}
+
+	searchData, err := c.UIDSearch(crit.compile(), nil).Wait()
+	if err != nil {
+		return fmt.Errorf("imap uid search seen before %s: %w", cutoff.Format(time.DateOnly), err)
+	}
+	uids := searchData.AllUIDs()
+	if len(uids) == 0 {
+		return nil
+	}
+
+	if isGmail(c) {
+		folder := trashFolder
+		if folder == "" {
+			folder = defaultGmailTrashFolder
+		}
+		return MoveProcessed(c, uids, folder)
+	}
+
+	set := imap.UIDSetNum(uids...)
+	storeCmd := c.Store(set, &imap.StoreFlags{
+		Op:     imap.StoreFlagsAdd,
+		Silent: true,
+		Flags:  []imap.Flag{imap.FlagDeleted},
+	}, nil)
+	if err := storeCmd.Close(); err != nil {
+		return fmt.Errorf("imap store add deleted: %w", err)
+	}
+
+	// UID EXPUNGE (RFC 4315) only reaps the UIDs just flagged, so an
+	// unrelated message another client flagged \Deleted in the
+	// meantime is left alone. Falls back to a plain EXPUNGE, which
+	// reaps every \Deleted message in the mailbox, on servers without
+	// UIDPLUS - same capability Move's own fallback already checks.
+	if caps := c.Caps(); caps != nil && caps.Has(imap.CapUIDPlus) {
+		if err := c.UIDExpunge(set).Close(); err != nil {
+			return fmt.Errorf("imap uid expunge: %w", err)
+		}
+		return nil
+	}
+	if err := c.Expunge().Close(); err != nil {
+		return fmt.Errorf("imap expunge: %w", err)
+	}
+	return nil
+}
@@ -14,12 +14,21 @@ import (
 
 	"jobhunt-engine/internal/config"
 	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/events"
+	"jobhunt-engine/internal/metrics"
+	"jobhunt-engine/internal/pipeline"
 	"jobhunt-engine/internal/rank"
+	"jobhunt-engine/internal/scrape/util"
 
 	"github.com/emersion/go-imap/v2"
 )
 
-type jobRow struct {
+// JobRow is the sink for every backend: MailSource implementations
+// feed RunEmailScrapeOnce, which builds one of these per extracted
+// lead and hands it to InsertJobIfNew. Exported so other packages that
+// talk to the mailbox directly (e.g. internal/jmap_scrape) can reuse
+// the same insert path instead of duplicating the jobs table write.
+type JobRow struct {
 	Company     string
 	Title       string
 	Location    string
@@ -32,9 +41,64 @@ type jobRow struct {
 	SourceID    string
 }
 
-// RunEmailScrapeOnce scans UNSEEN emails, but ONLY those whose subject matches cfg.Email.SearchSubjectAny.
-// It extracts job-ish URLs and inserts rows into jobs (deduped by source_id), then marks emails \Seen.
-func RunEmailScrapeOnce(db *sql.DB, cfg config.Config, onNewJob func()) (added int, err error) {
+// defaultMailboxQueries builds the single-mailbox, unseen-only sweep
+// RunEmailScrapeOnce used before cfg.Email.Mailboxes existed, so
+// configs that only set Mailbox/SearchSubjectAny keep working.
+func defaultMailboxQueries(cfg config.Config) []config.EmailMailbox {
+	mailbox := cfg.Email.Mailbox
+	if mailbox == "" {
+		mailbox = "INBOX"
+	}
+	return []config.EmailMailbox{{
+		Name:       mailbox,
+		Unseen:     true,
+		SubjectAny: cfg.Email.SearchSubjectAny,
+	}}
+}
+
+// toSearchCriteria compiles a configured mailbox query into the
+// SearchCriteria Fetch expects, applying SinceDays and the
+// with/without flag lists on top of the Unseen shorthand.
+func toSearchCriteria(mq config.EmailMailbox, maxEmails int) SearchCriteria {
+	crit := SearchCriteria{
+		From:         mq.From,
+		To:           mq.To,
+		SubjectAny:   mq.SubjectAny,
+		SubjectAll:   mq.SubjectAll,
+		BodyContains: mq.BodyContains,
+		Mailboxes:    []string{mq.Name},
+	}
+
+	if mq.SinceDays > 0 {
+		crit.Since = time.Now().AddDate(0, 0, -mq.SinceDays)
+	}
+	if mq.Unseen {
+		crit.WithoutFlags = append(crit.WithoutFlags, imap.FlagSeen)
+	}
+	for _, f := range mq.WithFlags {
+		crit.WithFlags = append(crit.WithFlags, imap.Flag(f))
+	}
+	for _, f := range mq.WithoutFlags {
+		crit.WithoutFlags = append(crit.WithoutFlags, imap.Flag(f))
+	}
+
+	crit.MaxPerMailbox = mq.MaxMessages
+	if crit.MaxPerMailbox <= 0 {
+		crit.MaxPerMailbox = maxEmails
+	}
+	return crit
+}
+
+// RunEmailScrapeOnce scans each mailbox configured in cfg.Email.Mailboxes
+// (falling back to a single unseen-only sweep of cfg.Email.Mailbox +
+// cfg.Email.SearchSubjectAny when Mailboxes is empty) against whichever
+// MailSource cfg.Email.Backend selects (imap, maildir or jmap), pushing
+// the subject/sender/body filters down into the backend's search where
+// it has one. It extracts job-ish URLs and inserts rows into jobs
+// (deduped by source_id), then marks processed messages handled so the
+// next run doesn't return them again. bus may be nil, in which case no
+// events are published.
+func RunEmailScrapeOnce(db *sql.DB, cfg config.Config, bus *events.Bus) (added int, err error) {
 	const (
 		maxEmails        = 2000
 		maxLinksPerEmail = 200
@@ -49,63 +113,101 @@ func RunEmailScrapeOnce(db *sql.DB, cfg config.Config, onNewJob func()) (added i
 	if !cfg.Email.Enabled {
 		return 0, nil
 	}
-	if cfg.Email.IMAPHost == "" || cfg.Email.Username == "" {
-		return 0, errors.New("email enabled but missing imap_host/username")
-	}
-	if cfg.Email.AppPassword == "" {
-		return 0, errors.New("missing email.app_password (gmail requires an app password with 2FA)")
+
+	rules, err := config.CompilePipeline(cfg.Scoring.Pipeline)
+	if err != nil {
+		return 0, fmt.Errorf("compile scoring.pipeline: %w", err)
 	}
 
-	addr := cfg.Email.IMAPHost
-	if cfg.Email.IMAPPort != 0 && !strings.Contains(addr, ":") {
-		addr = fmt.Sprintf("%s:%d", addr, cfg.Email.IMAPPort)
-	} else if !strings.Contains(addr, ":") {
-		addr += ":993"
+	src, err := SourceFor(cfg, db)
+	if err != nil {
+		return 0, err
 	}
 
-	mailbox := cfg.Email.Mailbox
-	if mailbox == "" {
-		mailbox = "INBOX"
+	mailboxQueries := cfg.Email.Mailboxes
+	if len(mailboxQueries) == 0 {
+		mailboxQueries = defaultMailboxQueries(cfg)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
 	defer cancel()
 
-	c, err := DialAndLoginIMAP(ctx, addr, cfg.Email.Username, cfg.Email.AppPassword, GmailTLSConfig())
-	if err != nil {
+	if err := src.Open(ctx); err != nil {
 		return 0, err
 	}
-	defer LogoutAndClose(c)
+	defer src.Close()
 
-	if _, err := c.Select(mailbox, &imap.SelectOptions{ReadOnly: false}).Wait(); err != nil {
-		return 0, fmt.Errorf("imap select %q: %w", mailbox, err)
+	for _, mq := range mailboxQueries {
+		mailboxAdded, mailboxErr := runMailboxScrapeOnce(ctx, db, src, cfg, mq, &scorer, rules, maxEmails, maxLinksPerEmail, maxAdds-added, bus)
+		added += mailboxAdded
+		if mailboxErr != nil {
+			return added, mailboxErr
+		}
+		if added >= maxAdds {
+			break
+		}
 	}
 
-	msgs, err := FetchUnseen(ctx, c, maxEmails)
+	return added, nil
+}
+
+// runMailboxScrapeOnce lists mq's SearchCriteria against src and
+// processes the resulting messages the same way regardless of which
+// mailbox or backend they came from. It publishes ScrapeRunCompleted on
+// bus once done, win or lose, so subscribers see every attempt.
+func runMailboxScrapeOnce(ctx context.Context, db *sql.DB, src MailSource, cfg config.Config, mq config.EmailMailbox, scorer *rank.YAMLScorer, rules []pipeline.Rule, maxEmails, maxLinksPerEmail, maxAdds int, bus *events.Bus) (added int, err error) {
+	start := time.Now()
+	defer func() {
+		metrics.ScrapeDurationSeconds.WithLabelValues("email", mq.Name).Observe(time.Since(start).Seconds())
+		publish(ctx, bus, events.ScrapeRunCompleted{Added: added, Duration: time.Since(start).Milliseconds(), Mailbox: mq.Name})
+	}()
+
+	msgs, err := src.List(ctx, toSearchCriteria(mq, maxEmails))
 	if err != nil {
-		return 0, err
+		metrics.ScrapeErrorsTotal.WithLabelValues("email", "list").Inc()
+		return 0, fmt.Errorf("list %q: %w", mq.Name, err)
 	}
 	if len(msgs) == 0 {
 		return 0, nil
 	}
 
-	processed := make([]imap.UID, 0, len(msgs))
+	processed := make([]string, 0, len(msgs))
+
+	// inserted tracks only the ids InsertJobIfNew actually accepted
+	// (skips duplicates and parse-only misses), since MoveProcessed
+	// below is meant to file away mail that produced a real lead, not
+	// every message MarkProcessed also marks \Seen.
+	var inserted []string
 
 runLoop:
 	for _, m := range msgs {
 
 		receivedAt := m.Date
-		msgID, bodyText, htmlBody, subj := parseRFC822(m.RawMessage, m.Subject)
+		msgID, bodyText, htmlBody, subj := parseRFC822(m.Raw, m.Subject)
 		subj = decodeRFC2047(subj)
 
-		// Require subject match when search_subject_any is set
-		if len(cfg.Email.SearchSubjectAny) > 0 && !containsAnyCI(subj, cfg.Email.SearchSubjectAny) {
-			processed = append(processed, m.UID)
+		_, pr := pipeline.Run(rules, pipeline.Input{
+			Subject:  subj,
+			From:     m.From,
+			Domain:   domainOfAddress(m.From),
+			BodyText: bodyText,
+			HTMLBody: htmlBody,
+		})
+		if pr.RouteTo != "" {
+			metrics.EmailMessagesProcessedTotal.WithLabelValues("true").Inc()
+		} else {
+			metrics.EmailMessagesProcessedTotal.WithLabelValues("false").Inc()
+		}
+		if pr.Dropped {
+			processed = append(processed, m.ID)
 			continue
 		}
 
-		// --- LinkedIn Job Alert special-case
-		if looksLikeLinkedInJobAlert(subj, bodyText) {
+		// --- route_to:linkedin - same LinkedIn job-alert parsing as
+		// before, now reached through scoring.pipeline (or
+		// pipeline.DefaultRules's equivalent built-in rule) instead of
+		// a hardcoded looksLikeLinkedInJobAlert check.
+		if pr.RouteTo == "linkedin" {
 
 			liJobs, perr := ParseLinkedInJobAlertHTML(htmlBody)
 			log.Printf("[email] LinkedIn parser: found %d jobs, err=%v", len(liJobs), perr)
@@ -139,7 +241,7 @@ runLoop:
 
 					score, tags := scorer.Score(lead)
 
-					j := jobRow{
+					j := JobRow{
 						Company:    lj.Company,
 						Title:      lj.Title,
 						Location:   lj.Location,
@@ -153,23 +255,30 @@ runLoop:
 						// LogoURL: lj.LogoURL,
 					}
 
-					ok, ierr := insertJobIfNew(ctx, db, j)
+					id, ok, ierr := InsertJobIfNew(ctx, db, j)
 					if ierr != nil {
 						continue
 					}
 					if ok {
 						added++
-						if onNewJob != nil {
-							onNewJob()
-						}
+						inserted = append(inserted, m.ID)
+						metrics.JobsIngestedTotal.WithLabelValues("email").Inc()
+						publish(ctx, bus, events.JobInserted{
+							ID:       id,
+							Company:  j.Company,
+							Title:    j.Title,
+							URL:      j.URL,
+							Score:    j.Score,
+							SourceID: j.SourceID,
+						})
 						if added >= maxAdds {
-							processed = append(processed, m.UID)
+							processed = append(processed, m.ID)
 							break runLoop
 						}
 					}
 				}
 
-				processed = append(processed, m.UID)
+				processed = append(processed, m.ID)
 				continue
 			}
 		}
@@ -177,7 +286,7 @@ runLoop:
 		// // Extract URLs + anchor contexts
 		// rawURLs, contexts := extractLinksFromBody(bodyText)
 		// if len(rawURLs) == 0 {
-		// 	processed = append(processed, m.UID)
+		// 	processed = append(processed, m.ID)
 		// 	continue
 		// }
 
@@ -200,7 +309,7 @@ runLoop:
 		// 	cands = append(cands, cu)
 		// }
 		// if len(cands) == 0 {
-		// 	processed = append(processed, m.UID)
+		// 	processed = append(processed, m.ID)
 		// 	continue
 		// }
 
@@ -262,7 +371,7 @@ runLoop:
 
 		// 	score, tags := scorer.Score(lead)
 
-		// 	j := jobRow{
+		// 	j := JobRow{
 		// 		Company:     company,
 		// 		Title:       title,
 		// 		Location:    location,
@@ -285,25 +394,52 @@ runLoop:
 		// 			onNewJob()
 		// 		}
 		// 		if added >= maxAdds {
-		// 			processed = append(processed, m.UID)
+		// 			processed = append(processed, m.ID)
 		// 			break runLoop
 		// 		}
 		// 	}
 		// }
 
-		processed = append(processed, m.UID)
+		processed = append(processed, m.ID)
 	}
 
 	if len(processed) > 0 {
-		if err := MarkSeen(c, processed); err != nil {
-			return added, fmt.Errorf("mark seen: %w", err)
+		if err := src.MarkProcessed(ctx, processed); err != nil {
+			metrics.ScrapeErrorsTotal.WithLabelValues("email", "mark_processed").Inc()
+			return added, fmt.Errorf("mark processed: %w", err)
+		}
+	}
+
+	// ProcessedFolder/DeleteAfterDays only apply to backends that
+	// implement postProcessor (the imap backend today) - maildir/jmap
+	// have no equivalent "move to another mailbox" concept, so this
+	// is a silent no-op for them rather than an error.
+	if pp, ok := src.(postProcessor); ok {
+		if cfg.Email.ProcessedFolder != "" && len(inserted) > 0 {
+			if err := pp.moveProcessed(ctx, inserted, cfg.Email.ProcessedFolder); err != nil {
+				metrics.ScrapeErrorsTotal.WithLabelValues("email", "move_processed").Inc()
+				log.Printf("[email] move processed to %q: %v", cfg.Email.ProcessedFolder, err)
+			}
+		}
+		if cfg.Email.DeleteAfterDays > 0 {
+			olderThan := time.Duration(cfg.Email.DeleteAfterDays) * 24 * time.Hour
+			sweepMailbox := mq.Name
+			if cfg.Email.ProcessedFolder != "" {
+				sweepMailbox = cfg.Email.ProcessedFolder
+			}
+			if err := pp.expungeOldSeen(ctx, sweepMailbox, olderThan, cfg.Email.TrashFolder); err != nil {
+				metrics.ScrapeErrorsTotal.WithLabelValues("email", "expunge_old_seen").Inc()
+				log.Printf("[email] expunge old seen in %q: %v", sweepMailbox, err)
+			}
 		}
 	}
 
 	return added, nil
 }
 
-func insertJobIfNew(ctx context.Context, db *sql.DB, j jobRow) (bool, error) {
+// InsertJobIfNew inserts j if its source_id is new, returning the
+// inserted row's ID and true, or (0, false) if it was a duplicate.
+func InsertJobIfNew(ctx context.Context, db *sql.DB, j JobRow) (id int64, inserted bool, err error) {
 	if j.Company == "" {
 		j.Company = "Unknown"
 	}
@@ -317,7 +453,7 @@ func insertJobIfNew(ctx context.Context, db *sql.DB, j jobRow) (bool, error) {
 		j.WorkMode = "unknown"
 	}
 	if j.URL == "" {
-		return false, errors.New("missing url")
+		return 0, false, errors.New("missing url")
 	}
 	if j.ReceivedAt.IsZero() {
 		j.ReceivedAt = time.Now().UTC()
@@ -342,28 +478,28 @@ VALUES(?,?,?,?,?,?,?,?,?);`,
 		j.SourceID,
 	)
 	if err != nil {
-		return false, err
+		return 0, false, err
 	}
 	n, _ := res.RowsAffected()
-	return n > 0, nil
+	if n == 0 {
+		return 0, false, nil
+	}
+	newID, _ := res.LastInsertId()
+	return newID, true, nil
 }
 
-// ---------------- Matching / heuristics ----------------
-
-func containsAnyCI(s string, any []string) bool {
-	ls := strings.ToLower(s)
-	for _, a := range any {
-		a = strings.TrimSpace(a)
-		if a == "" {
-			continue
-		}
-		if strings.Contains(ls, strings.ToLower(a)) {
-			return true
-		}
+// publish is a nil-safe convenience wrapper: RunEmailScrapeOnce's bus
+// parameter is optional, so every call site would otherwise need its
+// own nil check.
+func publish(ctx context.Context, bus *events.Bus, p events.Payload) {
+	if bus == nil {
+		return
 	}
-	return false
+	bus.Publish(ctx, p)
 }
 
+// ---------------- Matching / heuristics ----------------
+
 func normalizeSubjectTitle(subj string) string {
 	s := strings.TrimSpace(subj)
 	if s == "" {
@@ -443,7 +579,7 @@ func guessCompanyFromFrom(from string) string {
 // ---------------- Dedupe / URL canonicalization ----------------
 
 func makeSourceID(messageID, urlStr, subject, from string) string {
-	nurl := canonicalizeURL(urlStr)
+	nurl := util.CanonicalizeURL(urlStr)
 	if nurl == "" {
 		return ""
 	}
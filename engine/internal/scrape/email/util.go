@@ -13,6 +13,8 @@ import (
 	"net/mail"
 	"regexp"
 	"strings"
+
+	"jobhunt-engine/internal/scrape/util"
 )
 
 // ---------------- Link extraction ----------------
@@ -43,7 +45,7 @@ func extractLinksFromBody(body string) (urls []string, contexts map[string]strin
 				continue
 			}
 
-			cu := canonicalizeURL(href)
+			cu := util.CanonicalizeURL(href)
 			urls = append(urls, href)
 
 			// store best (longest) context text for this canonical URL
@@ -97,6 +99,28 @@ func parseRFC822(raw []byte, fallbackSubject string) (messageID, bodyText, htmlB
 	return messageID, bodyText, htmlBody, subject
 }
 
+// domainOfAddress pulls the domain out of a From header like
+// "Jane Doe <jane@acme.com>" or a bare "jane@acme.com", for matching
+// against a pipeline rule's When.Domain.
+func domainOfAddress(from string) string {
+	at := strings.LastIndex(from, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.Trim(from[at+1:], "> ")
+}
+
+// parseFromHeader reads just the From header out of a raw RFC822
+// message, for callers (the pipeline dry-run endpoint) that only have
+// the raw blob and not a MailSource Message's already-split From.
+func parseFromHeader(raw []byte) string {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(msg.Header.Get("From"))
+}
+
 func extractMIMETextParts(h mail.Header, body []byte) (plain, htmlPart string) {
 	ct := h.Get("Content-Type")
 	cte := strings.ToLower(strings.TrimSpace(h.Get("Content-Transfer-Encoding")))
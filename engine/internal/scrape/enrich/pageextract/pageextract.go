@@ -0,0 +1,324 @@
+// Package pageextract fetches a job posting's landing page and pulls
+// structured fields off it - schema.org/JobPosting JSON-LD first,
+// falling back to OpenGraph meta tags plus a generic text-extraction
+// walk when a career page doesn't embed JSON-LD. It's one step past
+// what ProcessLeads' logo enrichment already does: where that
+// resolves a favicon from the company's domain, this reads the
+// posting's own page for salary/location/employment-type/description
+// the original lead didn't carry.
+package pageextract
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+
+	"jobhunt-engine/internal/fetch"
+)
+
+// userAgent identifies this extractor to both robots.txt and the
+// career pages it fetches, the same way every other fetcher in this
+// repo sends a UA string instead of Go's default.
+const userAgent = "JobHunt/1.0 (+local; pageextract)"
+
+// Extracted is everything Extract manages to pull off one job
+// posting's page. A zero-value field means that strategy didn't find
+// it, not that Extract failed outright - see Extract's error return
+// for that.
+type Extracted struct {
+	Title           string
+	CompanyName     string
+	CompanyLogoURL  string
+	Location        string
+	BaseSalary      string
+	EmploymentType  string
+	DatePosted      string
+	Description     string // Plain text (see textExtract).
+	DescriptionHTML string // Full fetched page, for job_descriptions.
+}
+
+// Extractor fetches a posting URL through a rate-limited, robots.txt
+// aware HTTP client and extracts Extracted from the response.
+type Extractor struct {
+	fetcher *fetch.Fetcher
+	robots  *robotsCache
+}
+
+// New builds an Extractor with its own 1req/s-per-host limiter (see
+// internal/fetch) - separate from domainLimiter/webcrawl's own
+// limiters, since this hits whatever arbitrary host a lead's URL
+// points at, not a fixed panel of lookup providers.
+func New() *Extractor {
+	return &Extractor{
+		fetcher: fetch.New(fetch.WithFollowRedirects(true), fetch.WithUserAgents(userAgent)),
+		robots:  newRobotsCache(),
+	}
+}
+
+// Extract fetches rawURL and returns its structured fields. It
+// returns an error without fetching if robots.txt disallows rawURL.
+func (x *Extractor) Extract(ctx context.Context, rawURL string) (Extracted, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Extracted{}, fmt.Errorf("pageextract: parse %q: %w", rawURL, err)
+	}
+	if !x.robots.Allowed(ctx, u) {
+		return Extracted{}, fmt.Errorf("pageextract: %s disallowed by robots.txt", rawURL)
+	}
+
+	res, err := x.fetcher.Get(ctx, rawURL)
+	if err != nil {
+		return Extracted{}, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return Extracted{}, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return Extracted{}, err
+	}
+
+	e := extractPosting(doc)
+	e.DescriptionHTML = string(body)
+	return e, nil
+}
+
+// extractPosting tries a schema.org JSON-LD JobPosting block first
+// (what most ATS-hosted and many custom career pages embed for SEO),
+// falling back to OpenGraph meta tags plus a text-extraction pass
+// when no JSON-LD is present.
+func extractPosting(doc *goquery.Document) Extracted {
+	if e, ok := extractJSONLD(doc); ok {
+		if e.Description == "" {
+			e.Description = textExtract(doc.Find("body"))
+		}
+		return e
+	}
+	return extractFallback(doc)
+}
+
+// jsonLDJobPosting mirrors the schema.org JobPosting fields Extract
+// cares about. BaseSalary, EmploymentType, and HiringOrganization.Logo
+// are left as json.RawMessage since schema.org lets each be either a
+// bare string or a nested object - see baseSalaryString,
+// employmentTypeString, and logoURLString for how each shape is read.
+type jsonLDJobPosting struct {
+	Type               string `json:"@type"`
+	Title              string `json:"title"`
+	HiringOrganization struct {
+		Name string          `json:"name"`
+		Logo json.RawMessage `json:"logo"`
+	} `json:"hiringOrganization"`
+	JobLocation struct {
+		Address struct {
+			AddressLocality string `json:"addressLocality"`
+			AddressRegion   string `json:"addressRegion"`
+		} `json:"address"`
+	} `json:"jobLocation"`
+	BaseSalary     json.RawMessage `json:"baseSalary"`
+	EmploymentType json.RawMessage `json:"employmentType"`
+	DatePosted     string          `json:"datePosted"`
+	Description    string          `json:"description"`
+}
+
+func extractJSONLD(doc *goquery.Document) (Extracted, bool) {
+	var found Extracted
+	ok := false
+
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		var raw json.RawMessage
+		if err := json.Unmarshal([]byte(s.Text()), &raw); err != nil {
+			return true
+		}
+
+		for _, p := range candidateJobPostings(raw) {
+			if !strings.EqualFold(p.Type, "JobPosting") {
+				continue
+			}
+			found = Extracted{
+				Title:          cleanText(p.Title),
+				CompanyName:    cleanText(p.HiringOrganization.Name),
+				CompanyLogoURL: logoURLString(p.HiringOrganization.Logo),
+				Location:       cleanText(joinNonEmpty(p.JobLocation.Address.AddressLocality, p.JobLocation.Address.AddressRegion)),
+				BaseSalary:     baseSalaryString(p.BaseSalary),
+				EmploymentType: employmentTypeString(p.EmploymentType),
+				DatePosted:     cleanText(p.DatePosted),
+				Description:    cleanText(p.Description),
+			}
+			ok = true
+			return false
+		}
+		return true
+	})
+
+	return found, ok
+}
+
+// candidateJobPostings handles the three shapes a ld+json block
+// commonly takes: a single object, an array of objects, or a
+// "@graph"-wrapped array.
+func candidateJobPostings(raw json.RawMessage) []jsonLDJobPosting {
+	var one jsonLDJobPosting
+	if err := json.Unmarshal(raw, &one); err == nil && one.Type != "" {
+		return []jsonLDJobPosting{one}
+	}
+
+	var many []jsonLDJobPosting
+	if err := json.Unmarshal(raw, &many); err == nil {
+		return many
+	}
+
+	var graph struct {
+		Graph []jsonLDJobPosting `json:"@graph"`
+	}
+	if err := json.Unmarshal(raw, &graph); err == nil {
+		return graph.Graph
+	}
+
+	return nil
+}
+
+// extractFallback is used when a page has no JSON-LD JobPosting:
+// og:title/og:image cover the title and a logo-ish image, and
+// textExtract covers the description.
+func extractFallback(doc *goquery.Document) Extracted {
+	var e Extracted
+	if t, ok := doc.Find(`meta[property="og:title"]`).Attr("content"); ok {
+		e.Title = cleanText(t)
+	}
+	if img, ok := doc.Find(`meta[property="og:image"]`).Attr("content"); ok {
+		e.CompanyLogoURL = strings.TrimSpace(img)
+	}
+	e.Description = textExtract(doc.Find("body"))
+	return e
+}
+
+// textExtract walks sel's DOM subtree depth-first, collecting each
+// TextNode's trimmed content and substituting an <img>'s alt text for
+// the image itself, so a company/role name embedded only in a header
+// logo's alt attribute still survives into the plain-text description.
+// script/style/noscript subtrees are skipped outright.
+func textExtract(sel *goquery.Selection) string {
+	var parts []string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			if t := strings.TrimSpace(n.Data); t != "" {
+				parts = append(parts, t)
+			}
+			return
+		case html.ElementNode:
+			switch n.Data {
+			case "script", "style", "noscript":
+				return
+			case "img":
+				for _, a := range n.Attr {
+					if a.Key == "alt" {
+						if t := strings.TrimSpace(a.Val); t != "" {
+							parts = append(parts, t)
+						}
+					}
+				}
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	for _, n := range sel.Nodes {
+		walk(n)
+	}
+	return strings.Join(parts, " ")
+}
+
+func baseSalaryString(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return cleanText(s)
+	}
+
+	var amount struct {
+		Currency string `json:"currency"`
+		Value    struct {
+			MinValue float64 `json:"minValue"`
+			MaxValue float64 `json:"maxValue"`
+			Value    float64 `json:"value"`
+			UnitText string  `json:"unitText"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &amount); err != nil {
+		return ""
+	}
+
+	unit := strings.ToLower(amount.Value.UnitText)
+	switch {
+	case amount.Value.MinValue > 0 && amount.Value.MaxValue > 0:
+		return strings.TrimSpace(fmt.Sprintf("%s %.0f-%.0f/%s", amount.Currency, amount.Value.MinValue, amount.Value.MaxValue, unit))
+	case amount.Value.Value > 0:
+		return strings.TrimSpace(fmt.Sprintf("%s %.0f/%s", amount.Currency, amount.Value.Value, unit))
+	default:
+		return ""
+	}
+}
+
+func employmentTypeString(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return cleanText(s)
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return strings.Join(list, ", ")
+	}
+	return ""
+}
+
+func logoURLString(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return strings.TrimSpace(s)
+	}
+	var obj struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(raw, &obj); err == nil {
+		return strings.TrimSpace(obj.URL)
+	}
+	return ""
+}
+
+func cleanText(s string) string {
+	return strings.TrimSpace(strings.Join(strings.Fields(s), " "))
+}
+
+func joinNonEmpty(parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,112 @@
+package pageextract
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// robotsCache fetches and caches each host's robots.txt (flattened to
+// the Disallow prefixes that apply to userAgent or "*"), so Extract
+// only hits a host's robots.txt once per process lifetime instead of
+// once per posting URL. Mirrors webcrawl's own robotsCache - kept
+// separate since that one is unexported to its package and this
+// extractor fetches arbitrary posting URLs rather than crawled
+// career-page links.
+type robotsCache struct {
+	hc *http.Client
+
+	mu     sync.Mutex
+	byHost map[string][]string
+}
+
+func newRobotsCache() *robotsCache {
+	return &robotsCache{
+		hc:     &http.Client{Timeout: 10 * time.Second},
+		byHost: make(map[string][]string),
+	}
+}
+
+// Allowed reports whether u is fetchable per its host's robots.txt. A
+// robots.txt that's missing or fails to fetch is treated as "allow
+// everything", matching most crawlers' fail-open default.
+func (c *robotsCache) Allowed(ctx context.Context, u *url.URL) bool {
+	for _, prefix := range c.disallowFor(ctx, u) {
+		if prefix != "" && strings.HasPrefix(u.Path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *robotsCache) disallowFor(ctx context.Context, u *url.URL) []string {
+	c.mu.Lock()
+	if d, ok := c.byHost[u.Host]; ok {
+		c.mu.Unlock()
+		return d
+	}
+	c.mu.Unlock()
+
+	d := c.fetch(ctx, u)
+
+	c.mu.Lock()
+	c.byHost[u.Host] = d
+	c.mu.Unlock()
+	return d
+}
+
+func (c *robotsCache) fetch(ctx context.Context, u *url.URL) []string {
+	robotsURL := (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/robots.txt"}).String()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	res, err := c.hc.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 400 {
+		return nil
+	}
+	return parseDisallow(res.Body)
+}
+
+// parseDisallow reads a robots.txt body and returns the Disallow path
+// prefixes under any "User-agent: *" or "User-agent: JobHunt" group.
+func parseDisallow(r io.Reader) []string {
+	var disallow []string
+	relevant := false
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, val, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		val = strings.TrimSpace(val)
+
+		switch key {
+		case "user-agent":
+			relevant = val == "*" || strings.EqualFold(val, "jobhunt")
+		case "disallow":
+			if relevant && val != "" {
+				disallow = append(disallow, val)
+			}
+		}
+	}
+	return disallow
+}
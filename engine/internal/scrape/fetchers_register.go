@@ -0,0 +1,151 @@
+package scrape
+
+import (
+	"context"
+	"database/sql"
+
+	"jobhunt-engine/internal/config"
+	"jobhunt-engine/internal/domain"
+	email_scrape "jobhunt-engine/internal/scrape/email"
+	"jobhunt-engine/internal/scrape/greenhouse"
+	"jobhunt-engine/internal/scrape/lever"
+	"jobhunt-engine/internal/scrape/smartrecruiters"
+	"jobhunt-engine/internal/scrape/types"
+	"jobhunt-engine/internal/scrape/util"
+	"jobhunt-engine/internal/scrape/webcrawl"
+)
+
+// DB, if set via SetDB, lets registered fetcher factories (e.g.
+// smartrecruiters, for its resumable pagination checkpoints) reach the
+// store without threading *sql.DB through types.FetcherFactory itself.
+// Mirrors the package-level Dedupe/SetDedupeFilter pattern in
+// run_scrape_once.go.
+var DB *sql.DB
+
+// SetDB wires up the package-level DB handle. Call once at startup,
+// same lifecycle as SetDedupeFilter.
+func SetDB(db *sql.DB) {
+	DB = db
+}
+
+// Limiter, if set via SetLimiter, is handed to targets.Record after
+// each ATS fetch, which calls Penalize on a failure and Succeed on a
+// success under the target's "source/company" key. RunScrapeOnce does
+// not wait on Limiter before a fetch, and WaitURL looks backoff up by
+// URL host only, so the recorded backoff does not delay later runs of
+// a failing target. Nil skips the Penalize/Succeed calls.
+var Limiter *util.HostLimiter
+
+// SetLimiter wires up the package-level backoff limiter. Call once at
+// startup, same lifecycle as SetDB.
+func SetLimiter(l *util.HostLimiter) {
+	Limiter = l
+}
+
+// legacyJobLeadFetcher is satisfied by an ATS scraper whose Fetch
+// predates types.Fetcher and still returns a bare []domain.JobLead (the
+// same shape as the Source interface in source.go) instead of a
+// types.ScrapeResult.
+type legacyJobLeadFetcher interface {
+	Name() string
+	Fetch(ctx context.Context) ([]domain.JobLead, error)
+}
+
+// legacyFetcherAdapter wraps a legacyJobLeadFetcher so it satisfies
+// types.Fetcher, the shape poll.PollOnce/httpapi.ScrapeHandler expect.
+type legacyFetcherAdapter struct {
+	name string
+	s    legacyJobLeadFetcher
+}
+
+func (a legacyFetcherAdapter) Name() string { return a.name }
+
+func (a legacyFetcherAdapter) Fetch(ctx context.Context) (types.ScrapeResult, error) {
+	leads, err := a.s.Fetch(ctx)
+	return types.ScrapeResult{Source: a.name, Leads: leads}, err
+}
+
+// init registers the built-in ATS backends poll.PollOnce already knew
+// about by name, so EnabledFetchers can build them without the poller
+// hard-coding cfg.Sources.Greenhouse.Enabled / cfg.Sources.Lever.Enabled
+// / cfg.Sources.SmartRecruiters.Enabled checks itself. A new backend
+// (Workday, Ashby, iCIMS, Recruitee, JazzHR, ...) can follow this same
+// pattern from its own package instead of editing poll_once.go.
+func init() {
+	types.RegisterFetcher(types.FetcherSource{
+		Name:    "greenhouse",
+		Enabled: func(cfg any) bool { return cfg.(config.Config).Sources.Greenhouse.Enabled },
+		Config:  func(cfg any) any { return cfg.(config.Config).Sources.Greenhouse },
+		Schema:  func() any { return config.SourceConfig{} },
+		Factory: func(cfgAny any, limiter *util.HostLimiter) types.Fetcher {
+			sc, _ := cfgAny.(config.SourceConfig)
+			gh := greenhouse.New(greenhouse.Config{Companies: MapGreenhouseCompanies(sc.Companies)})
+			return legacyFetcherAdapter{name: "greenhouse", s: gh}
+		},
+	})
+
+	types.RegisterFetcher(types.FetcherSource{
+		Name:    "lever",
+		Enabled: func(cfg any) bool { return cfg.(config.Config).Sources.Lever.Enabled },
+		Config:  func(cfg any) any { return cfg.(config.Config).Sources.Lever },
+		Schema:  func() any { return config.SourceConfig{} },
+		Factory: func(cfgAny any, limiter *util.HostLimiter) types.Fetcher {
+			sc, _ := cfgAny.(config.SourceConfig)
+			return lever.New(lever.Config{Companies: MapLeverCompanies(sc.Companies)}, limiter)
+		},
+	})
+
+	types.RegisterFetcher(types.FetcherSource{
+		Name:    "smartrecruiters",
+		Enabled: func(cfg any) bool { return cfg.(config.Config).Sources.SmartRecruiters.Enabled },
+		Config:  func(cfg any) any { return cfg.(config.Config).Sources.SmartRecruiters },
+		Schema:  func() any { return config.SourceConfig{} },
+		Factory: func(cfgAny any, limiter *util.HostLimiter) types.Fetcher {
+			sc, _ := cfgAny.(config.SourceConfig)
+			companies := make([]smartrecruiters.Company, 0, len(sc.Companies))
+			for _, c := range sc.Companies {
+				companies = append(companies, smartrecruiters.Company{Slug: c.Slug, Name: c.Name})
+			}
+			sr := smartrecruiters.New(smartrecruiters.Config{Companies: companies}, limiter, DB)
+			return legacyFetcherAdapter{name: "smartrecruiters", s: sr}
+		},
+	})
+
+	types.RegisterFetcher(types.FetcherSource{
+		Name:    "webcrawl",
+		Enabled: func(cfg any) bool { return cfg.(config.Config).Sources.WebCrawl.Enabled },
+		Config:  func(cfg any) any { return cfg.(config.Config).Sources.WebCrawl },
+		Schema:  func() any { return config.WebCrawlSourceConfig{} },
+		Factory: func(cfgAny any, limiter *util.HostLimiter) types.Fetcher {
+			sc, _ := cfgAny.(config.WebCrawlSourceConfig)
+			seeds := make([]webcrawl.Seed, 0, len(sc.Seeds))
+			for _, s := range sc.Seeds {
+				seeds = append(seeds, webcrawl.Seed{Name: s.Name, URL: s.URL})
+			}
+			wc := webcrawl.New(webcrawl.Config{
+				Seeds:       seeds,
+				MaxDepth:    sc.MaxDepth,
+				Concurrency: sc.Concurrency,
+				PathAllow:   sc.PathAllow,
+			}, DB)
+			return legacyFetcherAdapter{name: "webcrawl", s: wc}
+		},
+	})
+
+	types.RegisterFetcher(types.FetcherSource{
+		Name: "email-idle",
+		Enabled: func(cfg any) bool {
+			c := cfg.(config.Config)
+			return c.Email.Enabled && c.Email.IdleEnabled
+		},
+		// No Schema: Email is an anonymous struct field on
+		// config.Config rather than its own named type like the other
+		// sources' SourceConfig/WebCrawlSourceConfig, so there's no
+		// config.XxxConfig{} to hand config.Validate here.
+		Config: func(cfg any) any { return cfg },
+		Factory: func(cfgAny any, limiter *util.HostLimiter) types.Fetcher {
+			cfg, _ := cfgAny.(config.Config)
+			return &email_scrape.IdleEmailFetcher{Cfg: cfg, DB: DB, Limiter: limiter}
+		},
+	})
+}
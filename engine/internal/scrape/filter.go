@@ -1,90 +1,166 @@
 package scrape
 
 import (
+	"regexp"
+	"sort"
 	"strings"
 
 	"jobhunt-engine/internal/config"
 	"jobhunt-engine/internal/domain"
 )
 
-func ShouldKeepJob(cfg config.Config, j domain.JobLead) (keep bool, reason string) {
-	// 1) Location filter (biggest filter)
-	if !passesLocation(cfg, j) {
-		return false, "location"
-	}
+// keywordRuleScale is how much less a KeywordRules match counts than a
+// TitleRules match at the same configured Weight - a keyword appearing
+// anywhere in the body is a weaker relevance signal than a title match.
+const keywordRuleScale = 0.5
 
-	// 2) Must match at least one title/keyword rule
-	if !matchesAnyRule(cfg, j) {
-		return false, "no_keyword_match"
-	}
+// remoteBoost is added once when a lead looks remote and
+// Filters.RemoteOK allows remote leads through.
+const remoteBoost = 5.0
 
-	return true, ""
+// hardBlockPenalty is the score ScoreJob returns for a lead that hits
+// Filters.LocationsBlock/CompaniesBlock - low enough that no realistic
+// Filters.MinScore lets it through, since those blocks are meant to be
+// a hard reject rather than a nudge.
+const hardBlockPenalty = -1_000_000.0
+
+// topReasons caps how many contributing reasons ScoreJob reports -
+// domain.JobLead.ScoreReasons is a short "why this ranked here" hint,
+// not a full audit trail.
+const topReasons = 3
+
+// scoreHit is one rule/penalty/boost that contributed to a ScoreJob
+// result, kept alongside its weight so the top contributors can be
+// picked out by magnitude once every hit is in.
+type scoreHit struct {
+	label  string
+	weight float64
 }
 
-func passesLocation(cfg config.Config, j domain.JobLead) bool {
-	text := strings.ToLower(strings.TrimSpace(j.LocationRaw))
+// ScoreJob computes j's weighted relevance score from cfg.Scoring's
+// rules and cfg.Filters: a matched TitleRules entry contributes its
+// full configured Weight, a matched KeywordRules entry contributes
+// keywordRuleScale of its Weight, a remote-looking lead gets
+// remoteBoost when Filters.RemoteOK, a matched Penalty subtracts its
+// Weight, and a Filters.LocationsBlock/CompaniesBlock hit hard-rejects
+// via hardBlockPenalty. reasons is the topReasons highest-magnitude
+// contributors, highest first.
+func ScoreJob(cfg config.Config, j domain.JobLead) (score float64, reasons []string) {
 	title := strings.ToLower(strings.TrimSpace(j.Title))
+	loc := strings.ToLower(strings.TrimSpace(j.LocationRaw))
 	desc := strings.ToLower(strings.TrimSpace(j.Description))
+	text := title + " " + desc
 
-	// treat any mention of "remote" as remote-ish
-	isRemote := strings.Contains(text, "remote") || strings.Contains(title, "remote") || strings.Contains(desc, "remote")
-
-	// Blocklist wins
 	for _, b := range cfg.Filters.LocationsBlock {
 		b = strings.ToLower(strings.TrimSpace(b))
 		if b == "" {
 			continue
 		}
-		if strings.Contains(text, b) || strings.Contains(title, b) || strings.Contains(desc, b) {
-			return false
+		if strings.Contains(loc, b) || strings.Contains(title, b) || strings.Contains(desc, b) {
+			return hardBlockPenalty, []string{"blocked_location:" + b}
+		}
+	}
+	for _, c := range cfg.Filters.CompaniesBlock {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if c == "" {
+			continue
+		}
+		if strings.Contains(strings.ToLower(strings.TrimSpace(j.CompanyName)), c) {
+			return hardBlockPenalty, []string{"blocked_company:" + c}
 		}
 	}
 
-	// Remote handling
+	var hits []scoreHit
+
+	isRemote := strings.Contains(loc, "remote") || strings.Contains(title, "remote") || strings.Contains(desc, "remote")
 	if isRemote && cfg.Filters.RemoteOK {
-		// still allowed (unless blocked above)
-		return true
+		hits = append(hits, scoreHit{"remote_ok", remoteBoost})
 	}
-	if isRemote && !cfg.Filters.RemoteOK {
-		return false
+
+	applyRules := func(rules []config.Rule, scale float64) {
+		for _, r := range rules {
+			if !ruleMatches(r, text) {
+				continue
+			}
+			hits = append(hits, scoreHit{r.Tag, r.Weight * scale})
+		}
 	}
+	applyRules(cfg.Scoring.TitleRules, 1.0)
+	applyRules(cfg.Scoring.KeywordRules, keywordRuleScale)
 
-	// Allowlist: if empty, allow everything (besides blocklist)
-	allow := cfg.Filters.LocationsAllow
-	if len(allow) == 0 {
-		return true
+	for _, p := range cfg.Scoring.Penalties {
+		for _, needle := range p.Any {
+			n := strings.ToLower(strings.TrimSpace(needle))
+			if n == "" {
+				continue
+			}
+			if strings.Contains(text, n) {
+				hits = append(hits, scoreHit{p.Reason, p.Weight})
+				break
+			}
+		}
 	}
 
-	// require at least one allow hit in location/title/desc
-	for _, a := range allow {
-		a = strings.ToLower(strings.TrimSpace(a))
-		if a == "" {
+	for _, h := range hits {
+		score += h.weight
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return absf(hits[i].weight) > absf(hits[j].weight)
+	})
+	for i := 0; i < len(hits) && i < topReasons; i++ {
+		reasons = append(reasons, hits[i].label)
+	}
+
+	return score, reasons
+}
+
+// ruleMatches reports whether r matches text via any of its Any
+// needles, its Phrase (an exact substring - Contains already requires
+// the phrase's tokens to appear adjacent and in order), or its Regex.
+// An invalid Regex is treated as a non-match, not an error.
+func ruleMatches(r config.Rule, text string) bool {
+	for _, needle := range r.Any {
+		n := strings.ToLower(strings.TrimSpace(needle))
+		if n == "" {
 			continue
 		}
-		if strings.Contains(text, a) || strings.Contains(title, a) || strings.Contains(desc, a) {
+		if strings.Contains(text, n) {
 			return true
 		}
 	}
+
+	if p := strings.ToLower(strings.TrimSpace(r.Phrase)); p != "" && strings.Contains(text, p) {
+		return true
+	}
+
+	if r.Regex != "" {
+		if re, err := regexp.Compile(r.Regex); err == nil && re.MatchString(text) {
+			return true
+		}
+	}
+
 	return false
 }
 
-func matchesAnyRule(cfg config.Config, j domain.JobLead) bool {
-	text := strings.ToLower(j.Title + " " + j.Description)
+func absf(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
 
-	hit := func(rules []config.Rule) bool {
-		for _, r := range rules {
-			for _, needle := range r.Any {
-				n := strings.ToLower(strings.TrimSpace(needle))
-				if n == "" {
-					continue
-				}
-				if strings.Contains(text, n) {
-					return true
-				}
-			}
+// ShouldKeepJob is a thin keep/reject wrapper over ScoreJob, kept for
+// callers that only want a boolean decision. ProcessLeads and
+// RunScrapeOnce call ScoreJob directly instead, so they can stash the
+// score and reasons on the lead before it's stored.
+func ShouldKeepJob(cfg config.Config, j domain.JobLead) (keep bool, reason string) {
+	score, reasons := ScoreJob(cfg, j)
+	if score < cfg.Filters.MinScore {
+		if len(reasons) > 0 {
+			return false, reasons[0]
 		}
-		return false
+		return false, "below_min_score"
 	}
-
-	return hit(cfg.Scoring.TitleRules) || hit(cfg.Scoring.KeywordRules)
+	return true, ""
 }
@@ -8,10 +8,15 @@ import (
 	"time"
 
 	"jobhunt-engine/internal/domain"
+	applog "jobhunt-engine/internal/log"
+	"jobhunt-engine/internal/metrics"
+	"jobhunt-engine/internal/scrape/dedupe"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
+const source = "greenhouse"
+
 type Config struct {
 	Companies []Company // list of boards
 }
@@ -22,8 +27,9 @@ type Company struct {
 }
 
 type Scraper struct {
-	cfg Config
-	hc  *http.Client
+	cfg    Config
+	hc     *http.Client
+	dedupe *dedupe.Filter
 }
 
 func New(cfg Config) *Scraper {
@@ -33,15 +39,29 @@ func New(cfg Config) *Scraper {
 	}
 }
 
+// SetDedupe attaches a Bloom filter used to skip hydrating job pages
+// we've almost certainly already ingested. Optional; nil means every
+// candidate job gets hydrated (the old behavior).
+func (s *Scraper) SetDedupe(f *dedupe.Filter) {
+	s.dedupe = f
+}
+
 func (s *Scraper) Name() string { return "greenhouse" }
 
+// Hydrate fills in title/location/description for a lead Fetch left
+// sparse (e.g. one the dedupe filter let through unhydrated). It
+// satisfies scrape.Source.
+func (s *Scraper) Hydrate(ctx context.Context, j *domain.JobLead) error {
+	return s.hydrateJob(ctx, j)
+}
+
 func (s *Scraper) Fetch(ctx context.Context) ([]domain.JobLead, error) {
 	var out []domain.JobLead
 	for _, co := range s.cfg.Companies {
 		jobs, err := s.fetchCompany(ctx, co)
 		if err != nil {
 			// don’t fail the whole run because one board is down
-			// log upstream; return partial results
+			applog.With("source", source, "company", co.Name).Warn("fetch company failed", "error", err)
 			continue
 		}
 		out = append(out, jobs...)
@@ -49,7 +69,19 @@ func (s *Scraper) Fetch(ctx context.Context) ([]domain.JobLead, error) {
 	return out, nil
 }
 
-func (s *Scraper) fetchCompany(ctx context.Context, co Company) ([]domain.JobLead, error) {
+func (s *Scraper) fetchCompany(ctx context.Context, co Company) (leads []domain.JobLead, err error) {
+	start := time.Now()
+	defer func() {
+		metrics.ScrapeDurationSeconds.WithLabelValues(source, "").Observe(time.Since(start).Seconds())
+		if err != nil {
+			metrics.ScrapeErrorsTotal.WithLabelValues(source, errorKind(err)).Inc()
+			metrics.ScrapeRunsTotal.WithLabelValues(source, "error").Inc()
+			return
+		}
+		metrics.ScrapeRunsTotal.WithLabelValues(source, "success").Inc()
+		metrics.ScrapeLastSuccessTimestamp.WithLabelValues(source).SetToCurrentTime()
+	}()
+
 	boardURL := fmt.Sprintf("https://boards.greenhouse.io/%s", co.Slug)
 
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, boardURL, nil)
@@ -118,9 +150,19 @@ func (s *Scraper) fetchCompany(ctx context.Context, co Company) ([]domain.JobLea
 		})
 	})
 
-	// Hydrate details (title/location/desc/date) by fetching each job page
+	// Hydrate details (title/location/desc/date) by fetching each job page.
+	// Skip the GET entirely for jobs the dedupe filter says we've already
+	// ingested; SQLite's unique index on source_id remains the final say.
 	for i := range jobs {
-		_ = s.hydrateJob(ctx, &jobs[i])
+		if s.dedupe != nil && s.dedupe.Seen(jobs[i].ATSJobID) {
+			continue
+		}
+		hydrateStart := time.Now()
+		herr := s.hydrateJob(ctx, &jobs[i])
+		metrics.HydrateDurationSeconds.WithLabelValues(source).Observe(time.Since(hydrateStart).Seconds())
+		if herr != nil {
+			metrics.ScrapeErrorsTotal.WithLabelValues(source, "hydrate").Inc()
+		}
 		// ignore hydrate errors; keep minimal entry
 	}
 
@@ -216,3 +258,17 @@ func guessLocation(doc *goquery.Document) string {
 	// low-effort fallback; refine later
 	return ""
 }
+
+// errorKind buckets a fetchCompany error into a low-cardinality label
+// for jobhunt_scrape_errors_total.
+func errorKind(err error) string {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "status"):
+		return "http_status"
+	case strings.Contains(msg, "parse"):
+		return "parse"
+	default:
+		return "network"
+	}
+}
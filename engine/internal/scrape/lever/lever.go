@@ -4,19 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
 	"sync"
 	"time"
 
 	"jobhunt-engine/internal/domain"
+	applog "jobhunt-engine/internal/log"
+	"jobhunt-engine/internal/metrics"
 	"jobhunt-engine/internal/scrape/types"
 	"jobhunt-engine/internal/scrape/util"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
+const source = "lever"
+
 type Config struct {
 	Companies []Company
 }
@@ -42,6 +45,13 @@ func New(cfg Config, limiter *util.HostLimiter) *Scraper {
 
 func (s *Scraper) Name() string { return "lever" }
 
+// Hydrate fills in title/location/work-mode for a lead Fetch left
+// sparse. Scraper is registered as a types.Fetcher (its Fetch returns
+// a types.ScrapeResult), not as a scrape.Source.
+func (s *Scraper) Hydrate(ctx context.Context, j *domain.JobLead) error {
+	return s.hydrateJob(ctx, j)
+}
+
 type leverPosting struct {
 	ID         string `json:"id"`
 	Text       string `json:"text"` // title
@@ -73,7 +83,7 @@ func (s *Scraper) Fetch(ctx context.Context) (types.ScrapeResult, error) {
 				cancel()
 
 				if err != nil {
-					log.Printf("[ats:lever] company=%q slug=%q err=%v", co.Name, co.Slug, err)
+					applog.With("source", "lever", "company", co.Name, "slug", co.Slug).Warn("fetch company failed", "error", err)
 					continue
 				}
 				if len(jobs) > 0 {
@@ -102,14 +112,26 @@ func (s *Scraper) Fetch(ctx context.Context) (types.ScrapeResult, error) {
 		out = append(out, batch...)
 	}
 
-	log.Printf("[lever] Processed: %d", len(out))
+	applog.With("source", "lever").Info("scrape finished", "processed", len(out))
 	return types.ScrapeResult{
 		Source: "lever",
 		Leads:  out,
 	}, nil
 }
 
-func (s *Scraper) fetchCompany(ctx context.Context, co Company) ([]domain.JobLead, error) {
+func (s *Scraper) fetchCompany(ctx context.Context, co Company) (leads []domain.JobLead, err error) {
+	start := time.Now()
+	defer func() {
+		metrics.ScrapeDurationSeconds.WithLabelValues(source, "").Observe(time.Since(start).Seconds())
+		if err != nil {
+			metrics.ScrapeErrorsTotal.WithLabelValues(source, errorKind(err)).Inc()
+			metrics.ScrapeRunsTotal.WithLabelValues(source, "error").Inc()
+			return
+		}
+		metrics.ScrapeRunsTotal.WithLabelValues(source, "success").Inc()
+		metrics.ScrapeLastSuccessTimestamp.WithLabelValues(source).SetToCurrentTime()
+	}()
+
 	apiURL := fmt.Sprintf("https://api.lever.co/v0/postings/%s?mode=json", co.Slug)
 
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
@@ -225,3 +247,17 @@ func (s *Scraper) hydrateJob(ctx context.Context, j *domain.JobLead) error {
 
 	return nil
 }
+
+// errorKind buckets a fetchCompany error into a low-cardinality label
+// for jobhunt_scrape_errors_total.
+func errorKind(err error) string {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "status"):
+		return "http_status"
+	case strings.Contains(msg, "decode"):
+		return "parse"
+	default:
+		return "network"
+	}
+}
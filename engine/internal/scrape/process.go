@@ -5,9 +5,12 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"jobhunt-engine/internal/compare"
 	"jobhunt-engine/internal/config"
 	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/metrics"
 	"jobhunt-engine/internal/rank"
+	"jobhunt-engine/internal/scrape/enrich/pageextract"
 	"jobhunt-engine/internal/scrape/greenhouse"
 	"jobhunt-engine/internal/scrape/lever"
 	"jobhunt-engine/internal/scrape/types"
@@ -15,20 +18,75 @@ import (
 	"jobhunt-engine/internal/store"
 	"log"
 	"net/url"
+	"sort"
 	"strings"
 	"time"
 )
 
+// domainLimiter rate-limits the domainResolvers providers ProcessLeads
+// consults for logo enrichment; they're per-process since leads from
+// different polls still hit the same search/infobox hosts.
+var domainLimiter = util.NewHostLimiter(1.0, 2)
+
+// pageExtractor reads each newly inserted job's own URL for fields
+// the seed lead didn't carry (salary, employment type, a full
+// description) - see pageextract.New for its rate-limit/robots.txt
+// policy.
+var pageExtractor = pageextract.New()
+
+// pageExtractTimeout bounds one job's page fetch so a slow or
+// unresponsive career page can't stall the rest of the batch.
+const pageExtractTimeout = 15 * time.Second
+
+// Cache TTLs for the store.KVCache-backed lookups below. Positive
+// results get a long TTL since a company's domain/favicon rarely
+// changes; negative results get a short one so a transient provider
+// outage or dead favicon endpoint doesn't poison the cache for a
+// month - this mirrors the old in-memory maps' "cache empty to avoid
+// retry storms" comment, just persisted across restarts and bounded
+// with an expiry instead of living only for one poll.
+const (
+	domainCacheTTL         = 30 * 24 * time.Hour
+	domainCacheNegativeTTL = 24 * time.Hour
+	logoCacheTTL           = 7 * 24 * time.Hour
+	logoCacheNegativeTTL   = 24 * time.Hour
+)
+
+// leadOrder ranks leads highest ScoreJob score first, then most
+// recently posted, then company name - so a batch works through its
+// most relevant/newest leads first instead of whatever order the
+// source returned them in.
+var leadOrder = compare.By(
+	func(a, b domain.JobLead) int { return compare.Float(b.Score, a.Score) },
+	func(a, b domain.JobLead) int { return compare.Int(postedAtUnix(b), postedAtUnix(a)) },
+	func(a, b domain.JobLead) int { return compare.String(a.CompanyName, b.CompanyName) },
+)
+
+func postedAtUnix(j domain.JobLead) int {
+	if j.PostedAt == nil {
+		return 0
+	}
+	return int(j.PostedAt.Unix())
+}
+
 func ProcessLeads(ctx context.Context, db *sql.DB, cfg config.Config, leads []domain.JobLead, onNewJob func()) (added int) {
 	scorer := rank.YAMLScorer{Cfg: cfg}
 
-	// Run-local caches (reset every poll)
-	domainCache := make(map[string]string) // company -> domain
-	logoCache := make(map[string]string)   // domain -> logo_key
+	for i := range leads {
+		score, reasons := ScoreJob(cfg, leads[i])
+		leads[i].Score = score
+		leads[i].ScoreReasons = reasons
+	}
+	sort.SliceStable(leads, func(i, j int) bool {
+		return leadOrder(leads[i], leads[j]) < 0
+	})
 
 	for _, lead := range leads {
-		keep, why := ShouldKeepJob(cfg, lead)
-		if !keep {
+		if lead.Score < cfg.Filters.MinScore {
+			why := "below_min_score"
+			if len(lead.ScoreReasons) > 0 {
+				why = lead.ScoreReasons[0]
+			}
 			log.Printf("[%s] skipped (%s) title=%q loc=%q url=%q",
 				lead.FirstSeenSource, why, lead.Title, lead.LocationRaw, lead.URL)
 			continue
@@ -49,29 +107,68 @@ func ProcessLeads(ctx context.Context, db *sql.DB, cfg config.Config, leads []do
 
 		// --- Logo enrichment (only for newly inserted jobs)
 
-		// 1) Domain lookup (cached by company name)
-		dom, ok := domainCache[j.Company]
-		if !ok {
-			found, derr := FindCompanyDomainDDG(ctx, j.Company)
+		// 1) Domain lookup (persistently cached by company name, see
+		// domainCacheTTL/domainCacheNegativeTTL above - GetOrFindCompanyDomain
+		// already persists positive results forever in company_domains,
+		// but has nowhere to remember "we tried and found nothing", so
+		// every restart re-ran the full resolver panel for those
+		// companies. KVGet/KVSet close that gap without touching
+		// GetOrFindCompanyDomain itself.
+		dom, hit, cerr := store.KVGet(ctx, db, "domain", j.Company)
+		if cerr != nil {
+			log.Printf("[logo] domain cache lookup err company=%q err=%v", j.Company, cerr)
+		}
+		if !hit {
+			found, derr := GetOrFindCompanyDomain(ctx, db, domainLimiter, j.Company)
 			if derr != nil {
 				log.Printf("[logo] domain lookup err company=%q err=%v", j.Company, derr)
 			}
 			dom = found
-			domainCache[j.Company] = dom // cache even if empty
+
+			ttl := domainCacheTTL
+			if dom == "" {
+				ttl = domainCacheNegativeTTL
+			}
+			if err := store.KVSet(ctx, db, "domain", j.Company, dom, ttl); err != nil {
+				log.Printf("[logo] domain cache write err company=%q err=%v", j.Company, err)
+			}
+
+			// Once we know the domain, see if it points at a known ATS
+			// board so onboarding doesn't require hand-editing config.
+			if dom != "" {
+				discovered, derr := DiscoverATS(ctx, dom)
+				if derr != nil {
+					log.Printf("[ats-discover] crawl err company=%q domain=%q err=%v", j.Company, dom, derr)
+				} else if len(discovered) > 0 {
+					if derr := store.InsertDiscoveredATS(ctx, db, j.Company, discovered); derr != nil {
+						log.Printf("[ats-discover] store err company=%q err=%v", j.Company, derr)
+					}
+				}
+			}
 		}
 
-		// 2) Favicon lookup (cached by domain)
+		// 2) Favicon lookup (persistently cached by domain)
 		if dom != "" {
 			log.Printf("[logo] no domain company=%q", j.Company)
-			key, ok := logoCache[dom]
-			if !ok {
+			key, hit, lerr := store.KVGet(ctx, db, "logo", dom)
+			if lerr != nil {
+				log.Printf("[logo] logo cache lookup err domain=%q err=%v", dom, lerr)
+			}
+			if !hit {
 				faviconURL := "https://www.google.com/s2/favicons?domain=" +
 					url.QueryEscape(dom) + "&sz=64"
 
-				if k, _ := store.CacheLogoFromURL(ctx, db, faviconURL); k != "" {
+				if k, _ := store.CacheLogoFromURL(ctx, db, cfg.App.DataDir, faviconURL); k != "" {
 					key = k
 				}
-				logoCache[dom] = key // cache empty to avoid retry storms
+
+				ttl := logoCacheTTL
+				if key == "" {
+					ttl = logoCacheNegativeTTL
+				}
+				if err := store.KVSet(ctx, db, "logo", dom, key, ttl); err != nil {
+					log.Printf("[logo] logo cache write err domain=%q err=%v", dom, err)
+				}
 			}
 
 			if key != "" {
@@ -86,6 +183,27 @@ WHERE source_id = ?
 			}
 		}
 
+		// 3) Page extraction (JSON-LD/OpenGraph/text-fallback; see
+		// pageextract) - best-effort, same as the logo lookups above:
+		// a failed fetch just means this job keeps whatever fields the
+		// seed lead already gave it.
+		func() {
+			pctx, cancel := context.WithTimeout(ctx, pageExtractTimeout)
+			defer cancel()
+
+			pe, perr := pageExtractor.Extract(pctx, j.URL)
+			if perr != nil {
+				log.Printf("[pageextract] fetch err source_id=%q url=%q err=%v", j.SourceID, j.URL, perr)
+				return
+			}
+			if err := store.UpdateJobEnrichment(ctx, db, j.SourceID, pe.Location, pe.BaseSalary, pe.EmploymentType, pe.DatePosted); err != nil {
+				log.Printf("[pageextract] update err source_id=%q err=%v", j.SourceID, err)
+			}
+			if err := store.UpsertJobDescription(ctx, db, j.SourceID, pe.DescriptionHTML, pe.Description); err != nil {
+				log.Printf("[pageextract] description store err source_id=%q err=%v", j.SourceID, err)
+			}
+		}()
+
 		added++
 		if onNewJob != nil {
 			onNewJob()
@@ -115,7 +233,9 @@ func InsertJobIfNew(ctx context.Context, db *sql.DB, j types.JobRow) (bool, erro
 		j.ReceivedAt = time.Now().UTC()
 	}
 	if j.SourceID == "" {
-		j.SourceID = util.ComputeSourceID(j)
+		// Match jobRowFromLead's own fallback so a row inserted with no
+		// SourceID can still be found again by URL.
+		j.SourceID = util.HashString("url:" + strings.TrimSpace(j.URL))
 	} else {
 		j.SourceID = strings.TrimSpace(j.SourceID)
 	}
@@ -141,15 +261,18 @@ VALUES(?,?,?,?,?,?,?,?,?,?,?);`,
 		return false, err
 	}
 	n, _ := res.RowsAffected()
-	if n == 0 && j.CompanyLogoURL != "" {
-		// job already existed; backfill logo_key if missing
-		_, _ = db.ExecContext(ctx, `
+	if n == 0 {
+		metrics.JobsDuplicateTotal.WithLabelValues(j.SeenFromSource).Inc()
+		if j.CompanyLogoURL != "" {
+			// job already existed; backfill logo_key if missing
+			_, _ = db.ExecContext(ctx, `
 UPDATE jobs
 SET logo_key = ?
 WHERE source_id = ?
   AND (logo_key = '' OR logo_key IS NULL);`,
-			j.CompanyLogoURL, j.SourceID,
-		)
+				j.CompanyLogoURL, j.SourceID,
+			)
+		}
 	}
 
 	//log.Println("New job added to DB")
@@ -181,7 +304,10 @@ func jobRowFromLead(lead domain.JobLead, s rank.YAMLScorer) types.JobRow {
 		ReceivedAt:     recv,
 		SourceID:       sourceID,
 		SeenFromSource: strings.TrimSpace(lead.FirstSeenSource),
-		CompanyLogoURL: strings.TrimSpace(lead.CompanyLogoURL),
+		// CompanyLogoURL is left blank here: domain.JobLead carries no
+		// logo field from the source itself - ProcessLeads' own favicon
+		// lookup below fills logo_key in with a direct UPDATE once a
+		// domain is resolved for the newly inserted row.
 	}
 }
 
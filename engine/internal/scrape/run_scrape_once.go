@@ -0,0 +1,286 @@
+package scrape
+
+import (
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"jobhunt-engine/internal/config"
+	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/events"
+	applog "jobhunt-engine/internal/log"
+	"jobhunt-engine/internal/metrics"
+	"jobhunt-engine/internal/scrape/dedupe"
+	email_scrape "jobhunt-engine/internal/scrape/email"
+	"jobhunt-engine/internal/scrape/targets"
+)
+
+const emailSource = "email"
+
+// Dedupe, if set via SetDedupeFilter, lets the ATS scrapers skip
+// redundant work for leads we've almost certainly already ingested.
+// Nil means dedupe is disabled (SQLite's unique index on source_id is
+// always the final word either way).
+var Dedupe *dedupe.Filter
+
+// SetDedupeFilter wires up the package-level dedupe filter. Call once
+// at startup after populating it from the jobs table.
+func SetDedupeFilter(f *dedupe.Filter) {
+	Dedupe = f
+}
+
+// RunScrapeOnce is the entrypoint your main.go calls. It runs the email
+// inbox scan and every registered ATS Source (see Register/Sources),
+// inserting new jobs into SQLite (deduped by source_id) and invoking
+// onNewJob with the originating source name for each one added.
+func RunScrapeOnce(db *sql.DB, cfg config.Config, onNewJob func(source string)) (added int, err error) {
+	emailAdded, emailErr := runEmailScrapeOnce(db, cfg, onNewJob)
+	added += emailAdded
+
+	atsAdded, atsErr := runATSSourcesOnce(db, cfg, onNewJob)
+	added += atsAdded
+
+	if emailErr != nil {
+		return added, emailErr
+	}
+	return added, atsErr
+}
+
+// atsSourceTimeout is each source's default deadline, armed fresh for
+// every Fetch via armDeadline - unlike the single shared
+// context.WithTimeout this replaced, a slow Greenhouse run no longer
+// eats into how long Lever gets on the same RunScrapeOnce tick, and
+// /scrape/{source}/deadline can move it while the fetch is in flight.
+const atsSourceTimeout = 5 * time.Minute
+
+// runATSSourcesOnce walks every Source registered via Register,
+// fetching, filtering, hydrating and inserting leads the same way
+// regardless of which board they came from.
+func runATSSourcesOnce(db *sql.DB, cfg config.Config, onNewJob func(source string)) (added int, err error) {
+	sources := Sources()
+	if len(sources) == 0 {
+		return 0, nil
+	}
+
+	for _, src := range sources {
+		addedHere, ferr := runOneATSSource(db, cfg, src, onNewJob)
+		added += addedHere
+		if ferr != nil {
+			err = ferr
+		}
+	}
+
+	return added, err
+}
+
+// runOneATSSource runs src's Fetch under its own armDeadline-backed
+// context (see internal/scrape/util.DeadlineTimer), then filters,
+// hydrates and inserts whatever leads it returned.
+func runOneATSSource(db *sql.DB, cfg config.Config, src Source, onNewJob func(source string)) (added int, err error) {
+	name := src.Name()
+	sourceLog := applog.With("source", name)
+	start := time.Now()
+
+	dt := armDeadline(name, atsSourceTimeout)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-dt.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	leads, ferr := src.Fetch(ctx)
+	duration := time.Since(start)
+	metrics.ScrapeDurationSeconds.WithLabelValues(name, "").Observe(duration.Seconds())
+	if ferr != nil {
+		sourceLog.Warn("ats fetch failed", "error", ferr)
+		metrics.ScrapeErrorsTotal.WithLabelValues(name, errorKind(ferr)).Inc()
+		metrics.ScrapeRunsTotal.WithLabelValues(name, "error").Inc()
+		metrics.RecordScrapeRun(name, 0, 0, ferr)
+		// company is "" here - none of the built-in ATS Sources
+		// report per-company results from one Fetch call, so this
+		// is as granular as Target tracking gets for them today.
+		targets.Record(name, "", duration, 0, ferr, Limiter)
+		return 0, ferr
+	}
+	targets.Record(name, "", duration, len(leads), nil, Limiter)
+	metrics.ScrapeRunsTotal.WithLabelValues(name, "success").Inc()
+	metrics.ScrapeLastSuccessTimestamp.WithLabelValues(name).SetToCurrentTime()
+	metrics.JobsFetchedTotal.WithLabelValues(name).Add(float64(len(leads)))
+
+	for i := range leads {
+		lead := &leads[i]
+
+		if Dedupe != nil && Dedupe.Seen(lead.ATSJobID) {
+			continue
+		}
+		if lead.LocationRaw == "" || lead.WorkMode == "" || lead.WorkMode == "Unknown" {
+			_ = src.Hydrate(ctx, lead)
+		}
+
+		score, reasons := ScoreJob(cfg, *lead)
+		lead.Score = score
+		lead.ScoreReasons = reasons
+
+		if score < cfg.Filters.MinScore {
+			why := "below_min_score"
+			if len(reasons) > 0 {
+				why = reasons[0]
+			}
+			sourceLog.Debug("ats lead skipped", "reason", why, "company", lead.CompanyName, "title", lead.Title, "url", lead.URL)
+			continue
+		}
+
+		row := jobRowFromDomainLead(*lead)
+		ok, ierr := insertJobIfNew(ctx, db, row)
+		if ierr != nil {
+			sourceLog.Warn("ats insert failed", "source_id", row.SourceID, "url", row.URL, "error", ierr)
+			continue
+		}
+		if ok {
+			if Dedupe != nil {
+				Dedupe.Add(lead.ATSJobID)
+			}
+			added++
+			metrics.JobsIngestedTotal.WithLabelValues(name).Inc()
+			if onNewJob != nil {
+				onNewJob(name)
+			}
+		}
+	}
+	sourceLog.Info("ats scrape finished", "fetched", len(leads), "added", added)
+	metrics.RecordScrapeRun(name, len(leads), added, nil)
+
+	return added, nil
+}
+
+// jobRowFromDomainLead adapts a Source lead into the jobRow shape
+// insertJobIfNew (below) expects.
+func jobRowFromDomainLead(j domain.JobLead) jobRow {
+	location := j.LocationRaw
+	if location == "" {
+		location = "unknown"
+	}
+	workMode := j.WorkMode
+	if workMode == "" {
+		workMode = "unknown"
+	}
+	return jobRow{
+		Company:   j.CompanyName,
+		Title:     j.Title,
+		Location:  location,
+		WorkMode:  workMode,
+		URL:       j.URL,
+		Score:     int(math.Round(j.Score)),
+		Tags:      []string{},
+		FirstSeen: time.Now().UTC(),
+		SourceID:  j.ATSJobID,
+	}
+}
+
+// runEmailScrapeOnce hands the inbox scan off to
+// email_scrape.RunEmailScrapeOnce (the mailbox-polling
+// SearchCriteria/MailSource pipeline in internal/scrape/email), which
+// has superseded the plain-IMAP scanner this package used to run
+// inline. A throwaway events.Bus translates its JobInserted publishes
+// into onNewJob(source) so RunScrapeOnce's signature can stay as every
+// existing caller already expects.
+func runEmailScrapeOnce(db *sql.DB, cfg config.Config, onNewJob func(source string)) (added int, err error) {
+	bus := events.NewBus(nil)
+	if onNewJob != nil {
+		bus.Subscribe(events.KindJobInserted, func(ctx context.Context, p events.Payload) {
+			onNewJob(emailSource)
+		})
+	}
+	return email_scrape.RunEmailScrapeOnce(db, cfg, bus)
+}
+
+type jobRow struct {
+	Company   string
+	Title     string
+	Location  string
+	WorkMode  string
+	URL       string
+	Score     int
+	Tags      []string
+	FirstSeen time.Time
+	SourceID  string
+}
+
+// insertJobIfNew inserts a job if its source_id is new. Returns true if inserted.
+func insertJobIfNew(ctx context.Context, db *sql.DB, j jobRow) (bool, error) {
+
+	if j.Company == "" {
+		j.Company = "Unknown"
+	}
+	if j.Title == "" {
+		j.Title = "Job Posting"
+	}
+	if j.Location == "" {
+		j.Location = "unknown"
+	}
+	if j.WorkMode == "" {
+		j.WorkMode = "unknown"
+	}
+	if j.URL == "" {
+		return false, errors.New("missing url")
+	}
+	if j.FirstSeen.IsZero() {
+		j.FirstSeen = time.Now().UTC()
+	}
+	if j.SourceID == "" {
+		j.SourceID = hashString("url:" + j.URL)
+	}
+
+	tagsB, _ := json.Marshal(j.Tags)
+
+	res, err := db.ExecContext(ctx, `
+INSERT OR IGNORE INTO jobs(company, title, location, work_mode, url, score, tags, date, source_id)
+VALUES(?,?,?,?,?,?,?,?,?);`,
+		j.Company,
+		j.Title,
+		j.Location,
+		j.WorkMode,
+		j.URL,
+		j.Score,
+		string(tagsB),
+		j.FirstSeen.Format(time.RFC3339),
+		j.SourceID,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func hashString(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// errorKind buckets an error into a low-cardinality label for the
+// jobhunt_scrape_errors_total metric.
+func errorKind(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case strings.Contains(err.Error(), "imap"):
+		return "imap"
+	case strings.Contains(err.Error(), "config"), strings.Contains(err.Error(), "missing"):
+		return "config"
+	default:
+		return "other"
+	}
+}
@@ -2,6 +2,7 @@ package smartrecruiters
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -12,10 +13,13 @@ import (
 	"time"
 
 	"jobhunt-engine/internal/domain"
-	"jobhunt-engine/internal/scrape/types"
+	"jobhunt-engine/internal/metrics"
 	"jobhunt-engine/internal/scrape/util"
+	"jobhunt-engine/internal/store"
 )
 
+const source = "smartrecruiters"
+
 type Config struct {
 	Companies []Company
 }
@@ -31,13 +35,20 @@ type Scraper struct {
 	cfg     Config
 	hc      *http.Client
 	limiter *util.HostLimiter
+
+	// db persists per-company pagination checkpoints (see
+	// store.ATSCheckpoint) so fetchCompany resumes instead of rescanning
+	// every page on each poll. Nil disables checkpointing (falls back to
+	// always starting at offset 0).
+	db *sql.DB
 }
 
-func New(cfg Config, limiter *util.HostLimiter) *Scraper {
+func New(cfg Config, limiter *util.HostLimiter, db *sql.DB) *Scraper {
 	return &Scraper{
 		cfg:     cfg,
 		hc:      &http.Client{Timeout: 25 * time.Second},
 		limiter: limiter,
+		db:      db,
 	}
 }
 
@@ -67,7 +78,10 @@ type posting struct {
 	CustomField string `json:"customField"`
 }
 
-func (s *Scraper) Fetch(ctx context.Context) (types.ScrapeResult, error) {
+// Fetch lists every open posting for each configured company. It
+// satisfies scrape.Source; Hydrate is a no-op since postings already
+// come back fully populated.
+func (s *Scraper) Fetch(ctx context.Context) ([]domain.JobLead, error) {
 	const workers = 8
 
 	companies := s.cfg.Companies
@@ -115,10 +129,28 @@ func (s *Scraper) Fetch(ctx context.Context) (types.ScrapeResult, error) {
 	}
 
 	log.Printf("[smartrecruiters] Processed: %d", len(out))
-	return types.ScrapeResult{Source: "smartrecruiters", Leads: out}, nil
+	return out, nil
 }
 
-func (s *Scraper) fetchCompany(ctx context.Context, co Company) ([]domain.JobLead, error) {
+// Hydrate is a no-op: the postings API already returns a fully
+// populated posting in Fetch. It satisfies scrape.Source.
+func (s *Scraper) Hydrate(ctx context.Context, j *domain.JobLead) error {
+	return nil
+}
+
+func (s *Scraper) fetchCompany(ctx context.Context, co Company) (leads []domain.JobLead, err error) {
+	start := time.Now()
+	defer func() {
+		metrics.ScrapeDurationSeconds.WithLabelValues(source, "").Observe(time.Since(start).Seconds())
+		if err != nil {
+			metrics.ScrapeErrorsTotal.WithLabelValues(source, errorKind(err)).Inc()
+			metrics.ScrapeRunsTotal.WithLabelValues(source, "error").Inc()
+			return
+		}
+		metrics.ScrapeRunsTotal.WithLabelValues(source, "success").Inc()
+		metrics.ScrapeLastSuccessTimestamp.WithLabelValues(source).SetToCurrentTime()
+	}()
+
 	slug := strings.TrimSpace(co.Slug)
 	if slug == "" {
 		return nil, fmt.Errorf("empty slug")
@@ -128,8 +160,22 @@ func (s *Scraper) fetchCompany(ctx context.Context, co Company) ([]domain.JobLea
 	// Example: https://api.smartrecruiters.com/v1/companies/<slug>/postings?limit=100&offset=0
 	base := fmt.Sprintf("https://api.smartrecruiters.com/v1/companies/%s/postings", url.PathEscape(slug))
 
+	var checkpoint store.ATSCheckpoint
+	if s.db != nil {
+		if cp, cpErr := store.GetATSCheckpoint(ctx, s.db, source, slug); cpErr != nil {
+			log.Printf("[ats:smartrecruiters] slug=%q load checkpoint failed: %v", slug, cpErr)
+		} else {
+			checkpoint = cp
+		}
+	}
+	var lastReleasedDate time.Time
+	if checkpoint.LastReleasedDate != "" {
+		lastReleasedDate, _ = time.Parse(time.RFC3339, checkpoint.LastReleasedDate)
+	}
+	maxReleasedDate := lastReleasedDate
+
 	limit := 100
-	offset := 0
+	offset := checkpoint.Offset
 	var out []domain.JobLead
 
 	for {
@@ -168,12 +214,23 @@ func (s *Scraper) fetchCompany(ctx context.Context, co Company) ([]domain.JobLea
 			break
 		}
 
+		pageAllOverlap := true
 		for _, p := range pr.Content {
 			title := strings.TrimSpace(p.Name)
 			id := strings.TrimSpace(firstNonEmpty(p.ID, p.UUID, p.Ref))
 			if title == "" || id == "" {
 				continue
 			}
+
+			if !lastReleasedDate.IsZero() && !p.ReleasedDate.After(lastReleasedDate) {
+				// Already scraped in a prior run.
+				continue
+			}
+			pageAllOverlap = false
+			if p.ReleasedDate.After(maxReleasedDate) {
+				maxReleasedDate = p.ReleasedDate
+			}
+
 			jobURL := fmt.Sprintf("https://jobs.smartrecruiters.com/%s/%s", slug, id)
 
 			loc := strings.TrimSpace(strings.Join(nonEmpty(p.Location.City, p.Location.Region, p.Location.Country), ", "))
@@ -199,6 +256,12 @@ func (s *Scraper) fetchCompany(ctx context.Context, co Company) ([]domain.JobLea
 		}
 
 		offset += limit
+
+		// Short-circuit once a full page overlaps jobs a prior run
+		// already saw, instead of always walking to the hard cap below.
+		if !lastReleasedDate.IsZero() && pageAllOverlap {
+			break
+		}
 		if pr.TotalFound > 0 && offset >= pr.TotalFound {
 			break
 		}
@@ -207,6 +270,16 @@ func (s *Scraper) fetchCompany(ctx context.Context, co Company) ([]domain.JobLea
 		}
 	}
 
+	if s.db != nil {
+		cp := store.ATSCheckpoint{Offset: offset}
+		if !maxReleasedDate.IsZero() {
+			cp.LastReleasedDate = maxReleasedDate.UTC().Format(time.RFC3339)
+		}
+		if setErr := store.SetATSCheckpoint(ctx, s.db, source, slug, cp); setErr != nil {
+			log.Printf("[ats:smartrecruiters] slug=%q save checkpoint failed: %v", slug, setErr)
+		}
+	}
+
 	return out, nil
 }
 
@@ -229,3 +302,17 @@ func nonEmpty(vals ...string) []string {
 	}
 	return out
 }
+
+// errorKind buckets a fetchCompany error into a low-cardinality label
+// for jobhunt_scrape_errors_total.
+func errorKind(err error) string {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "status"):
+		return "http_status"
+	case strings.Contains(msg, "decode"):
+		return "parse"
+	default:
+		return "network"
+	}
+}
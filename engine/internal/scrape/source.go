@@ -0,0 +1,44 @@
+package scrape
+
+import (
+	"context"
+	"sync"
+
+	"jobhunt-engine/internal/domain"
+)
+
+// Source is the common interface every ATS board scraper implements
+// (greenhouse, lever, ashby, ...). Fetch lists candidate leads for a
+// run; Hydrate fills in anything Fetch left blank (location, work
+// mode, description) for a single lead. Implementations may already
+// hydrate everything inside Fetch, in which case Hydrate is a no-op.
+type Source interface {
+	Name() string
+	Fetch(ctx context.Context) ([]domain.JobLead, error)
+	Hydrate(ctx context.Context, j *domain.JobLead) error
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Source{}
+)
+
+// Register adds (or replaces) a Source in the package-level ATS
+// registry. RunScrapeOnce walks every registered source on each call.
+// Call once at startup, after building each Source from config.
+func Register(s Source) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[s.Name()] = s
+}
+
+// Sources returns the currently registered ATS sources.
+func Sources() []Source {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	out := make([]Source, 0, len(registry))
+	for _, s := range registry {
+		out = append(out, s)
+	}
+	return out
+}
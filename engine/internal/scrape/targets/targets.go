@@ -0,0 +1,130 @@
+// Package targets tracks the health of every individual scrape
+// target - an ATS source or an email account - the way Prometheus's
+// own retrieval/target package tracks scrape targets, so "is
+// greenhouse actually reachable right now" doesn't require grepping
+// logs for the last error.
+package targets
+
+import (
+	"sync"
+	"time"
+
+	"jobhunt-engine/internal/metrics"
+	"jobhunt-engine/internal/scrape/util"
+)
+
+// Health summarizes a Target's most recent Fetch attempt.
+type Health string
+
+const (
+	HealthUnknown Health = "unknown"
+	HealthUp      Health = "up"
+	HealthDown    Health = "down"
+)
+
+// Target is one source/company pair's scrape health. Company is ""
+// for a source that doesn't report per-company results at its Fetch
+// boundary - every built-in ATS scraper today bundles all of its
+// configured companies into a single Fetch call, so Source/"" is as
+// granular as Record can currently get for them.
+type Target struct {
+	Source  string `json:"source"`
+	Company string `json:"company"`
+
+	LastScrape          time.Time     `json:"last_scrape"`
+	LastDuration        time.Duration `json:"last_duration_ns"`
+	LastError           string        `json:"last_error,omitempty"`
+	Health              Health        `json:"health"`
+	ConsecutiveFailures int           `json:"consecutive_failures"`
+}
+
+// Registry holds every Target seen so far, keyed by source/company.
+type Registry struct {
+	mu      sync.Mutex
+	targets map[string]*Target
+}
+
+func NewRegistry() *Registry {
+	return &Registry{targets: map[string]*Target{}}
+}
+
+func key(source, company string) string { return source + "/" + company }
+
+// Record updates source/company's Target after one Fetch attempt,
+// flipping Health and tracking ConsecutiveFailures, then mirrors the
+// same result into the jobhunt_target_up/
+// jobhunt_last_scrape_timestamp_seconds/jobhunt_scrape_leads_total
+// Prometheus collectors so /metrics and Snapshot's JSON never
+// disagree. If limiter is non-nil, a failure calls
+// limiter.Penalize(source/company) and a success calls
+// limiter.Succeed with the same key. limiter.WaitURL looks backoff up
+// by URL host, not by this key, so nothing waits on the recorded
+// backoff.
+func (r *Registry) Record(source, company string, duration time.Duration, leads int, err error, limiter *util.HostLimiter) Target {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := key(source, company)
+	t, ok := r.targets[k]
+	if !ok {
+		t = &Target{Source: source, Company: company}
+		r.targets[k] = t
+	}
+
+	t.LastScrape = time.Now()
+	t.LastDuration = duration
+
+	if err != nil {
+		t.LastError = err.Error()
+		t.ConsecutiveFailures++
+		t.Health = HealthDown
+		if limiter != nil {
+			limiter.Penalize(k)
+		}
+	} else {
+		t.LastError = ""
+		t.ConsecutiveFailures = 0
+		t.Health = HealthUp
+		if limiter != nil {
+			limiter.Succeed(k)
+		}
+	}
+
+	up := 0.0
+	if t.Health == HealthUp {
+		up = 1
+	}
+	metrics.TargetUp.WithLabelValues(source, company).Set(up)
+	metrics.TargetLastScrapeTimestamp.WithLabelValues(source, company).SetToCurrentTime()
+	if leads > 0 {
+		metrics.TargetLeadsTotal.WithLabelValues(source, company).Add(float64(leads))
+	}
+
+	return *t
+}
+
+// Snapshot returns every tracked Target, for httpapi's /targets.
+func (r *Registry) Snapshot() []Target {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Target, 0, len(r.targets))
+	for _, t := range r.targets {
+		out = append(out, *t)
+	}
+	return out
+}
+
+// Default is the process-wide registry RunScrapeOnce records into and
+// httpapi.TargetsHandler reads from - one shared instance with
+// package-level funcs forwarding to it, the same shape as
+// internal/metrics' sourceStatus map.
+var Default = NewRegistry()
+
+func Record(source, company string, duration time.Duration, leads int, err error, limiter *util.HostLimiter) Target {
+	return Default.Record(source, company, duration, leads, err, limiter)
+}
+
+func Snapshot() []Target {
+	return Default.Snapshot()
+}
@@ -0,0 +1,144 @@
+package types
+
+import (
+	"sort"
+	"sync"
+
+	"jobhunt-engine/internal/scrape/util"
+)
+
+// FetcherFactory builds a Fetcher from its own config section and the
+// shared per-host rate limiter. cfg and the Config/Schema functions
+// below all traffic in `any` rather than config.Config so this package
+// doesn't need to import internal/config (which in turn lets
+// internal/config import this package back for FetcherSource.Schema
+// discovery without a cycle).
+type FetcherFactory func(cfg any, limiter *util.HostLimiter) Fetcher
+
+// FetcherSource is one entry in the pluggable-ATS fetcher registry.
+// RegisterFetcher it once (see internal/scrape's init()) and
+// poll.PollOnce picks it up automatically via EnabledFetchers instead
+// of a hard-coded cfg.Sources.Greenhouse.Enabled || cfg.Sources.Lever.Enabled
+// chain, so a new backend (Workday, Ashby, iCIMS, Recruitee, JazzHR, ...)
+// only needs to register itself from its own package.
+type FetcherSource struct {
+	Name string
+
+	// Enabled reports whether this source's config section turns it
+	// on. Receives the full config.Config, boxed as `any`.
+	Enabled func(cfg any) bool
+
+	// Config extracts this source's own config section out of the
+	// full config.Config (boxed as `any`), passed to Factory below.
+	Config func(cfg any) any
+
+	Factory FetcherFactory
+
+	// Schema, if set, returns a zero-value instance of this source's
+	// YAML config section (e.g. config.SourceConfig{}) so
+	// config.Validate can type-check sources.<name> generically
+	// instead of hard-coding a block per backend.
+	Schema func() any
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]FetcherSource{}
+)
+
+// RegisterFetcher adds (or replaces) a FetcherSource in the
+// package-level pluggable-ATS registry.
+func RegisterFetcher(s FetcherSource) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[s.Name] = s
+}
+
+// RegisteredFetcherNames returns every registered source name, sorted,
+// regardless of whether it's currently enabled.
+func RegisteredFetcherNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FetcherSchema returns the registered source's declared config schema
+// (see FetcherSource.Schema), or nil if it didn't declare one.
+func FetcherSchema(name string) any {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	s, ok := registry[name]
+	if !ok || s.Schema == nil {
+		return nil
+	}
+	return s.Schema()
+}
+
+// FetcherConfig returns the registered source's own config section
+// extracted from cfg (see FetcherSource.Config), or nil if name isn't
+// registered or didn't declare a Config func.
+func FetcherConfig(name string, cfg any) any {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	s, ok := registry[name]
+	if !ok || s.Config == nil {
+		return nil
+	}
+	return s.Config(cfg)
+}
+
+// BuildFetcher constructs name's Fetcher from cfg, or (nil, false) if
+// name isn't registered or its Enabled(cfg) check fails. Unlike
+// EnabledFetchers this builds a single named source on demand, for
+// callers (e.g. internal/jobs' FetcherWorker) that re-resolve a
+// Fetcher fresh on every job run rather than holding one across a
+// whole poll cycle.
+func BuildFetcher(name string, cfg any, limiter *util.HostLimiter) (Fetcher, bool) {
+	registryMu.RLock()
+	s, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	if s.Enabled != nil && !s.Enabled(cfg) {
+		return nil, false
+	}
+	var cfgSection any
+	if s.Config != nil {
+		cfgSection = s.Config(cfg)
+	}
+	return s.Factory(cfgSection, limiter), true
+}
+
+// EnabledFetchers builds a Fetcher for every registered source whose
+// Enabled(cfg) returns true, in name-sorted order so a poll run is
+// deterministic regardless of registration order.
+func EnabledFetchers(cfg any, limiter *util.HostLimiter) []Fetcher {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := make([]Fetcher, 0, len(names))
+	for _, name := range names {
+		s := registry[name]
+		if s.Enabled != nil && !s.Enabled(cfg) {
+			continue
+		}
+		var cfgSection any
+		if s.Config != nil {
+			cfgSection = s.Config(cfg)
+		}
+		out = append(out, s.Factory(cfgSection, limiter))
+	}
+	return out
+}
@@ -18,6 +18,18 @@ type ScrapeStatus struct {
 	LastError string `json:"last_error"`
 	LastAdded int    `json:"last_added"`
 	Running   bool   `json:"running"`
+
+	// Checkpoints surfaces each registered ATS source's resumable
+	// pagination progress (see store.ATSCheckpoint), keyed by source
+	// name. Populated by ScrapeHandler.Status, not by the poller itself.
+	Checkpoints map[string]any `json:"checkpoints,omitempty"`
+
+	// EmailCacheStats surfaces the imap backend's per-message cache
+	// hit/miss counters (see internal/scrape/email/cache), populated
+	// by ScrapeHandler.Status the same way Checkpoints is. Omitted
+	// entirely if the cache has never been opened (email scraping
+	// disabled, or the imap backend never used).
+	EmailCacheStats map[string]int64 `json:"email_cache_stats,omitempty"`
 }
 
 type Fetcher interface {
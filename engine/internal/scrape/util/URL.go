@@ -6,7 +6,13 @@ import (
 	"strings"
 )
 
-func canonicalizeURL(raw string) string {
+// CanonicalizeURL lowercases scheme/host, strips the fragment and
+// known tracking query params (utm_*, gclid, fbclid, ...), and - for
+// linkedin.com - drops every query param except currentJobId, so two
+// URLs that land on the same posting compare equal. Exported for
+// store.ImportJobs, which needs the same normalization ClassifyURL
+// uses below to dedupe a restored backup against existing rows.
+func CanonicalizeURL(raw string) string {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
 		return ""
@@ -58,3 +64,156 @@ func urlIsTooGeneric(u string) bool {
 
 	return false
 }
+
+// LinkTag is ClassifyURL's verdict for a discovered link.
+type LinkTag string
+
+const (
+	// TagPrimary is a URL that should become a JobLead on its own: an
+	// ATS job page (greenhouse/lever/workday direct, /jobs/view/<id>).
+	TagPrimary LinkTag = "primary"
+	// TagRelated is worth fetching for enrichment (pageextract, a logo)
+	// but isn't itself a standalone posting: a company "about" page, a
+	// careers index, a logo image.
+	TagRelated LinkTag = "related"
+	// TagIgnore is never touched: unsubscribe/preferences links,
+	// tracking pixels, and other template cruft a careers-page template
+	// litters every listing with.
+	TagIgnore LinkTag = "ignore"
+)
+
+// URLScopeRule is ClassifyURL's config-driven override: any URL
+// containing Pattern (case-insensitive substring, same style as this
+// file's own heuristics) is tagged Tag ("primary"/"related"/"ignore")
+// without consulting the heuristic scorer below. Mirrors
+// config.URLScopeRule field for field - this package can't import
+// internal/config (internal/config imports internal/scrape/types,
+// which already imports this package), so SetURLScope's caller
+// converts for us.
+type URLScopeRule struct {
+	Pattern string
+	Tag     string
+}
+
+// urlScopeRules holds the overrides set via SetURLScope, consulted by
+// ClassifyURL ahead of its built-in heuristics.
+var urlScopeRules []URLScopeRule
+
+// SetURLScope wires up the site-specific allow/deny rules ClassifyURL
+// checks first. Call once at startup, same lifecycle as
+// scrape.SetDedupeFilter/SetDB.
+func SetURLScope(rules []URLScopeRule) {
+	urlScopeRules = rules
+}
+
+// ClassifyURL tags u as TagPrimary/TagRelated/TagIgnore. A
+// config-driven URLScopeRule (set via SetURLScope) always wins over the
+// heuristic verdict below, first match in list order; otherwise direct
+// ATS job pages and /jobs/view/<id> links score TagPrimary, anything
+// isObviousJunkURL already drops scores TagIgnore, and everything else
+// falls back to scoreURL's sign.
+func ClassifyURL(u string) LinkTag {
+	cu := CanonicalizeURL(u)
+	if cu == "" {
+		return TagIgnore
+	}
+	lu := strings.ToLower(cu)
+
+	for _, r := range urlScopeRules {
+		p := strings.ToLower(strings.TrimSpace(r.Pattern))
+		if p == "" {
+			continue
+		}
+		if strings.Contains(lu, p) {
+			switch strings.ToLower(strings.TrimSpace(r.Tag)) {
+			case "primary":
+				return TagPrimary
+			case "related":
+				return TagRelated
+			case "ignore":
+				return TagIgnore
+			}
+		}
+	}
+
+	if isObviousJunkURL(cu) {
+		return TagIgnore
+	}
+
+	switch {
+	case strings.Contains(lu, "/jobs/view/"),
+		strings.Contains(lu, "greenhouse.io"),
+		strings.Contains(lu, "lever.co"),
+		strings.Contains(lu, "myworkdayjobs"):
+		return TagPrimary
+	}
+
+	if scoreURL(cu) > 0 {
+		return TagRelated
+	}
+	return TagIgnore
+}
+
+// scoreURL rates how likely u is to be a single job-posting page
+// (positive) vs. obviously unrelated (negative/zero). ClassifyURL uses
+// it as the TagRelated/TagIgnore cutoff for links that aren't an
+// obvious direct ATS/job-view hit.
+func scoreURL(u string) int {
+	lu := strings.ToLower(u)
+	score := 0
+
+	if strings.Contains(lu, "/jobs/view/") {
+		score += 100
+	}
+	if strings.Contains(lu, "greenhouse.io") || strings.Contains(lu, "lever.co") || strings.Contains(lu, "myworkdayjobs") {
+		score += 80
+	}
+	if strings.Contains(lu, "/apply") {
+		score += 40
+	}
+	if strings.Contains(lu, "/job") || strings.Contains(lu, "/jobs") || strings.Contains(lu, "/careers") {
+		score += 20
+	}
+
+	if strings.Contains(lu, "/alerts") || strings.Contains(lu, "/settings") {
+		score -= 100
+	}
+	if strings.Contains(lu, "linkedin.com/comm/") {
+		score -= 10
+	}
+
+	return score
+}
+
+// isObviousJunkURL flags links that ClassifyURL/scoreURL never treat as
+// a posting: unsubscribe/preferences flows, tracking pixels, and
+// account/legal boilerplate. Mirrors internal/scrape's own
+// isObviousJunkURL (kept separate rather than shared - see that file's
+// doc comment).
+func isObviousJunkURL(u string) bool {
+	lu := strings.ToLower(u)
+
+	junks := []string{
+		"unsubscribe",
+		"preferences",
+		"manage-preferences",
+		"email-preferences",
+		"privacy",
+		"terms",
+		"view-in-browser",
+		"viewaswebpage",
+		"tracking",
+		"pixel",
+		"beacon",
+		"/alerts",
+		"/settings",
+		"/help",
+		"/legal",
+	}
+	for _, j := range junks {
+		if strings.Contains(lu, j) {
+			return true
+		}
+	}
+	return false
+}
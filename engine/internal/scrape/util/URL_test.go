@@ -0,0 +1,113 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// linkedInJobAlertFixture is a trimmed but structurally faithful copy of
+// a real "Jobs for you" LinkedIn email: a job card anchor, a "more jobs
+// at this company" anchor worth enriching but not itself a posting, and
+// the "manage this job alert" footer link every such email carries.
+const linkedInJobAlertFixture = `
+<table>
+  <tr><td>
+    <a href="https://www.linkedin.com/comm/jobs/view/4012345678/?trackingId=abc123&refId=xyz">
+      <p>Senior Platform Engineer</p>
+      <p>Acme Corp · Remote</p>
+    </a>
+  </td></tr>
+  <tr><td>
+    <a href="https://www.linkedin.com/company/acme-corp/jobs/">More jobs at Acme Corp</a>
+  </td></tr>
+  <tr><td>
+    <a href="https://www.linkedin.com/comm/jobs/alerts/manage/?trk=email_jobs_alert">Manage this job alert</a>
+  </td></tr>
+  <tr><td>
+    <a href="https://www.linkedin.com/comm/jobs/preferences/?trk=email">Unsubscribe</a>
+  </td></tr>
+</table>
+`
+
+// greenhouseNotificationFixture mirrors an ATS-hosted job-board
+// notification email: a direct Greenhouse posting link, a careers
+// index link (enrichment-worthy but not itself a posting), and a
+// tracking pixel image with no anchor at all.
+const greenhouseNotificationFixture = `
+<div>
+  <a href="https://boards.greenhouse.io/acmecorp/jobs/4500111222">Staff Backend Engineer</a>
+  <a href="https://www.acmecorp.com/careers">View all open roles</a>
+  <a href="https://click.email-tracker.example.com/unsubscribe?u=1234">Unsubscribe from these alerts</a>
+  <img src="https://click.email-tracker.example.com/pixel.gif?u=1234" width="1" height="1">
+</div>
+`
+
+// extractHrefs returns every anchor href in htmlBody, in document order -
+// the same traversal ParseLinkedInJobAlertHTML uses before consulting
+// ClassifyURL.
+func extractHrefs(t *testing.T, htmlBody string) []string {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlBody))
+	if err != nil {
+		t.Fatalf("parse fixture: %v", err)
+	}
+	var hrefs []string
+	doc.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+		href, _ := a.Attr("href")
+		if href = strings.TrimSpace(href); href != "" {
+			hrefs = append(hrefs, href)
+		}
+	})
+	return hrefs
+}
+
+func TestClassifyURL_EmailFixtureCorpus(t *testing.T) {
+	cases := []struct {
+		fixture string
+		hrefOf  string // substring identifying which anchor's href to check
+		want    LinkTag
+	}{
+		{linkedInJobAlertFixture, "/jobs/view/4012345678/?trackingId", TagPrimary},
+		{linkedInJobAlertFixture, "/company/acme-corp/jobs/", TagRelated},
+		{linkedInJobAlertFixture, "/jobs/alerts/manage", TagIgnore},
+		{linkedInJobAlertFixture, "/jobs/preferences/", TagIgnore},
+
+		{greenhouseNotificationFixture, "boards.greenhouse.io", TagPrimary},
+		{greenhouseNotificationFixture, "acmecorp.com/careers", TagRelated},
+		{greenhouseNotificationFixture, "unsubscribe?u=1234", TagIgnore},
+	}
+
+	for _, tc := range cases {
+		hrefs := extractHrefs(t, tc.fixture)
+		var href string
+		for _, h := range hrefs {
+			if strings.Contains(h, tc.hrefOf) {
+				href = h
+				break
+			}
+		}
+		if href == "" {
+			t.Fatalf("fixture has no anchor containing %q (found %v)", tc.hrefOf, hrefs)
+		}
+
+		if got := ClassifyURL(href); got != tc.want {
+			t.Errorf("ClassifyURL(%q) = %q, want %q", href, got, tc.want)
+		}
+	}
+}
+
+func TestClassifyURL_IgnoresTrackingPixelsOutsideAnchors(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(greenhouseNotificationFixture))
+	if err != nil {
+		t.Fatalf("parse fixture: %v", err)
+	}
+	src, ok := doc.Find("img").Attr("src")
+	if !ok {
+		t.Fatal("fixture has no tracking pixel img")
+	}
+	if got := ClassifyURL(src); got != TagIgnore {
+		t.Errorf("ClassifyURL(%q) = %q, want %q", src, got, TagIgnore)
+	}
+}
@@ -0,0 +1,36 @@
+package util
+
+import (
+	"time"
+
+	"jobhunt-engine/internal/scrape/challenge"
+)
+
+// ChallengeSolverConfig configures NewChallengeSolver - shared by every
+// ATS package that wants to delegate a Cloudflare challenge to an
+// external solver instead of failing outright.
+type ChallengeSolverConfig struct {
+	// FlareSolverrEndpoint, if set, is a running FlareSolverr
+	// instance's /v1 endpoint (e.g. "http://flaresolverr:8191/v1").
+	// Empty falls back to challenge.NoopSolver.
+	FlareSolverrEndpoint string
+
+	// FlareSolverrTimeout bounds how long FlareSolverr's browser gets
+	// per challenge. Defaults to 60s when <= 0.
+	FlareSolverrTimeout time.Duration
+}
+
+// NewChallengeSolver builds the challenge.Solver described by cfg,
+// wrapped in challenge.NewCachingSolver so repeat calls for a host
+// already holding unexpired cookies skip the round trip. This is the
+// one place every ATS package should build a solver from, so adding a
+// new implementation only needs wiring here once.
+func NewChallengeSolver(cfg ChallengeSolverConfig) challenge.Solver {
+	if cfg.FlareSolverrEndpoint == "" {
+		return challenge.NoopSolver{}
+	}
+	return challenge.NewCachingSolver(challenge.NewFlareSolverr(challenge.FlareSolverrConfig{
+		Endpoint: cfg.FlareSolverrEndpoint,
+		Timeout:  cfg.FlareSolverrTimeout,
+	}))
+}
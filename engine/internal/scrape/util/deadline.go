@@ -0,0 +1,69 @@
+package util
+
+import (
+	"sync"
+	"time"
+)
+
+// DeadlineTimer backs a Fetcher's cancellable, adjustable deadline,
+// modelled on netstack's own deadlineTimer: a chan struct{} closed
+// exactly once, either by an time.AfterFunc when the deadline arrives
+// or by an explicit Cancel, so every select watching Done() alongside
+// ctx.Done() wakes regardless of how many times SetDeadline moved the
+// trigger time in between.
+type DeadlineTimer struct {
+	mu      sync.Mutex
+	timer   *time.Timer
+	done    chan struct{}
+	expired bool
+}
+
+func NewDeadlineTimer() *DeadlineTimer {
+	return &DeadlineTimer{done: make(chan struct{})}
+}
+
+// Done returns the channel that closes when the deadline fires or
+// Cancel is called. Safe to read from multiple goroutines/selects.
+func (d *DeadlineTimer) Done() <-chan struct{} {
+	return d.done
+}
+
+// SetDeadline arms (or re-arms) the timer to fire at t, replacing
+// whatever deadline was set before - callers extend or shorten an
+// in-flight fetch's timeout by calling this again with a new t. A
+// zero t clears the pending deadline without closing Done. Has no
+// effect once Done has already fired; a fetch that needs a fresh
+// window after that should get a new DeadlineTimer instead.
+func (d *DeadlineTimer) SetDeadline(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.expired {
+		return
+	}
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	if t.IsZero() {
+		return
+	}
+	d.timer = time.AfterFunc(time.Until(t), d.fire)
+}
+
+// Cancel fires Done immediately, as if the deadline had just arrived.
+func (d *DeadlineTimer) Cancel() {
+	d.fire()
+}
+
+func (d *DeadlineTimer) fire() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.expired {
+		return
+	}
+	d.expired = true
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	close(d.done)
+}
@@ -4,24 +4,88 @@ import (
 	"context"
 	"net/url"
 	"sync"
+	"time"
+
+	"jobhunt-engine/internal/metrics"
 
 	"golang.org/x/time/rate"
 )
 
+// maxHostBackoff caps Penalize's exponential backoff window, so a
+// host that's been down for hours doesn't end up waiting more than
+// this long between retries.
+const maxHostBackoff = 10 * time.Minute
+
+// hostBackoff tracks one key's consecutive-failure backoff window.
+type hostBackoff struct {
+	failures int
+	until    time.Time
+}
+
 // HostLimiter rate-limits per hostname (api.lever.co, boards.greenhouse.io, etc).
 type HostLimiter struct {
 	mu sync.Mutex
 	m  map[string]*rate.Limiter
 	r  rate.Limit
 	b  int
+
+	// backoff holds an additional, exponentially growing wait on top
+	// of the steady-state rate limit above, keyed by whatever string
+	// Penalize/Succeed are called with. WaitURL consults it only under
+	// the request URL's host, so an entry under a "source/company" key
+	// (what targets.Registry.Record passes to Penalize) is recorded but
+	// never waited on.
+	backoff map[string]*hostBackoff
 }
 
 func NewHostLimiter(reqPerSec float64, burst int) *HostLimiter {
 	return &HostLimiter{
-		m: make(map[string]*rate.Limiter),
-		r: rate.Limit(reqPerSec),
-		b: burst,
+		m:       make(map[string]*rate.Limiter),
+		r:       rate.Limit(reqPerSec),
+		b:       burst,
+		backoff: make(map[string]*hostBackoff),
+	}
+}
+
+// Penalize exponentially backs off key (doubling per consecutive call,
+// capped at maxHostBackoff), so the next WaitURL/backoffRemaining for
+// the same key blocks well past its usual rate-limit slot.
+func (hl *HostLimiter) Penalize(key string) {
+	hl.mu.Lock()
+	defer hl.mu.Unlock()
+
+	bo, ok := hl.backoff[key]
+	if !ok {
+		bo = &hostBackoff{}
+		hl.backoff[key] = bo
 	}
+	bo.failures++
+
+	wait := time.Second << uint(min(bo.failures, 10))
+	if wait > maxHostBackoff {
+		wait = maxHostBackoff
+	}
+	bo.until = time.Now().Add(wait)
+}
+
+// Succeed clears any backoff window Penalize built up for key.
+func (hl *HostLimiter) Succeed(key string) {
+	hl.mu.Lock()
+	defer hl.mu.Unlock()
+	delete(hl.backoff, key)
+}
+
+// backoffRemaining returns how much longer key's Penalize window has
+// left to run, or 0 if there isn't one.
+func (hl *HostLimiter) backoffRemaining(key string) time.Duration {
+	hl.mu.Lock()
+	defer hl.mu.Unlock()
+
+	bo, ok := hl.backoff[key]
+	if !ok {
+		return 0
+	}
+	return time.Until(bo.until)
 }
 
 func (hl *HostLimiter) limiterFor(host string) *rate.Limiter {
@@ -37,9 +101,23 @@ func (hl *HostLimiter) limiterFor(host string) *rate.Limiter {
 }
 
 func (hl *HostLimiter) WaitURL(ctx context.Context, raw string) error {
-	u, err := url.Parse(raw)
-	if err != nil || u.Host == "" {
-		return hl.limiterFor("_").Wait(ctx)
+	host := "_"
+	if u, err := url.Parse(raw); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	if wait := hl.backoffRemaining(host); wait > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
 	}
-	return hl.limiterFor(u.Host).Wait(ctx)
+
+	start := time.Now()
+	defer func() {
+		metrics.RateLimiterWaitSeconds.WithLabelValues(host).Observe(time.Since(start).Seconds())
+	}()
+
+	return hl.limiterFor(host).Wait(ctx)
 }
@@ -1,6 +1,17 @@
 package util
 
-import "strings"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// HashString returns a stable hex-encoded sha256 digest of s, used to derive
+// a synthetic job ID when a source provides no stable identifier of its own.
+func HashString(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
 
 func CleanText(s string) string {
 	s = strings.ReplaceAll(s, "\u00a0", " ")
@@ -0,0 +1,152 @@
+package webcrawl
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// extracted is what extractPosting pulls off a landed page, enough to
+// fill in a domain.JobLead once the caller also knows the URL/company
+// seed it came from.
+type extracted struct {
+	Title    string
+	Company  string
+	Location string
+}
+
+// jsonLDJobPosting mirrors the schema.org JobPosting fields this
+// package cares about; everything else in the object is ignored.
+type jsonLDJobPosting struct {
+	Type               string `json:"@type"`
+	Title              string `json:"title"`
+	HiringOrganization struct {
+		Name string `json:"name"`
+	} `json:"hiringOrganization"`
+	JobLocation struct {
+		Address struct {
+			AddressLocality string `json:"addressLocality"`
+			AddressRegion   string `json:"addressRegion"`
+		} `json:"address"`
+	} `json:"jobLocation"`
+}
+
+// extractPosting tries schema.org JSON-LD JobPosting blocks first
+// (what most ATS-hosted and many custom career pages embed for SEO),
+// then falls back to heuristics over the rendered HTML.
+func extractPosting(doc *goquery.Document) extracted {
+	if e, ok := extractJSONLD(doc); ok {
+		return e
+	}
+	return extractHeuristic(doc)
+}
+
+func extractJSONLD(doc *goquery.Document) (extracted, bool) {
+	var found extracted
+	ok := false
+
+	doc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		var raw json.RawMessage
+		if err := json.Unmarshal([]byte(s.Text()), &raw); err != nil {
+			return true
+		}
+
+		for _, p := range candidateJobPostings(raw) {
+			if !strings.EqualFold(p.Type, "JobPosting") {
+				continue
+			}
+			found = extracted{
+				Title:    cleanText(p.Title),
+				Company:  cleanText(p.HiringOrganization.Name),
+				Location: cleanText(joinNonEmpty(p.JobLocation.Address.AddressLocality, p.JobLocation.Address.AddressRegion)),
+			}
+			ok = true
+			return false
+		}
+		return true
+	})
+
+	return found, ok
+}
+
+// candidateJobPostings handles the three shapes a ld+json block
+// commonly takes: a single object, an array of objects, or a
+// "@graph"-wrapped array.
+func candidateJobPostings(raw json.RawMessage) []jsonLDJobPosting {
+	var one jsonLDJobPosting
+	if err := json.Unmarshal(raw, &one); err == nil && one.Type != "" {
+		return []jsonLDJobPosting{one}
+	}
+
+	var many []jsonLDJobPosting
+	if err := json.Unmarshal(raw, &many); err == nil {
+		return many
+	}
+
+	var graph struct {
+		Graph []jsonLDJobPosting `json:"@graph"`
+	}
+	if err := json.Unmarshal(raw, &graph); err == nil {
+		return graph.Graph
+	}
+
+	return nil
+}
+
+// extractHeuristic is the fallback for career pages with no JSON-LD:
+// the best-scoring heading on the page as the title (see titleScore),
+// plus a couple of common CSS-class guesses for location.
+func extractHeuristic(doc *goquery.Document) extracted {
+	var e extracted
+
+	bestScore := -1000
+	doc.Find("h1, h2").Each(func(_ int, sel *goquery.Selection) {
+		t := cleanText(sel.Text())
+		if t == "" {
+			return
+		}
+		if sc := titleScore(t); sc > bestScore {
+			bestScore = sc
+			e.Title = t
+		}
+	})
+
+	for _, sel := range []string{".location", ".job-location", "[data-location]"} {
+		if loc := cleanText(doc.Find(sel).First().Text()); loc != "" {
+			e.Location = loc
+			break
+		}
+	}
+
+	return e
+}
+
+// titleScore rates how much s looks like a real job title versus
+// boilerplate a careers page's template repeats on every page
+// ("Apply now", "Join our team").
+func titleScore(s string) int {
+	l := strings.ToLower(s)
+	score := len(strings.Fields(s))
+	for _, junk := range []string{"apply now", "join our team", "why work", "life at", "about us", "benefits"} {
+		if strings.Contains(l, junk) {
+			score -= 10
+		}
+	}
+	return score
+}
+
+func cleanText(s string) string {
+	s = strings.ReplaceAll(s, " ", " ")
+	return strings.TrimSpace(strings.Join(strings.Fields(s), " "))
+}
+
+func joinNonEmpty(parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, ", ")
+}
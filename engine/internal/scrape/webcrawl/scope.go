@@ -0,0 +1,120 @@
+package webcrawl
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Classification is what Scope.Classify returns for a link discovered
+// on a crawled page.
+type Classification int
+
+const (
+	// IGNORE drops the link entirely: not fetched, not recursed into.
+	IGNORE Classification = iota
+	// RELATED fetches the page once for metadata (it may be a single
+	// job posting) but doesn't recurse through its own outbound links.
+	RELATED
+	// PRIMARY fetches AND recurses: a listing/category page likely to
+	// link to more postings.
+	PRIMARY
+)
+
+// defaultPathAllow is the set of path prefixes (lowercased) a
+// same-host link must start with to be scored PRIMARY, when
+// WebCrawlSourceConfig.PathAllow isn't set.
+var defaultPathAllow = []string{"/careers", "/jobs", "/job", "/join-us", "/positions", "/openings"}
+
+// Scope decides, for each link discovered while crawling current,
+// whether the Crawler should recurse into it (PRIMARY), fetch it once
+// without recursing (RELATED), or drop it (IGNORE).
+type Scope interface {
+	Classify(current *url.URL, link *url.URL) Classification
+}
+
+// hostPathScope is the Crawler's default Scope: PRIMARY for same-host
+// links under one of pathAllow's prefixes, RELATED for other same-host
+// links that scoreURL likes the look of, IGNORE for everything else
+// (including every other host, so the crawl never wanders off the
+// seed's own career site).
+type hostPathScope struct {
+	pathAllow []string
+}
+
+func newHostPathScope(pathAllow []string) *hostPathScope {
+	if len(pathAllow) == 0 {
+		pathAllow = defaultPathAllow
+	}
+	return &hostPathScope{pathAllow: pathAllow}
+}
+
+func (s *hostPathScope) Classify(current, link *url.URL) Classification {
+	if link == nil || link.Host == "" {
+		return IGNORE
+	}
+	if !strings.EqualFold(link.Host, current.Host) {
+		return IGNORE
+	}
+	if isObviousJunkURL(link.String()) {
+		return IGNORE
+	}
+
+	lp := strings.ToLower(link.Path)
+	for _, prefix := range s.pathAllow {
+		if strings.HasPrefix(lp, prefix) {
+			return PRIMARY
+		}
+	}
+	if scoreURL(link.String()) > 0 {
+		return RELATED
+	}
+	return IGNORE
+}
+
+// scoreURL rates how likely u is to be a single job-posting page
+// (positive) vs. obviously unrelated (negative). hostPathScope uses
+// it as the RELATED/IGNORE cutoff for links outside PathAllow.
+func scoreURL(u string) int {
+	if isObviousJunkURL(u) {
+		return -10
+	}
+
+	lu := strings.ToLower(u)
+	score := 0
+	if strings.Contains(lu, "/job/") || strings.Contains(lu, "/jobs/") ||
+		strings.Contains(lu, "/position/") || strings.Contains(lu, "/posting/") ||
+		strings.Contains(lu, "/req/") || strings.Contains(lu, "/opening/") {
+		score += 5
+	}
+	if strings.Contains(lu, "apply") {
+		score++
+	}
+	return score
+}
+
+// isObviousJunkURL flags links that are never job postings: static
+// assets, auth/account flows, and the social/share links a careers
+// page's template tends to litter every listing with.
+func isObviousJunkURL(u string) bool {
+	lu := strings.ToLower(u)
+
+	for _, ext := range []string{".css", ".js", ".png", ".jpg", ".jpeg", ".gif", ".svg", ".ico", ".pdf", ".zip", ".woff", ".woff2"} {
+		if strings.HasSuffix(lu, ext) {
+			return true
+		}
+	}
+
+	for _, sub := range []string{
+		"mailto:", "tel:", "javascript:",
+		"/login", "/signin", "/signup", "/logout",
+		"/privacy", "/terms", "/cookie",
+		"/wp-admin", "/wp-content",
+		"facebook.com", "twitter.com", "x.com", "instagram.com",
+		"linkedin.com/sharing", "share?", "/rss",
+	} {
+		if strings.Contains(lu, sub) {
+			return true
+		}
+	}
+	return false
+}
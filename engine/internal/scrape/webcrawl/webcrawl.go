@@ -0,0 +1,248 @@
+// Package webcrawl is a generic career-page crawler: given a seed URL
+// per company, it BFS-walks same-host links under a whitelisted path
+// prefix (see Scope) looking for individual job postings, instead of
+// relying on a known ATS board like greenhouse/lever/smartrecruiters.
+package webcrawl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"jobhunt-engine/internal/domain"
+	"jobhunt-engine/internal/fetch"
+	applog "jobhunt-engine/internal/log"
+	"jobhunt-engine/internal/metrics"
+	"jobhunt-engine/internal/store"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const source = "webcrawl"
+
+const (
+	defaultMaxDepth    = 2
+	defaultConcurrency = 4
+)
+
+// Seed is one company's career-page crawl root.
+type Seed struct {
+	Name string
+	URL  string
+}
+
+// Config configures a Crawler's BFS: which seeds to start from, how
+// deep PRIMARY-scoped links may be followed, how many workers crawl
+// concurrently, and (via PathAllow) what hostPathScope treats as a
+// listing page worth recursing into.
+type Config struct {
+	Seeds       []Seed
+	MaxDepth    int
+	Concurrency int
+	PathAllow   []string
+}
+
+// Crawler (exposed as Scraper to match the other ATS packages' naming)
+// satisfies scrape.Source: Fetch BFS-walks every seed and returns the
+// job postings it found; Hydrate is a no-op since Fetch already
+// extracts everything it can from the landed page.
+type Scraper struct {
+	cfg    Config
+	scope  Scope
+	fet    *fetch.Fetcher
+	robots *robotsCache
+
+	// db persists the crawl_seen visited-set (see store.CrawlSeen) so
+	// a restarted crawl doesn't re-fetch pages a prior run already
+	// visited. Nil disables persistence (every run starts fresh).
+	db *sql.DB
+}
+
+// New returns a Scraper for cfg. Per-host rate limiting and
+// retry/backoff come from fetch.Fetcher (the same policy jobboard's
+// sources use) rather than a shared *util.HostLimiter, since a crawl
+// fans out across arbitrarily many seed hosts instead of a fixed,
+// known set of company slugs.
+func New(cfg Config, db *sql.DB) *Scraper {
+	if cfg.MaxDepth <= 0 {
+		cfg.MaxDepth = defaultMaxDepth
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultConcurrency
+	}
+
+	fet := fetch.New(
+		fetch.WithUserAgents(crawlerUserAgent),
+		fetch.WithFollowRedirects(true),
+	)
+	return &Scraper{
+		cfg:    cfg,
+		scope:  newHostPathScope(cfg.PathAllow),
+		fet:    fet,
+		robots: newRobotsCache(&http.Client{Timeout: 10 * time.Second}),
+		db:     db,
+	}
+}
+
+func (s *Scraper) Name() string { return source }
+
+// Hydrate is a no-op: Fetch already extracts title/location/company
+// from the landed page, the same way smartrecruiters' Hydrate is.
+func (s *Scraper) Hydrate(ctx context.Context, j *domain.JobLead) error { return nil }
+
+// crawlItem is one pending page in the BFS queue.
+type crawlItem struct {
+	u       *url.URL
+	depth   int
+	class   Classification
+	company string
+}
+
+// Fetch BFS-walks every configured seed with cfg.Concurrency workers,
+// stopping PRIMARY recursion at cfg.MaxDepth, and returns every
+// JobLead extractPosting found along the way.
+func (s *Scraper) Fetch(ctx context.Context) ([]domain.JobLead, error) {
+	workCh := make(chan crawlItem, 64)
+	var pending sync.WaitGroup
+
+	var mu sync.Mutex
+	var out []domain.JobLead
+	seenThisRun := map[string]bool{}
+
+	enqueue := func(item crawlItem) {
+		pending.Add(1)
+		go func() {
+			select {
+			case workCh <- item:
+			case <-ctx.Done():
+				pending.Done()
+			}
+		}()
+	}
+
+	var workers sync.WaitGroup
+	workers.Add(s.cfg.Concurrency)
+	for i := 0; i < s.cfg.Concurrency; i++ {
+		go func() {
+			defer workers.Done()
+			for item := range workCh {
+				s.visit(ctx, item, enqueue, &mu, &out, seenThisRun)
+				pending.Done()
+			}
+		}()
+	}
+
+	for _, seed := range s.cfg.Seeds {
+		u, err := url.Parse(strings.TrimSpace(seed.URL))
+		if err != nil || u.Host == "" {
+			applog.With("source", source, "seed", seed.Name).Warn("invalid seed url", "url", seed.URL)
+			continue
+		}
+		enqueue(crawlItem{u: u, depth: 0, class: PRIMARY, company: seed.Name})
+	}
+
+	go func() {
+		pending.Wait()
+		close(workCh)
+	}()
+	workers.Wait()
+
+	return out, nil
+}
+
+// visit fetches item.u, records any posting it looks like, and (for
+// PRIMARY items under cfg.MaxDepth) enqueues the PRIMARY/RELATED links
+// it discovers on the page.
+func (s *Scraper) visit(ctx context.Context, item crawlItem, enqueue func(crawlItem), mu *sync.Mutex, out *[]domain.JobLead, seenThisRun map[string]bool) {
+	key := item.u.String()
+
+	mu.Lock()
+	already := seenThisRun[key]
+	seenThisRun[key] = true
+	mu.Unlock()
+	if already {
+		return
+	}
+
+	if s.db != nil {
+		if seen, err := store.CrawlSeen(ctx, s.db, key); err == nil && seen {
+			return
+		}
+	}
+
+	if !s.robots.Allowed(ctx, item.u) {
+		return
+	}
+
+	res, err := s.fet.Get(ctx, key)
+	if err != nil {
+		applog.With("source", source, "url", key).Warn("fetch failed", "error", err)
+		metrics.ScrapeErrorsTotal.WithLabelValues(source, "network").Inc()
+		return
+	}
+	defer res.Body.Close()
+
+	if s.db != nil {
+		_ = store.MarkCrawlSeen(ctx, s.db, key)
+	}
+
+	if res.StatusCode >= 400 {
+		metrics.ScrapeErrorsTotal.WithLabelValues(source, "http_status").Inc()
+		return
+	}
+
+	doc, err := goquery.NewDocumentFromReader(res.Body)
+	if err != nil {
+		metrics.ScrapeErrorsTotal.WithLabelValues(source, "parse").Inc()
+		return
+	}
+
+	if e := extractPosting(doc); e.Title != "" {
+		company := e.Company
+		if company == "" {
+			company = item.company
+		}
+		mu.Lock()
+		*out = append(*out, domain.JobLead{
+			CompanyName:     company,
+			Title:           e.Title,
+			URL:             key,
+			LocationRaw:     e.Location,
+			FirstSeenSource: source,
+			ATSJobID:        fmt.Sprintf("webcrawl:%s", store.HashCrawlURL(key)),
+		})
+		mu.Unlock()
+	}
+
+	if item.class != PRIMARY || item.depth >= s.cfg.MaxDepth {
+		return
+	}
+
+	doc.Find("body a[href]").Each(func(_ int, a *goquery.Selection) {
+		href, ok := a.Attr("href")
+		if !ok {
+			return
+		}
+		href = strings.TrimSpace(href)
+		if href == "" || strings.HasPrefix(href, "#") {
+			return
+		}
+		ref, err := url.Parse(href)
+		if err != nil {
+			return
+		}
+		resolved := item.u.ResolveReference(ref)
+
+		switch s.scope.Classify(item.u, resolved) {
+		case PRIMARY:
+			enqueue(crawlItem{u: resolved, depth: item.depth + 1, class: PRIMARY, company: item.company})
+		case RELATED:
+			enqueue(crawlItem{u: resolved, depth: item.depth + 1, class: RELATED, company: item.company})
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package workday
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// breakerMinCooldown/breakerMaxCooldown bound the per-host circuit
+// breaker's cool-off: the first Open waits breakerMinCooldown,
+// doubling on every further consecutive Open up to breakerMaxCooldown.
+const (
+	breakerMinCooldown = time.Minute
+	breakerMaxCooldown = time.Hour
+)
+
+// hostState is one host's breaker state, persisted as JSON so a
+// restart still respects an in-progress cool-off instead of
+// immediately re-hammering a tenant Cloudflare just finished blocking.
+type hostState struct {
+	Blocks        int       `json:"blocks"`
+	CooldownUntil time.Time `json:"cooldown_until"`
+}
+
+// breaker is a per-host circuit breaker for the Workday scraper: Open
+// puts a host in a cool-off that grows exponentially with each
+// consecutive block, and Allow refuses requests until that cool-off
+// elapses. Safe for concurrent use.
+type breaker struct {
+	mu        sync.Mutex
+	hosts     map[string]*hostState
+	statePath string // empty disables persistence
+}
+
+// newBreaker loads any prior state from statePath (if non-empty) and
+// returns a breaker seeded with it.
+func newBreaker(statePath string) *breaker {
+	b := &breaker{hosts: map[string]*hostState{}, statePath: statePath}
+	b.load()
+	return b
+}
+
+func (b *breaker) load() {
+	if b.statePath == "" {
+		return
+	}
+	data, err := os.ReadFile(b.statePath)
+	if err != nil {
+		return // first run, or no prior state - start clean
+	}
+	var hosts map[string]*hostState
+	if err := json.Unmarshal(data, &hosts); err != nil {
+		log.Printf("[ats:workday] breaker: discarding unreadable state file %s: %v", b.statePath, err)
+		return
+	}
+	b.hosts = hosts
+}
+
+// saveLocked persists the current host map; callers must hold b.mu.
+func (b *breaker) saveLocked() {
+	if b.statePath == "" {
+		return
+	}
+	data, err := json.Marshal(b.hosts)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(b.statePath), 0o755); err != nil {
+		log.Printf("[ats:workday] breaker: mkdir %s: %v", filepath.Dir(b.statePath), err)
+		return
+	}
+	if err := os.WriteFile(b.statePath, data, 0o644); err != nil {
+		log.Printf("[ats:workday] breaker: write %s: %v", b.statePath, err)
+	}
+}
+
+// Allow reports whether host is currently clear to request, and if
+// not, how much longer its cool-off has left.
+func (b *breaker) Allow(host string) (ok bool, retryAfter time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st, found := b.hosts[host]
+	if !found || !time.Now().Before(st.CooldownUntil) {
+		return true, 0
+	}
+	return false, time.Until(st.CooldownUntil)
+}
+
+// Open starts or extends host's cool-off, doubling from
+// breakerMinCooldown each consecutive time it's called (until an
+// intervening Clear resets the count). minWait, when >0 (a
+// Retry-After the server actually sent us), floors the cool-off even
+// if the exponential schedule would otherwise produce something
+// shorter - the tenant knows its own rate limit better than we do.
+func (b *breaker) Open(host string, minWait time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st, ok := b.hosts[host]
+	if !ok {
+		st = &hostState{}
+		b.hosts[host] = st
+	}
+	st.Blocks++
+
+	wait := breakerMinCooldown << uint(min(st.Blocks-1, 6))
+	if wait > breakerMaxCooldown {
+		wait = breakerMaxCooldown
+	}
+	if minWait > wait {
+		wait = minWait
+	}
+
+	st.CooldownUntil = time.Now().Add(wait)
+	b.saveLocked()
+}
+
+// Clear drops host's breaker state entirely, so the next Open starts
+// the exponential schedule over instead of picking up from a now-stale
+// run of failures.
+func (b *breaker) Clear(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.hosts[host]; ok {
+		delete(b.hosts, host)
+		b.saveLocked()
+	}
+}
+
+// Status is one host's breaker state, exported for Scraper.Status's
+// event-hub/observability use.
+type Status struct {
+	Host          string    `json:"host"`
+	Blocked       bool      `json:"blocked"`
+	Blocks        int       `json:"blocks"`
+	CooldownUntil time.Time `json:"cooldown_until,omitempty"`
+}
+
+// Snapshot returns every host breaker currently holds state for.
+func (b *breaker) Snapshot() []Status {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	out := make([]Status, 0, len(b.hosts))
+	for host, st := range b.hosts {
+		out = append(out, Status{
+			Host:          host,
+			Blocked:       time.Now().Before(st.CooldownUntil),
+			Blocks:        st.Blocks,
+			CooldownUntil: st.CooldownUntil,
+		})
+	}
+	return out
+}
@@ -0,0 +1,128 @@
+package workday
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"golang.org/x/net/proxy"
+)
+
+// UserAgentProfile is one realistic browser fingerprint: a User-Agent
+// plus the Client Hints headers modern Chromium sends alongside it, so
+// a tenant comparing the two doesn't see a mismatch that flags the
+// request as a bot.
+type UserAgentProfile struct {
+	UserAgent       string
+	SecCHUA         string
+	SecCHUAMobile   string
+	SecCHUAPlatform string
+}
+
+// defaultUserAgents is used when Config.UserAgents is empty.
+var defaultUserAgents = []UserAgentProfile{
+	{
+		UserAgent:       "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+		SecCHUA:         `"Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"`,
+		SecCHUAMobile:   "?0",
+		SecCHUAPlatform: `"Windows"`,
+	},
+	{
+		UserAgent:       "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+		SecCHUA:         `"Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"`,
+		SecCHUAMobile:   "?0",
+		SecCHUAPlatform: `"macOS"`,
+	},
+	{
+		UserAgent:       "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+		SecCHUA:         `"Chromium";v="124", "Google Chrome";v="124", "Not-A.Brand";v="99"`,
+		SecCHUAMobile:   "?0",
+		SecCHUAPlatform: `"Linux"`,
+	},
+}
+
+// applyBrowserHeaders sets req's User-Agent and sec-ch-ua* headers
+// from ua, leaving any Client Hints field ua doesn't set untouched.
+func applyBrowserHeaders(req *http.Request, ua UserAgentProfile) {
+	req.Header.Set("User-Agent", ua.UserAgent)
+	if ua.SecCHUA != "" {
+		req.Header.Set("Sec-Ch-Ua", ua.SecCHUA)
+	}
+	if ua.SecCHUAMobile != "" {
+		req.Header.Set("Sec-Ch-Ua-Mobile", ua.SecCHUAMobile)
+	}
+	if ua.SecCHUAPlatform != "" {
+		req.Header.Set("Sec-Ch-Ua-Platform", ua.SecCHUAPlatform)
+	}
+}
+
+// uaRotator hands back Config.UserAgents (or defaultUserAgents, if
+// that's empty) round-robin, one profile per call.
+type uaRotator struct {
+	mu       sync.Mutex
+	next     int
+	profiles []UserAgentProfile
+}
+
+func newUARotator(profiles []UserAgentProfile) *uaRotator {
+	if len(profiles) == 0 {
+		profiles = defaultUserAgents
+	}
+	return &uaRotator{profiles: profiles}
+}
+
+func (r *uaRotator) pick() UserAgentProfile {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p := r.profiles[r.next%len(r.profiles)]
+	r.next++
+	return p
+}
+
+// proxyRing round-robins Config.Proxies (http://, https://, socks5://,
+// or socks5h:// URLs) and hands back a ready-to-use *http.Transport
+// dialing through whichever one is next. transport returns (nil, nil)
+// when no proxies are configured, meaning "dial direct".
+type proxyRing struct {
+	mu      sync.Mutex
+	next    int
+	proxies []string
+}
+
+func newProxyRing(proxies []string) *proxyRing {
+	return &proxyRing{proxies: proxies}
+}
+
+func (p *proxyRing) transport() (*http.Transport, error) {
+	if len(p.proxies) == 0 {
+		return nil, nil
+	}
+
+	p.mu.Lock()
+	raw := p.proxies[p.next%len(p.proxies)]
+	p.next++
+	p.mu.Unlock()
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("workday: invalid proxy %q: %w", raw, err)
+	}
+
+	switch u.Scheme {
+	case "socks5", "socks5h":
+		dialer, err := proxy.FromURL(u, proxy.Direct)
+		if err != nil {
+			return nil, fmt.Errorf("workday: socks5 proxy %q: %w", raw, err)
+		}
+		return &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return dialer.Dial(network, addr)
+			},
+		}, nil
+	default:
+		return &http.Transport{Proxy: http.ProxyURL(u)}, nil
+	}
+}
@@ -0,0 +1,71 @@
+package secrets
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"jobhunt-engine/internal/config"
+
+	"filippo.io/age"
+)
+
+// ageProvider decrypts email.secret_file with an age identity loaded
+// from email.age_identity_file. Lets the password live on disk
+// encrypted at rest instead of in plain text, without standing up a
+// Vault cluster just for one secret.
+type ageProvider struct{}
+
+func (ageProvider) Name() string { return "age" }
+
+func (ageProvider) Get(cfg config.Config) (string, error) {
+	secretPath := strings.TrimSpace(cfg.Email.SecretFile)
+	if secretPath == "" {
+		return "", fmt.Errorf("secrets: email.secret_file is not set")
+	}
+	identityPath := strings.TrimSpace(cfg.Email.AgeIdentityFile)
+	if identityPath == "" {
+		return "", fmt.Errorf("secrets: email.age_identity_file is not set")
+	}
+
+	identityFile, err := os.Open(identityPath)
+	if err != nil {
+		return "", fmt.Errorf("secrets: open age identity: %w", err)
+	}
+	defer identityFile.Close()
+
+	identities, err := age.ParseIdentities(identityFile)
+	if err != nil {
+		return "", fmt.Errorf("secrets: parse age identity: %w", err)
+	}
+
+	encrypted, err := os.Open(secretPath)
+	if err != nil {
+		return "", fmt.Errorf("secrets: open %s: %w", secretPath, err)
+	}
+	defer encrypted.Close()
+
+	r, err := age.Decrypt(encrypted, identities...)
+	if err != nil {
+		return "", fmt.Errorf("secrets: age decrypt %s: %w", secretPath, err)
+	}
+	plain, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("secrets: read decrypted secret: %w", err)
+	}
+
+	pw := strings.TrimSpace(string(plain))
+	if pw == "" {
+		return "", fmt.Errorf("secrets: decrypted %s is empty", secretPath)
+	}
+	return pw, nil
+}
+
+func (ageProvider) Set(cfg config.Config, password string) error {
+	return fmt.Errorf("secrets: age backend is read-only, encrypt email.secret_file with `age -r <recipient>` yourself: %w", ErrNotSupported)
+}
+
+func (ageProvider) Delete(cfg config.Config) error {
+	return fmt.Errorf("secrets: age backend is read-only, remove email.secret_file yourself: %w", ErrNotSupported)
+}
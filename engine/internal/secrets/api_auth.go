@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Keyring accounts for the httpapi bearer token and HMAC signing key
+// (see httpapi.RequireAuth). Unlike the IMAP password, these aren't
+// per-config-account - one engine instance has one API token and one
+// signing key, so the account names are fixed.
+const (
+	apiTokenAccount       = "jobhunt:api:bearer-token"
+	hmacSigningKeyAccount = "jobhunt:api:hmac-signing-key"
+)
+
+// GetAPIToken returns the bearer token httpapi.RequireAuth checks
+// every request against, or an error if none has been set yet.
+func GetAPIToken() (string, error) {
+	return getKeyringSecret(apiTokenAccount, "API bearer token")
+}
+
+// SetAPIToken stores token as the bearer token httpapi.RequireAuth
+// checks every request against.
+func SetAPIToken(token string) error {
+	return setKeyringSecret(apiTokenAccount, token)
+}
+
+// DeleteAPIToken removes the stored bearer token.
+func DeleteAPIToken() error {
+	return keyring.Delete(KeyringService, apiTokenAccount)
+}
+
+// GetHMACSigningKey returns the key httpapi.RequireAuth verifies
+// X-JobHunt-Signature against for config PUT and secrets endpoints, or
+// an error if none has been set yet.
+func GetHMACSigningKey() (string, error) {
+	return getKeyringSecret(hmacSigningKeyAccount, "HMAC signing key")
+}
+
+// SetHMACSigningKey stores key as the HMAC signing key.
+func SetHMACSigningKey(key string) error {
+	return setKeyringSecret(hmacSigningKeyAccount, key)
+}
+
+// DeleteHMACSigningKey removes the stored HMAC signing key.
+func DeleteHMACSigningKey() error {
+	return keyring.Delete(KeyringService, hmacSigningKeyAccount)
+}
+
+func getKeyringSecret(account, what string) (string, error) {
+	v, err := keyring.Get(KeyringService, account)
+	if err == nil && strings.TrimSpace(v) != "" {
+		return v, nil
+	}
+	return "", errors.New(what + " not found (set it via the secrets endpoint first)")
+}
+
+func setKeyringSecret(account, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return errors.New("value is empty")
+	}
+	return keyring.Set(KeyringService, account, value)
+}
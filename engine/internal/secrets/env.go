@@ -0,0 +1,36 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"jobhunt-engine/internal/config"
+)
+
+// EnvVar is the environment variable envProvider reads the IMAP app
+// password from.
+const EnvVar = "JOBHUNT_IMAP_PASSWORD"
+
+// envProvider reads the password from an environment variable, for
+// deployments that inject secrets that way (systemd EnvironmentFile,
+// docker --env-file, CI secrets, ...).
+type envProvider struct{}
+
+func (envProvider) Name() string { return "env" }
+
+func (envProvider) Get(cfg config.Config) (string, error) {
+	pw := strings.TrimSpace(os.Getenv(EnvVar))
+	if pw == "" {
+		return "", fmt.Errorf("secrets: %s is not set", EnvVar)
+	}
+	return pw, nil
+}
+
+func (envProvider) Set(cfg config.Config, password string) error {
+	return fmt.Errorf("secrets: env backend is read-only, set %s yourself: %w", EnvVar, ErrNotSupported)
+}
+
+func (envProvider) Delete(cfg config.Config) error {
+	return fmt.Errorf("secrets: env backend is read-only, unset %s yourself: %w", EnvVar, ErrNotSupported)
+}
@@ -0,0 +1,60 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"jobhunt-engine/internal/config"
+)
+
+// fileProvider reads the password from a plain-text file, rejecting
+// anything more permissive than mode 0600 so the secret isn't
+// world/group-readable on disk.
+type fileProvider struct{}
+
+func (fileProvider) Name() string { return "file" }
+
+func (fileProvider) Get(cfg config.Config) (string, error) {
+	path := strings.TrimSpace(cfg.Email.SecretFile)
+	if path == "" {
+		return "", fmt.Errorf("secrets: email.secret_file is not set")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("secrets: stat %s: %w", path, err)
+	}
+	if mode := info.Mode().Perm(); mode&0o077 != 0 {
+		return "", fmt.Errorf("secrets: %s is mode %04o, expected 0600 or stricter", path, mode)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("secrets: read %s: %w", path, err)
+	}
+	pw := strings.TrimSpace(string(b))
+	if pw == "" {
+		return "", fmt.Errorf("secrets: %s is empty", path)
+	}
+	return pw, nil
+}
+
+func (fileProvider) Set(cfg config.Config, password string) error {
+	path := strings.TrimSpace(cfg.Email.SecretFile)
+	if path == "" {
+		return fmt.Errorf("secrets: email.secret_file is not set")
+	}
+	return os.WriteFile(path, []byte(password+"\n"), 0o600)
+}
+
+func (fileProvider) Delete(cfg config.Config) error {
+	path := strings.TrimSpace(cfg.Email.SecretFile)
+	if path == "" {
+		return fmt.Errorf("secrets: email.secret_file is not set")
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("secrets: remove %s: %w", path, err)
+	}
+	return nil
+}
@@ -3,9 +3,10 @@ package secrets
 import (
 	"errors"
 	"fmt"
-	"jobhunt-engine/internal/config"
 	"strings"
 
+	"jobhunt-engine/internal/config"
+
 	"github.com/zalando/go-keyring"
 )
 
@@ -14,6 +15,27 @@ const (
 	KeyringService = "jobhunt"
 )
 
+// keyringProvider is the original backend: the OS keychain via
+// zalando/go-keyring. It's the default when Email.SecretBackend is
+// unset, but needs a D-Bus session and so fails on headless Linux
+// boxes and containers — see envProvider/fileProvider/ageProvider/
+// vaultProvider for alternatives.
+type keyringProvider struct{}
+
+func (keyringProvider) Name() string { return "keyring" }
+
+func (keyringProvider) Get(cfg config.Config) (string, error) {
+	return GetIMAPPassword(IMAPKeyringAccount(cfg))
+}
+
+func (keyringProvider) Set(cfg config.Config, password string) error {
+	return SetIMAPPassword(IMAPKeyringAccount(cfg), password)
+}
+
+func (keyringProvider) Delete(cfg config.Config) error {
+	return DeleteIMAPPassword(IMAPKeyringAccount(cfg))
+}
+
 func GetIMAPPassword(keyringAccount string) (string, error) {
 	// 1) Keyring first (recommended)
 	if strings.TrimSpace(keyringAccount) != "" {
@@ -0,0 +1,52 @@
+// Package secrets resolves the IMAP app password from whichever
+// backend the deployment is configured for. The OS keyring (the
+// original, and still the default) needs a D-Bus session and fails on
+// headless boxes and containers, so env/file/age/vault backends exist
+// as drop-in alternatives for server and CI deployments.
+package secrets
+
+import (
+	"errors"
+	"strings"
+
+	"jobhunt-engine/internal/config"
+)
+
+// ErrNotSupported is returned by Set/Delete on read-only backends
+// (env, age, vault) where the engine has nowhere to write the
+// secret back to — it's provisioned out-of-band by the deployer.
+var ErrNotSupported = errors.New("secrets: backend does not support this operation")
+
+// SecretProvider resolves and (where supported) manages the IMAP app
+// password for one backend.
+type SecretProvider interface {
+	Name() string
+	Get(cfg config.Config) (string, error)
+	Set(cfg config.Config, password string) error
+	Delete(cfg config.Config) error
+}
+
+// ProviderFor returns the SecretProvider for the given
+// config.Email.SecretBackend value. An empty or unrecognized value
+// falls back to "keyring" for backward compatibility.
+func ProviderFor(backend string) SecretProvider {
+	switch strings.ToLower(strings.TrimSpace(backend)) {
+	case "env":
+		return envProvider{}
+	case "file":
+		return fileProvider{}
+	case "age":
+		return ageProvider{}
+	case "vault":
+		return vaultProvider{}
+	default:
+		return keyringProvider{}
+	}
+}
+
+// Resolve fetches the IMAP app password using whichever backend
+// cfg.Email.SecretBackend selects. Scrapers call this instead of
+// reading a password out of cfg directly.
+func Resolve(cfg config.Config) (string, error) {
+	return ProviderFor(cfg.Email.SecretBackend).Get(cfg)
+}
@@ -0,0 +1,149 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"jobhunt-engine/internal/config"
+)
+
+// vaultProvider reads the password from a HashiCorp Vault KV v2 secret
+// engine over the plain HTTP API, rather than pulling in the full
+// Vault Go SDK for one GET request. Authenticates with $VAULT_TOKEN if
+// set, otherwise falls back to an AppRole login using
+// Email.VaultRoleID and the secret ID stored in Email.VaultSecretIDFile.
+type vaultProvider struct{}
+
+var vaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+func (vaultProvider) Name() string { return "vault" }
+
+func (vaultProvider) Get(cfg config.Config) (string, error) {
+	addr := strings.TrimRight(strings.TrimSpace(cfg.Email.VaultAddr), "/")
+	if addr == "" {
+		return "", fmt.Errorf("secrets: email.vault_addr is not set")
+	}
+	mount := strings.Trim(strings.TrimSpace(cfg.Email.VaultMount), "/")
+	if mount == "" {
+		mount = "secret"
+	}
+	path := strings.Trim(strings.TrimSpace(cfg.Email.VaultPath), "/")
+	if path == "" {
+		return "", fmt.Errorf("secrets: email.vault_path is not set")
+	}
+	field := strings.TrimSpace(cfg.Email.VaultField)
+	if field == "" {
+		field = "password"
+	}
+
+	token, err := vaultToken(cfg, addr)
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/data/%s", addr, mount, path)
+	var body struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := vaultRequest(http.MethodGet, url, token, nil, &body); err != nil {
+		return "", fmt.Errorf("secrets: vault read %s: %w", path, err)
+	}
+
+	raw, ok := body.Data.Data[field]
+	if !ok {
+		return "", fmt.Errorf("secrets: vault secret %s has no field %q", path, field)
+	}
+	pw, ok := raw.(string)
+	if !ok || strings.TrimSpace(pw) == "" {
+		return "", fmt.Errorf("secrets: vault secret %s field %q is empty or not a string", path, field)
+	}
+	return pw, nil
+}
+
+func (vaultProvider) Set(cfg config.Config, password string) error {
+	return fmt.Errorf("secrets: vault backend is read-only from the engine, write the secret with `vault kv put` yourself: %w", ErrNotSupported)
+}
+
+func (vaultProvider) Delete(cfg config.Config) error {
+	return fmt.Errorf("secrets: vault backend is read-only from the engine, remove the secret with `vault kv delete` yourself: %w", ErrNotSupported)
+}
+
+// vaultToken returns the Vault token to authenticate with, logging in
+// via AppRole if $VAULT_TOKEN isn't set.
+func vaultToken(cfg config.Config, addr string) (string, error) {
+	if tok := strings.TrimSpace(os.Getenv("VAULT_TOKEN")); tok != "" {
+		return tok, nil
+	}
+
+	roleID := strings.TrimSpace(cfg.Email.VaultRoleID)
+	secretIDFile := strings.TrimSpace(cfg.Email.VaultSecretIDFile)
+	if roleID == "" || secretIDFile == "" {
+		return "", fmt.Errorf("secrets: no $VAULT_TOKEN and email.vault_role_id/vault_secret_id_file not set")
+	}
+	secretIDBytes, err := os.ReadFile(secretIDFile)
+	if err != nil {
+		return "", fmt.Errorf("secrets: read vault secret id file: %w", err)
+	}
+	secretID := strings.TrimSpace(string(secretIDBytes))
+
+	loginReq := map[string]string{"role_id": roleID, "secret_id": secretID}
+	var loginResp struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	url := fmt.Sprintf("%s/v1/auth/approle/login", addr)
+	if err := vaultRequest(http.MethodPost, url, "", loginReq, &loginResp); err != nil {
+		return "", fmt.Errorf("secrets: vault approle login: %w", err)
+	}
+	if loginResp.Auth.ClientToken == "" {
+		return "", fmt.Errorf("secrets: vault approle login returned no token")
+	}
+	return loginResp.Auth.ClientToken, nil
+}
+
+// vaultRequest performs a Vault HTTP API call and decodes the JSON
+// response body into out (if non-nil).
+func vaultRequest(method, url, token string, reqBody any, out any) error {
+	var bodyReader *strings.Reader
+	if reqBody != nil {
+		b, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		bodyReader = strings.NewReader(string(b))
+	} else {
+		bodyReader = strings.NewReader("")
+	}
+
+	req, err := http.NewRequest(method, url, bodyReader)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := vaultHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("vault returned status %d", resp.StatusCode)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// ATSCheckpoint is the persisted pagination progress for one (source,
+// slug) ATS fetch, e.g. smartrecruiters.fetchCompany resuming a
+// multi-thousand-posting company across polls instead of rescanning
+// every page from offset 0 each time.
+type ATSCheckpoint struct {
+	Source           string
+	Slug             string
+	Offset           int
+	LastReleasedDate string
+	ETag             string
+	LastModified     string
+	UpdatedAt        string
+}
+
+// GetATSCheckpoint returns the last-persisted checkpoint for (source,
+// slug), or the zero value if this is the first run.
+func GetATSCheckpoint(ctx context.Context, db *sql.DB, source, slug string) (ATSCheckpoint, error) {
+	c := ATSCheckpoint{Source: source, Slug: slug}
+	err := db.QueryRowContext(ctx, `
+SELECT offset_val, last_released_date, etag, last_modified, updated_at
+FROM ats_scrape_checkpoints
+WHERE source = ? AND slug = ?
+LIMIT 1;
+`, source, slug).Scan(&c.Offset, &c.LastReleasedDate, &c.ETag, &c.LastModified, &c.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ATSCheckpoint{Source: source, Slug: slug}, nil
+	}
+	if err != nil {
+		return ATSCheckpoint{}, err
+	}
+	return c, nil
+}
+
+// SetATSCheckpoint persists c as (source, slug)'s latest pagination
+// progress so the next fetchCompany run resumes from it.
+func SetATSCheckpoint(ctx context.Context, db *sql.DB, source, slug string, c ATSCheckpoint) error {
+	_, err := db.ExecContext(ctx, `
+INSERT INTO ats_scrape_checkpoints(source, slug, offset_val, last_released_date, etag, last_modified, updated_at)
+VALUES(?,?,?,?,?,?,?)
+ON CONFLICT(source, slug) DO UPDATE SET
+  offset_val = excluded.offset_val,
+  last_released_date = excluded.last_released_date,
+  etag = excluded.etag,
+  last_modified = excluded.last_modified,
+  updated_at = excluded.updated_at;
+`, source, slug, c.Offset, c.LastReleasedDate, c.ETag, c.LastModified, time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// ResetATSCheckpoint deletes the persisted checkpoint for (source,
+// slug), so the next fetchCompany run starts over from offset 0. If
+// slug is "", every checkpoint for source is cleared.
+func ResetATSCheckpoint(ctx context.Context, db *sql.DB, source, slug string) error {
+	if slug == "" {
+		_, err := db.ExecContext(ctx, `DELETE FROM ats_scrape_checkpoints WHERE source = ?;`, source)
+		return err
+	}
+	_, err := db.ExecContext(ctx, `DELETE FROM ats_scrape_checkpoints WHERE source = ? AND slug = ?;`, source, slug)
+	return err
+}
+
+// ListATSCheckpoints returns every persisted checkpoint for source, for
+// surfacing progress via /scrape/status.
+func ListATSCheckpoints(ctx context.Context, db *sql.DB, source string) ([]ATSCheckpoint, error) {
+	rows, err := db.QueryContext(ctx, `
+SELECT slug, offset_val, last_released_date, etag, last_modified, updated_at
+FROM ats_scrape_checkpoints
+WHERE source = ?
+ORDER BY slug;
+`, source)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ATSCheckpoint
+	for rows.Next() {
+		c := ATSCheckpoint{Source: source}
+		if err := rows.Scan(&c.Slug, &c.Offset, &c.LastReleasedDate, &c.ETag, &c.LastModified, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,156 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	scrapeutil "jobhunt-engine/internal/scrape/util"
+)
+
+// BackupJob is one row of the GET /jobs/backup NDJSON stream: a job
+// plus the two fields ImportJobs dedupes on - ATSJobID (the jobs
+// table's source_id column) and URL, which ImportJobs re-canonicalizes
+// with scrapeutil.CanonicalizeURL the same way a scrape run does.
+// Feedback is this schema's only per-job "notes" field.
+type BackupJob struct {
+	ATSJobID string   `json:"atsJobId"`
+	Company  string   `json:"company"`
+	Title    string   `json:"title"`
+	Location string   `json:"location"`
+	WorkMode string   `json:"workMode"`
+	URL      string   `json:"url"`
+	Score    int      `json:"score"`
+	Tags     []string `json:"tags"`
+	Date     string   `json:"date"`
+	LogoKey  string   `json:"logoKey"`
+	Feedback string   `json:"feedback"`
+}
+
+// ExportJobs streams every jobs row, oldest id first, as a BackupJob,
+// calling yield once per row so a caller (GET /jobs/backup, the
+// "backup" Spec job) can write NDJSON straight out without buffering
+// the whole table in memory. Stops and returns yield's error, if any.
+func ExportJobs(ctx context.Context, db *sql.DB, yield func(BackupJob) error) (n int, err error) {
+	rows, err := db.QueryContext(ctx, `
+SELECT source_id, company, title, location, work_mode, url, score, tags, date, logo_key, feedback
+FROM jobs
+ORDER BY id;
+`)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var j BackupJob
+		var tagsJSON string
+		if err := rows.Scan(
+			&j.ATSJobID, &j.Company, &j.Title, &j.Location, &j.WorkMode,
+			&j.URL, &j.Score, &tagsJSON, &j.Date, &j.LogoKey, &j.Feedback,
+		); err != nil {
+			return n, err
+		}
+		_ = json.Unmarshal([]byte(tagsJSON), &j.Tags)
+		if err := yield(j); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+// ImportJobs decodes one BackupJob per line from r (the format
+// ExportJobs writes) and inserts each into db inside a single
+// transaction, skipping any row whose ATSJobID or canonicalized URL
+// already exists - the same source_id uniqueness InsertJobIfNew relies
+// on for live scrapes, plus a URL check since a restored row's
+// ATSJobID is sometimes empty (leads scraped before source IDs existed).
+// progress, if non-nil, is called every progressEvery inserted rows
+// with the running totals, so a caller can publish a mid-import event.
+func ImportJobs(ctx context.Context, db *sql.DB, r io.Reader, progressEvery int, progress func(seen, inserted int)) (seen, inserted int, err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	bySourceID, byURL, err := loadExistingJobKeys(ctx, tx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		var j BackupJob
+		if err := dec.Decode(&j); err != nil {
+			return seen, inserted, fmt.Errorf("decode backup row %d: %w", seen+1, err)
+		}
+		seen++
+
+		canon := scrapeutil.CanonicalizeURL(j.URL)
+		if j.ATSJobID != "" && bySourceID[j.ATSJobID] {
+			continue
+		}
+		if canon != "" && byURL[canon] {
+			continue
+		}
+
+		tagsB, _ := json.Marshal(j.Tags)
+		if _, err := tx.ExecContext(ctx, `
+INSERT OR IGNORE INTO jobs(company, title, location, work_mode, url, score, tags, date, source_id, logo_key, feedback)
+VALUES(?,?,?,?,?,?,?,?,?,?,?);
+`,
+			j.Company, j.Title, j.Location, j.WorkMode, j.URL, j.Score,
+			string(tagsB), j.Date, j.ATSJobID, j.LogoKey, j.Feedback,
+		); err != nil {
+			return seen, inserted, fmt.Errorf("insert backup row %d: %w", seen, err)
+		}
+		inserted++
+
+		if j.ATSJobID != "" {
+			bySourceID[j.ATSJobID] = true
+		}
+		if canon != "" {
+			byURL[canon] = true
+		}
+
+		if progress != nil && progressEvery > 0 && inserted%progressEvery == 0 {
+			progress(seen, inserted)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return seen, inserted, err
+	}
+	return seen, inserted, nil
+}
+
+// loadExistingJobKeys snapshots every current source_id and
+// canonicalized url within tx, so ImportJobs can dedupe in memory
+// instead of a query per incoming row.
+func loadExistingJobKeys(ctx context.Context, tx *sql.Tx) (bySourceID, byURL map[string]bool, err error) {
+	rows, err := tx.QueryContext(ctx, `SELECT source_id, url FROM jobs;`)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	bySourceID = map[string]bool{}
+	byURL = map[string]bool{}
+	for rows.Next() {
+		var sourceID, rawURL string
+		if err := rows.Scan(&sourceID, &rawURL); err != nil {
+			return nil, nil, err
+		}
+		if sourceID != "" {
+			bySourceID[sourceID] = true
+		}
+		if canon := scrapeutil.CanonicalizeURL(rawURL); canon != "" {
+			byURL[canon] = true
+		}
+	}
+	return bySourceID, byURL, rows.Err()
+}
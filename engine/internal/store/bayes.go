@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+// BayesTokenStats is one bayes_tokens row: how many times token has
+// appeared in a job a user marked positive (interested/applied) versus
+// negative (dismissed).
+type BayesTokenStats struct {
+	Positive int64
+	Negative int64
+}
+
+// IncrementBayesTokens bumps positive's (or negative's) count for
+// every token by how many times it occurs in tokens, upserting new
+// tokens starting from 0/0.
+func IncrementBayesTokens(ctx context.Context, db *sql.DB, tokens []string, positive bool) error {
+	counts := map[string]int64{}
+	for _, t := range tokens {
+		if t == "" {
+			continue
+		}
+		counts[t]++
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+
+	posCol, negCol := int64(0), int64(1)
+	if positive {
+		posCol, negCol = 1, 0
+	}
+
+	for tok, n := range counts {
+		if _, err := db.ExecContext(ctx, `
+INSERT INTO bayes_tokens(token, positive, negative) VALUES(?,?,?)
+ON CONFLICT(token) DO UPDATE SET
+  positive = positive + excluded.positive,
+  negative = negative + excluded.negative;
+`, tok, posCol*n, negCol*n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadBayesTokens returns every bayes_tokens row, for
+// rank.BayesScorer.Load to snapshot into memory.
+func LoadBayesTokens(ctx context.Context, db *sql.DB) (map[string]BayesTokenStats, error) {
+	rows, err := db.QueryContext(ctx, `SELECT token, positive, negative FROM bayes_tokens;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[string]BayesTokenStats{}
+	for rows.Next() {
+		var tok string
+		var c BayesTokenStats
+		if err := rows.Scan(&tok, &c.Positive, &c.Negative); err != nil {
+			return nil, err
+		}
+		out[tok] = c
+	}
+	return out, rows.Err()
+}
+
+// ResetBayesTokens deletes every learned token, for /api/bayes/reset.
+func ResetBayesTokens(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM bayes_tokens;`)
+	return err
+}
+
+// SetJobFeedback records the user's relevance signal for job id
+// ("interested", "applied", "dismissed", or "" to clear it) and
+// returns the job's title and tags so the caller can retrain
+// bayes_tokens from it without a second query. Returns sql.ErrNoRows
+// if id doesn't exist.
+func SetJobFeedback(ctx context.Context, db *sql.DB, id int64, feedback string) (title string, tags []string, err error) {
+	res, err := db.ExecContext(ctx, `UPDATE jobs SET feedback = ? WHERE id = ?;`, feedback, id)
+	if err != nil {
+		return "", nil, err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return "", nil, sql.ErrNoRows
+	}
+
+	var tagsJSON string
+	if err := db.QueryRowContext(ctx, `SELECT title, tags FROM jobs WHERE id = ?;`, id).Scan(&title, &tagsJSON); err != nil {
+		return "", nil, err
+	}
+	_ = json.Unmarshal([]byte(tagsJSON), &tags)
+	return title, tags, nil
+}
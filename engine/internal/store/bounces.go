@@ -0,0 +1,57 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Bounce is one bounces row: a delivery-failure report for outbound
+// application/watch email, tied back to the jobs row with a matching
+// source_id.
+type Bounce struct {
+	SourceID   string
+	Reason     string
+	Type       string // "hard" or "soft"
+	ReceivedAt time.Time
+}
+
+// InsertBounce records b.
+func InsertBounce(ctx context.Context, db *sql.DB, b Bounce) error {
+	_, err := db.ExecContext(ctx, `
+INSERT INTO bounces(source_id, reason, type, received_at)
+VALUES(?,?,?,?);
+`, b.SourceID, b.Reason, b.Type, b.ReceivedAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// CompanyForSourceID returns the company of the jobs row with
+// source_id, or "" if no job matches (a bounce for a since-deleted or
+// unrecognized source is still recorded; it just can't feed
+// auto-blocking).
+func CompanyForSourceID(ctx context.Context, db *sql.DB, sourceID string) (string, error) {
+	var company string
+	err := db.QueryRowContext(ctx,
+		`SELECT company FROM jobs WHERE source_id = ? LIMIT 1;`, sourceID,
+	).Scan(&company)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return company, nil
+}
+
+// CountHardBounces returns how many hard bounces are on record across
+// every job belonging to company.
+func CountHardBounces(ctx context.Context, db *sql.DB, company string) (int, error) {
+	var n int
+	err := db.QueryRowContext(ctx, `
+SELECT COUNT(*)
+FROM bounces b
+JOIN jobs j ON j.source_id = b.source_id
+WHERE b.type = 'hard' AND j.company = ?;
+`, company).Scan(&n)
+	return n, err
+}
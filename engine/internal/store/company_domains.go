@@ -7,6 +7,15 @@ import (
 	"time"
 )
 
+// CompanyDomainCandidate is one provider's vote for a company's domain,
+// recorded alongside the winner so resolver weights can be tuned from
+// real data later.
+type CompanyDomainCandidate struct {
+	Provider string
+	Domain   string
+	Weight   int
+}
+
 // GetCompanyDomain returns cached domain or "" if missing.
 func GetCompanyDomain(ctx context.Context, db *sql.DB, company string) (string, error) {
 	company = normalizeCompanyKey(company)
@@ -29,7 +38,7 @@ func GetCompanyDomain(ctx context.Context, db *sql.DB, company string) (string,
 	return strings.TrimSpace(domain), nil
 }
 
-func UpsertCompanyDomain(ctx context.Context, db *sql.DB, company, domain string) error {
+func UpsertCompanyDomain(ctx context.Context, db *sql.DB, company, domain, provider string) error {
 	company = normalizeCompanyKey(company)
 	domain = strings.ToLower(strings.TrimSpace(domain))
 
@@ -38,16 +47,70 @@ func UpsertCompanyDomain(ctx context.Context, db *sql.DB, company, domain string
 	}
 
 	_, err := db.ExecContext(ctx, `
-INSERT INTO company_domains(company, domain, fetched_at)
-VALUES(?,?,?)
+INSERT INTO company_domains(company, domain, provider, fetched_at)
+VALUES(?,?,?,?)
 ON CONFLICT(company) DO UPDATE SET
   domain = excluded.domain,
+  provider = excluded.provider,
   fetched_at = excluded.fetched_at;
-`, company, domain, time.Now().UTC().Format(time.RFC3339))
+`, company, domain, provider, time.Now().UTC().Format(time.RFC3339))
 
 	return err
 }
 
+// InsertCompanyDomainCandidates records every provider's vote for
+// company's domain in this resolution pass (not just the winner), so
+// resolver weights can be tuned against real vote distributions.
+func InsertCompanyDomainCandidates(ctx context.Context, db *sql.DB, company string, candidates []CompanyDomainCandidate) error {
+	company = normalizeCompanyKey(company)
+	if company == "" || len(candidates) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, c := range candidates {
+		if _, err := db.ExecContext(ctx, `
+INSERT INTO company_domain_candidates(company, provider, domain, weight, fetched_at)
+VALUES(?,?,?,?,?);
+`, company, c.Provider, strings.ToLower(strings.TrimSpace(c.Domain)), c.Weight, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DefaultDomainRefreshDays is used when a caller passes olderThanDays
+// <= 0 to StaleCompanyDomains.
+const DefaultDomainRefreshDays = 90
+
+// StaleCompanyDomains returns every company_domains.company value whose
+// fetched_at is older than olderThanDays, oldest first - a resolved
+// domain is otherwise cached forever, so this is what lets a scheduled
+// sweep notice a company that's since moved to a new domain.
+func StaleCompanyDomains(ctx context.Context, db *sql.DB, olderThanDays int) ([]string, error) {
+	if olderThanDays <= 0 {
+		olderThanDays = DefaultDomainRefreshDays
+	}
+	cutoff := time.Now().UTC().AddDate(0, 0, -olderThanDays).Format(time.RFC3339)
+	rows, err := db.QueryContext(ctx,
+		`SELECT company FROM company_domains WHERE fetched_at < ? ORDER BY fetched_at ASC;`, cutoff,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var companies []string
+	for rows.Next() {
+		var company string
+		if err := rows.Scan(&company); err != nil {
+			return nil, err
+		}
+		companies = append(companies, company)
+	}
+	return companies, rows.Err()
+}
+
 func normalizeCompanyKey(s string) string {
 	s = strings.TrimSpace(s)
 	s = strings.Join(strings.Fields(s), " ")
@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// GetConnectorCache returns the last conditional-GET metadata and
+// response body cached for (connectorType, cacheKey) - e.g.
+// ("greenhouse", "boards-api.greenhouse.io/v1/boards/acme/jobs") - so
+// a Connector can send If-None-Match/If-Modified-Since on its next
+// request and, on a 304, reparse body instead of refetching an
+// unchanged board. found=false if nothing's cached yet.
+func GetConnectorCache(ctx context.Context, db *sql.DB, connectorType, cacheKey string) (etag, lastModified string, body []byte, found bool, err error) {
+	err = db.QueryRowContext(ctx, `
+SELECT etag, last_modified, body FROM connector_cache
+WHERE connector_type = ? AND cache_key = ?
+LIMIT 1;
+`, connectorType, cacheKey).Scan(&etag, &lastModified, &body)
+	if err == sql.ErrNoRows {
+		return "", "", nil, false, nil
+	}
+	if err != nil {
+		return "", "", nil, false, err
+	}
+	return etag, lastModified, body, true, nil
+}
+
+// PutConnectorCache upserts the conditional-GET metadata and response
+// body for (connectorType, cacheKey) after a fresh 200 response.
+func PutConnectorCache(ctx context.Context, db *sql.DB, connectorType, cacheKey, etag, lastModified string, body []byte) error {
+	_, err := db.ExecContext(ctx, `
+INSERT INTO connector_cache(connector_type, cache_key, etag, last_modified, body, fetched_at)
+VALUES(?,?,?,?,?,?)
+ON CONFLICT(connector_type, cache_key) DO UPDATE SET
+  etag = excluded.etag,
+  last_modified = excluded.last_modified,
+  body = excluded.body,
+  fetched_at = excluded.fetched_at;
+`, connectorType, cacheKey, etag, lastModified, body, time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// ConnectorCache adapts GetConnectorCache/PutConnectorCache to the
+// shape ats.ConditionalCache expects, so callers can build one as
+// store.ConnectorCache{DB: db} and hand it to any Connector needing a
+// conditional-GET cache. It satisfies that interface structurally;
+// this package imports internal/ingest/ats only for Discovered
+// (discovered_ats.go).
+type ConnectorCache struct {
+	DB *sql.DB
+}
+
+func (c ConnectorCache) Get(ctx context.Context, connectorType, cacheKey string) (etag, lastModified string, body []byte, found bool, err error) {
+	return GetConnectorCache(ctx, c.DB, connectorType, cacheKey)
+}
+
+func (c ConnectorCache) Put(ctx context.Context, connectorType, cacheKey, etag, lastModified string, body []byte) error {
+	return PutConnectorCache(ctx, c.DB, connectorType, cacheKey, etag, lastModified, body)
+}
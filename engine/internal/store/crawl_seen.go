@@ -0,0 +1,40 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"time"
+)
+
+// HashCrawlURL returns the crawl_seen primary key for url: a hex
+// SHA-256 digest, so the visited-set index stays small and fixed-width
+// regardless of how long the crawled URL is.
+func HashCrawlURL(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
+// CrawlSeen reports whether url (or rather its HashCrawlURL) is
+// already recorded in crawl_seen, so webcrawl.Crawler can skip
+// re-fetching pages a prior run already visited.
+func CrawlSeen(ctx context.Context, db *sql.DB, url string) (bool, error) {
+	var n int
+	err := db.QueryRowContext(ctx, `SELECT COUNT(1) FROM crawl_seen WHERE url_hash = ?;`, HashCrawlURL(url)).Scan(&n)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// MarkCrawlSeen records url as visited (or refreshes last_seen if it
+// already was), so a restarted crawl doesn't revisit it.
+func MarkCrawlSeen(ctx context.Context, db *sql.DB, url string) error {
+	_, err := db.ExecContext(ctx, `
+INSERT INTO crawl_seen(url_hash, url, last_seen)
+VALUES(?,?,?)
+ON CONFLICT(url_hash) DO UPDATE SET last_seen = excluded.last_seen;
+`, HashCrawlURL(url), url, time.Now().UTC().Format(time.RFC3339))
+	return err
+}
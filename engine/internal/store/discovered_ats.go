@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"jobhunt-engine/internal/ingest/ats"
+)
+
+// DiscoveredATS is one row of discovered_ats: an ATS board slug
+// scrape.DiscoverATS found while crawling company's own site.
+type DiscoveredATS struct {
+	Company      string
+	ATSType      string
+	Slug         string
+	Confidence   int
+	DiscoveredAt string
+}
+
+// InsertDiscoveredATS records every board scrape.DiscoverATS found for
+// company, replacing any prior row for the same (company, ats_type,
+// slug) so confidence/discovered_at stay current on re-crawl.
+func InsertDiscoveredATS(ctx context.Context, db *sql.DB, company string, found []ats.Discovered) error {
+	company = normalizeCompanyKey(company)
+	if company == "" || len(found) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, d := range found {
+		if _, err := db.ExecContext(ctx, `
+INSERT INTO discovered_ats(company, ats_type, slug, confidence, discovered_at)
+VALUES(?,?,?,?,?)
+ON CONFLICT(company, ats_type, slug) DO UPDATE SET
+  confidence = excluded.confidence,
+  discovered_at = excluded.discovered_at;
+`, company, d.ATSType, strings.TrimSpace(d.Slug), d.Confidence, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListDiscoveredATS returns every discovered_ats row, highest confidence first.
+func ListDiscoveredATS(ctx context.Context, db *sql.DB) ([]DiscoveredATS, error) {
+	rows, err := db.QueryContext(ctx, `
+SELECT company, ats_type, slug, confidence, discovered_at
+FROM discovered_ats
+ORDER BY confidence DESC;
+`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []DiscoveredATS
+	for rows.Next() {
+		var d DiscoveredATS
+		if err := rows.Scan(&d.Company, &d.ATSType, &d.Slug, &d.Confidence, &d.DiscoveredAt); err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// GetJMAPState returns the last-seen JMAP `state` string persisted for
+// account (keyed by the feed's session endpoint), or "" if this is the
+// first run. jmap_scrape.RunJMAPScrapeOnce uses "" to mean "do a full
+// Email/query" versus passing a non-empty state to Email/changes.
+func GetJMAPState(ctx context.Context, db *sql.DB, account string) (string, error) {
+	var state string
+	err := db.QueryRowContext(ctx,
+		`SELECT state FROM jmap_sync_state WHERE account = ? LIMIT 1;`, account,
+	).Scan(&state)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return state, nil
+}
+
+// SetJMAPState persists state as account's last-seen JMAP state, so the
+// next run's Email/changes only returns what's changed since.
+func SetJMAPState(ctx context.Context, db *sql.DB, account, state string) error {
+	_, err := db.ExecContext(ctx, `
+INSERT INTO jmap_sync_state(account, state, updated_at)
+VALUES(?,?,?)
+ON CONFLICT(account) DO UPDATE SET
+  state = excluded.state,
+  updated_at = excluded.updated_at;
+`, account, state, time.Now().UTC().Format(time.RFC3339))
+	return err
+}
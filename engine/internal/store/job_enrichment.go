@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// UpdateJobEnrichment writes the page-extraction fields (see
+// internal/scrape/enrich/pageextract) onto an existing jobs row,
+// identified by source_id - the same identifier ATS-sourced jobs
+// already dedupe on. location is only applied when the row's existing
+// value is empty/"Unknown", since the original lead's location is
+// usually more precise than what a generic career-page scrape finds;
+// the salary/employment-type/date-posted columns have no such
+// fallback, so they're simply overwritten.
+func UpdateJobEnrichment(ctx context.Context, db *sql.DB, sourceID string, location, baseSalary, employmentType, datePosted string) error {
+	_, err := db.ExecContext(ctx, `
+UPDATE jobs
+SET location = CASE WHEN location = '' OR location = 'Unknown' THEN ? ELSE location END,
+    base_salary = ?,
+    employment_type = ?,
+    date_posted = ?
+WHERE source_id = ?;
+`, location, baseSalary, employmentType, datePosted, sourceID)
+	return err
+}
+
+// UpsertJobDescription stores a posting's fetched page (see
+// pageextract.Extracted.DescriptionHTML) and its plain-text extraction
+// keyed by source_id, so the ranker can re-score a job's description
+// later without re-fetching its page. It also mirrors text onto
+// jobs.description, since that's the column jobs_fts indexes - the
+// full html/text pair lives here in job_descriptions rather than on
+// jobs itself because most callers never need it.
+func UpsertJobDescription(ctx context.Context, db *sql.DB, sourceID, htmlBody, text string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `
+INSERT INTO job_descriptions(source_id, html, text, fetched_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT(source_id) DO UPDATE SET
+  html = excluded.html,
+  text = excluded.text,
+  fetched_at = excluded.fetched_at;
+`, sourceID, htmlBody, text, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+UPDATE jobs SET description = ? WHERE source_id = ?;
+`, text, sourceID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetJobDescription returns the html/text stashed by
+// UpsertJobDescription for sourceID, or ("", "", nil) if nothing has
+// been fetched for it yet.
+func GetJobDescription(ctx context.Context, db *sql.DB, sourceID string) (htmlBody, text string, err error) {
+	err = db.QueryRowContext(ctx, `
+SELECT html, text FROM job_descriptions WHERE source_id = ?;
+`, sourceID).Scan(&htmlBody, &text)
+	if err == sql.ErrNoRows {
+		return "", "", nil
+	}
+	return htmlBody, text, err
+}
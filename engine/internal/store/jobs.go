@@ -0,0 +1,203 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// JobStatus is a job_runs row's lifecycle state. Table name is
+// job_runs, not jobs, since "jobs" already names the job-postings
+// table - this is the scheduler's own queue of work items, not a
+// posting a user sees in the UI.
+type JobStatus string
+
+const (
+	JobPending    JobStatus = "Pending"
+	JobInProgress JobStatus = "InProgress"
+	JobSuccess    JobStatus = "Success"
+	JobError      JobStatus = "Error"
+	JobCanceled   JobStatus = "Canceled"
+)
+
+// JobRecord is one job_runs row: a unit of work for internal/jobs'
+// Server to dispatch to a registered Worker.
+type JobRecord struct {
+	ID             int64
+	Type           string
+	Status         JobStatus
+	Data           []byte // JSON, decoded by whichever Worker owns Type
+	Progress       string
+	RetryCount     int
+	CreatedAt      time.Time
+	StartAt        time.Time
+	LastActivityAt time.Time
+}
+
+// EnqueueJob inserts a new Pending job_runs row for jobType, startable
+// immediately (start_at = now), and returns its id.
+func EnqueueJob(ctx context.Context, db *sql.DB, jobType string, data []byte) (int64, error) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	res, err := db.ExecContext(ctx, `
+INSERT INTO job_runs(type, status, data, progress, retry_count, created_at, start_at, last_activity_at)
+VALUES(?,?,?,'',0,?,?,?);
+`, jobType, string(JobPending), string(data), now, now, now)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// ClaimNextPendingJob atomically claims the oldest startable (start_at
+// <= now) Pending row of jobType - the SELECT and the flip to
+// InProgress happen in one transaction, so two dispatch loops (or two
+// JobServer processes against the same DB) never claim the same row.
+// Returns (nil, nil) if there's nothing to claim.
+func ClaimNextPendingJob(ctx context.Context, db *sql.DB, jobType string) (*JobRecord, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	var j JobRecord
+	var status, data, createdAt, startAt string
+	err = tx.QueryRowContext(ctx, `
+SELECT id, type, status, data, progress, retry_count, created_at, start_at
+FROM job_runs
+WHERE type = ? AND status = ? AND start_at <= ?
+ORDER BY id
+LIMIT 1;
+`, jobType, string(JobPending), now).Scan(
+		&j.ID, &j.Type, &status, &data, &j.Progress, &j.RetryCount, &createdAt, &startAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+UPDATE job_runs SET status = ?, last_activity_at = ? WHERE id = ?;
+`, string(JobInProgress), now, j.ID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	j.Status = JobInProgress
+	j.Data = []byte(data)
+	j.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	j.StartAt, _ = time.Parse(time.RFC3339, startAt)
+	j.LastActivityAt, _ = time.Parse(time.RFC3339, now)
+	return &j, nil
+}
+
+// RetryJob records a failed-but-retryable attempt: retry_count
+// increments, progress holds errMsg, and the row goes back to Pending
+// with start_at pushed out by backoff so ClaimNextPendingJob won't
+// pick it up again until then.
+func RetryJob(ctx context.Context, db *sql.DB, id int64, errMsg string, backoff time.Duration) error {
+	now := time.Now().UTC()
+	_, err := db.ExecContext(ctx, `
+UPDATE job_runs
+SET status = ?, progress = ?, retry_count = retry_count + 1, start_at = ?, last_activity_at = ?
+WHERE id = ?;
+`, string(JobPending), errMsg, now.Add(backoff).Format(time.RFC3339), now.Format(time.RFC3339), id)
+	return err
+}
+
+// FailJob marks a job Error - its Worker's MaxRetries was exhausted.
+func FailJob(ctx context.Context, db *sql.DB, id int64, errMsg string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	_, err := db.ExecContext(ctx, `
+UPDATE job_runs SET status = ?, progress = ?, last_activity_at = ? WHERE id = ?;
+`, string(JobError), errMsg, now, id)
+	return err
+}
+
+// SucceedJob marks a job Success.
+func SucceedJob(ctx context.Context, db *sql.DB, id int64) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	_, err := db.ExecContext(ctx, `
+UPDATE job_runs SET status = ?, progress = '', last_activity_at = ? WHERE id = ?;
+`, string(JobSuccess), now, id)
+	return err
+}
+
+// ReclaimStaleJobs flips InProgress rows whose last_activity_at is
+// older than staleThreshold back to Pending (startable immediately),
+// for JobServer.Start to call on launch so a worker process that died
+// mid-job doesn't strand its row forever.
+func ReclaimStaleJobs(ctx context.Context, db *sql.DB, staleThreshold time.Duration) (int64, error) {
+	now := time.Now().UTC()
+	cutoff := now.Add(-staleThreshold).Format(time.RFC3339)
+	res, err := db.ExecContext(ctx, `
+UPDATE job_runs
+SET status = ?, start_at = ?, last_activity_at = ?
+WHERE status = ? AND last_activity_at <= ?;
+`, string(JobPending), now.Format(time.RFC3339), now.Format(time.RFC3339), string(JobInProgress), cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// JobListOpts filters ListJobRuns. Type/Status empty means "don't filter
+// on this field".
+type JobListOpts struct {
+	Type   string
+	Status JobStatus
+	Limit  int
+}
+
+// ListJobRuns returns job_runs rows matching opts, newest first.
+func ListJobRuns(ctx context.Context, db *sql.DB, opts JobListOpts) ([]JobRecord, error) {
+	where := "WHERE 1=1"
+	var args []any
+	if opts.Type != "" {
+		where += " AND type = ?"
+		args = append(args, opts.Type)
+	}
+	if opts.Status != "" {
+		where += " AND status = ?"
+		args = append(args, string(opts.Status))
+	}
+	limit := opts.Limit
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	args = append(args, limit)
+
+	rows, err := db.QueryContext(ctx, `
+SELECT id, type, status, data, progress, retry_count, created_at, start_at, last_activity_at
+FROM job_runs
+`+where+`
+ORDER BY id DESC
+LIMIT ?;
+`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []JobRecord
+	for rows.Next() {
+		var j JobRecord
+		var status, data, createdAt, startAt, lastActivityAt string
+		if err := rows.Scan(&j.ID, &j.Type, &status, &data, &j.Progress, &j.RetryCount, &createdAt, &startAt, &lastActivityAt); err != nil {
+			return nil, err
+		}
+		j.Status = JobStatus(status)
+		j.Data = []byte(data)
+		j.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		j.StartAt, _ = time.Parse(time.RFC3339, startAt)
+		j.LastActivityAt, _ = time.Parse(time.RFC3339, lastActivityAt)
+		out = append(out, j)
+	}
+	return out, rows.Err()
+}
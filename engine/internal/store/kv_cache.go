@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// KVGet returns key's current value in namespace, or ("", false, nil)
+// if it's missing or has expired. Expired rows aren't deleted here -
+// StartKVCachePurger reclaims them on its own schedule, so a hot
+// lookup path never pays for a DELETE.
+func KVGet(ctx context.Context, db *sql.DB, namespace, key string) (value string, found bool, err error) {
+	err = db.QueryRowContext(ctx, `
+SELECT value FROM kv_cache
+WHERE namespace = ? AND key = ? AND expires_at > ?;
+`, namespace, key, time.Now().UTC().Format(time.RFC3339)).Scan(&value)
+
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+// KVSet upserts value under namespace/key with an expiry ttl from now.
+// value may be "" - callers use this to cache a negative result
+// (company/domain not found) with a shorter ttl, so a retry is tried
+// again later instead of never.
+func KVSet(ctx context.Context, db *sql.DB, namespace, key, value string, ttl time.Duration) error {
+	_, err := db.ExecContext(ctx, `
+INSERT INTO kv_cache(namespace, key, value, expires_at)
+VALUES(?,?,?,?)
+ON CONFLICT(namespace, key) DO UPDATE SET
+  value = excluded.value,
+  expires_at = excluded.expires_at;
+`, namespace, key, value, time.Now().UTC().Add(ttl).Format(time.RFC3339))
+	return err
+}
+
+// KVDelete removes namespace/key, if present.
+func KVDelete(ctx context.Context, db *sql.DB, namespace, key string) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM kv_cache WHERE namespace = ? AND key = ?;`, namespace, key)
+	return err
+}
+
+// KVPurgeExpired deletes every row whose expires_at has passed and
+// returns how many were removed, for StartKVCachePurger's log line.
+func KVPurgeExpired(ctx context.Context, db *sql.DB) (int64, error) {
+	res, err := db.ExecContext(ctx, `DELETE FROM kv_cache WHERE expires_at <= ?;`, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return 0, err
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+)
+
+// DefaultKVCachePurgeInterval is used when StartKVCachePurger is given
+// an interval <= 0.
+const DefaultKVCachePurgeInterval = time.Hour
+
+// StartKVCachePurger launches a background goroutine that deletes
+// expired kv_cache rows every interval (DefaultKVCachePurgeInterval if
+// interval <= 0), the same ticker-loop shape as StartLogoEvictor.
+func StartKVCachePurger(db *sql.DB, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultKVCachePurgeInterval
+	}
+
+	go func() {
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			if n, err := KVPurgeExpired(context.Background(), db); err != nil {
+				log.Printf("[kv-cache] purge error: %v", err)
+			} else if n > 0 {
+				log.Printf("[kv-cache] purged %d expired entries", n)
+			}
+			<-t.C
+		}
+	}()
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"crypto/sha1"
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
@@ -10,16 +11,49 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
+
+	"jobhunt-engine/internal/metrics"
 )
 
+// logoHTTPClient is shared by fetch and revalidation requests.
+var logoHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
 func LogoKeyFromURL(u string) string {
 	h := sha256.Sum256([]byte(u))
 	return hex.EncodeToString(h[:])
 }
 
-func CacheLogoFromURL(ctx context.Context, db *sql.DB, raw string) (key string, err error) {
+// contentETag is a strong ETag derived from the bytes actually being
+// served, rather than whatever (possibly weak, possibly absent) ETag
+// the origin sent - what LogosHandler hands back to browsers and
+// compares If-None-Match against, independent of the origin-facing
+// etag column RevalidateLogo uses for its own conditional GET.
+func contentETag(b []byte) string {
+	sum := sha1.Sum(b)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// logoPath is the content-addressed on-disk location for key: sharded
+// two levels deep by its first two hex characters so a single directory
+// never holds more than ~1/256th of the cache.
+func logoPath(dataDir, key string) string {
+	shard := "_"
+	if len(key) >= 2 {
+		shard = key[:2]
+	}
+	return filepath.Join(dataDir, "logos", shard, key)
+}
+
+// CacheLogoFromURL fetches raw (if host-allowlisted and not already
+// cached), writes its bytes to the content-addressed logo cache on
+// disk under dataDir, and records metadata (content type, ETag,
+// Last-Modified, size) in the logos table so RevalidateLogo and the
+// LRU evictor can work without re-fetching bytes.
+func CacheLogoFromURL(ctx context.Context, db *sql.DB, dataDir, raw string) (key string, err error) {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
 		return "", nil
@@ -55,19 +89,20 @@ func CacheLogoFromURL(ctx context.Context, db *sql.DB, raw string) (key string,
 		return "", nil
 	}
 
-	//log.Printf("[logo-cache] fetch url=%s", raw)
-
 	key = LogoKeyFromURL(raw)
 
-	// If already cached, skip fetch
+	// If already cached, just bump last_accessed_at for the evictor.
 	var exists int
 	e := db.QueryRowContext(ctx, `SELECT 1 FROM logos WHERE key = ? LIMIT 1;`, key).Scan(&exists)
 	if e == nil {
+		metrics.LogoCacheHitsTotal.Inc()
+		touchLogoAccess(ctx, db, key)
 		return key, nil
 	}
 	if e != sql.ErrNoRows {
 		return "", e
 	}
+	metrics.LogoCacheMissesTotal.Inc()
 
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, raw, nil)
 	req.Header.Set("User-Agent", "Mozilla/5.0")
@@ -75,8 +110,7 @@ func CacheLogoFromURL(ctx context.Context, db *sql.DB, raw string) (key string,
 	req.Header.Set("Referer", "https://www.linkedin.com/")
 	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
 
-	client := &http.Client{Timeout: 15 * time.Second}
-	resp, err := client.Do(req)
+	resp, err := logoHTTPClient.Do(req)
 	if err != nil {
 		log.Printf("[logo-cache] fetch error url=%s err=%v", raw, err)
 		return "", nil
@@ -88,7 +122,7 @@ func CacheLogoFromURL(ctx context.Context, db *sql.DB, raw string) (key string,
 		return "", nil
 	}
 
-	// Limit size (protect DB)
+	// Limit size (protect disk)
 	const max = 512 * 1024 // 512KB
 	b, err := io.ReadAll(io.LimitReader(resp.Body, max+1))
 	if err != nil {
@@ -108,13 +142,23 @@ func CacheLogoFromURL(ctx context.Context, db *sql.DB, raw string) (key string,
 		ct = sn
 	}
 
+	if err := writeLogoFile(dataDir, key, b); err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
 	_, err = db.ExecContext(ctx, `
-INSERT OR REPLACE INTO logos(key, content_type, bytes, fetched_at)
-VALUES(?,?,?,?);`,
+INSERT OR REPLACE INTO logos(key, content_type, source_url, etag, content_etag, last_modified, fetched_at, size, last_accessed_at)
+VALUES(?,?,?,?,?,?,?,?,?);`,
 		key,
 		ct,
-		b,
-		time.Now().UTC().Format(time.RFC3339),
+		raw,
+		resp.Header.Get("ETag"),
+		contentETag(b),
+		resp.Header.Get("Last-Modified"),
+		now,
+		len(b),
+		now,
 	)
 	if err != nil {
 		return "", err
@@ -123,6 +167,161 @@ VALUES(?,?,?,?);`,
 	return key, nil
 }
 
+// RevalidateLogo re-issues key's fetch as a conditional GET using its
+// stored ETag/Last-Modified. A 304 just refreshes fetched_at (the file
+// on disk is untouched); a fresh 200 rewrites the file and metadata.
+// No-ops if key isn't cached.
+func RevalidateLogo(ctx context.Context, db *sql.DB, dataDir, key string) error {
+	var sourceURL, etag, lastModified string
+	err := db.QueryRowContext(ctx,
+		`SELECT source_url, etag, last_modified FROM logos WHERE key = ? LIMIT 1;`, key,
+	).Scan(&sourceURL, &etag, &lastModified)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if sourceURL == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := logoHTTPClient.Do(req)
+	if err != nil {
+		return nil // upstream down; keep serving the cached copy
+	}
+	defer resp.Body.Close()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	if resp.StatusCode == http.StatusNotModified {
+		_, err := db.ExecContext(ctx, `UPDATE logos SET fetched_at = ? WHERE key = ?;`, now, key)
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil // don't blow away a good cached copy over a transient upstream error
+	}
+
+	const max = 512 * 1024
+	b, err := io.ReadAll(io.LimitReader(resp.Body, max+1))
+	if err != nil || len(b) == 0 || len(b) > max {
+		return nil
+	}
+
+	if err := writeLogoFile(dataDir, key, b); err != nil {
+		return err
+	}
+
+	ct := resp.Header.Get("Content-Type")
+	if ct == "" {
+		ct = http.DetectContentType(b)
+	}
+
+	_, err = db.ExecContext(ctx, `
+UPDATE logos
+SET content_type = ?, etag = ?, content_etag = ?, last_modified = ?, fetched_at = ?, size = ?, last_accessed_at = ?
+WHERE key = ?;`,
+		ct, resp.Header.Get("ETag"), contentETag(b), resp.Header.Get("Last-Modified"), now, len(b), now, key,
+	)
+	if err != nil {
+		return err
+	}
+
+	// A fresh 200 invalidates any cached resized variants - they were
+	// generated from the old bytes.
+	_, err = db.ExecContext(ctx, `DELETE FROM logo_variants WHERE key = ?;`, key)
+	return err
+}
+
+// DefaultLogoRefreshDays is used when a caller passes olderThanDays <= 0
+// to StaleLogoKeys.
+const DefaultLogoRefreshDays = 30
+
+// StaleLogoKeys returns every logos key whose fetched_at is older than
+// olderThanDays, oldest first - the candidate list a scheduled
+// RevalidateLogo sweep works through so cached logos eventually notice
+// an upstream favicon change instead of being kept (and evicted) purely
+// by size.
+func StaleLogoKeys(ctx context.Context, db *sql.DB, olderThanDays int) ([]string, error) {
+	if olderThanDays <= 0 {
+		olderThanDays = DefaultLogoRefreshDays
+	}
+	cutoff := time.Now().UTC().AddDate(0, 0, -olderThanDays).Format(time.RFC3339)
+	rows, err := db.QueryContext(ctx,
+		`SELECT key FROM logos WHERE fetched_at < ? ORDER BY fetched_at ASC;`, cutoff,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// LogoFile returns key's content type, strong content ETag, bytes and
+// fetchedAt (for If-Modified-Since), reading the on-disk cache and
+// bumping last_accessed_at so the LRU evictor sees fresh use. Returns
+// sql.ErrNoRows if key isn't cached. A row written before the
+// content_etag column existed gets it computed and persisted here,
+// lazily, instead of needing a backfill migration that would've had to
+// read every cached file off disk up front.
+func LogoFile(ctx context.Context, db *sql.DB, dataDir, key string) (contentType, etag string, data []byte, fetchedAt string, err error) {
+	err = db.QueryRowContext(ctx,
+		`SELECT content_type, content_etag, fetched_at FROM logos WHERE key = ? LIMIT 1;`, key,
+	).Scan(&contentType, &etag, &fetchedAt)
+	if err != nil {
+		return "", "", nil, "", err
+	}
+
+	data, err = os.ReadFile(logoPath(dataDir, key))
+	if err != nil {
+		return "", "", nil, "", err
+	}
+
+	if etag == "" {
+		etag = contentETag(data)
+		_, _ = db.ExecContext(ctx, `UPDATE logos SET content_etag = ? WHERE key = ?;`, etag, key)
+	}
+
+	touchLogoAccess(ctx, db, key)
+	return contentType, etag, data, fetchedAt, nil
+}
+
+func touchLogoAccess(ctx context.Context, db *sql.DB, key string) {
+	_, _ = db.ExecContext(ctx,
+		`UPDATE logos SET last_accessed_at = ? WHERE key = ?;`,
+		time.Now().UTC().Format(time.RFC3339), key,
+	)
+}
+
+func writeLogoFile(dataDir, key string, b []byte) error {
+	path := logoPath(dataDir, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
 func FaviconURLForDomain(domain string) string {
 	domain = strings.TrimSpace(strings.ToLower(domain))
 	domain = strings.TrimPrefix(domain, "http://")
@@ -136,10 +335,10 @@ func FaviconURLForDomain(domain string) string {
 	return "https://www.google.com/s2/favicons?domain=" + url.QueryEscape(domain) + "&sz=64"
 }
 
-func CacheFaviconForDomain(ctx context.Context, db *sql.DB, domain string) (string, error) {
+func CacheFaviconForDomain(ctx context.Context, db *sql.DB, dataDir, domain string) (string, error) {
 	u := FaviconURLForDomain(domain)
 	if u == "" {
 		return "", nil
 	}
-	return CacheLogoFromURL(ctx, db, u)
+	return CacheLogoFromURL(ctx, db, dataDir, u)
 }
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"time"
+)
+
+// DefaultLogoCacheCapBytes is used when StartLogoEvictor is given a cap
+// <= 0.
+const DefaultLogoCacheCapBytes int64 = 200 * 1024 * 1024 // 200MB
+
+// StartLogoEvictor launches a background goroutine that periodically
+// sums the logos table's size column and, once it crosses capBytes,
+// deletes the oldest-by-last_accessed_at rows (and their on-disk
+// files) until back under the cap. capBytes <= 0 uses
+// DefaultLogoCacheCapBytes.
+func StartLogoEvictor(db *sql.DB, dataDir string, capBytes int64) {
+	if capBytes <= 0 {
+		capBytes = DefaultLogoCacheCapBytes
+	}
+
+	go func() {
+		t := time.NewTicker(10 * time.Minute)
+		defer t.Stop()
+
+		for {
+			evictLogosOverCap(db, dataDir, capBytes)
+			<-t.C
+		}
+	}()
+}
+
+func evictLogosOverCap(db *sql.DB, dataDir string, capBytes int64) {
+	ctx := context.Background()
+
+	var total int64
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(SUM(size), 0) FROM logos;`).Scan(&total); err != nil {
+		log.Printf("[logo-evict] sum size: %v", err)
+		return
+	}
+
+	if total <= capBytes {
+		return
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT key, size FROM logos ORDER BY last_accessed_at ASC;`)
+	if err != nil {
+		log.Printf("[logo-evict] list: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	var evicted int
+	for total > capBytes && rows.Next() {
+		var key string
+		var size int64
+		if err := rows.Scan(&key, &size); err != nil {
+			log.Printf("[logo-evict] scan: %v", err)
+			break
+		}
+
+		if _, err := db.ExecContext(ctx, `DELETE FROM logos WHERE key = ?;`, key); err != nil {
+			log.Printf("[logo-evict] delete row key=%s: %v", key, err)
+			continue
+		}
+		if err := os.Remove(logoPath(dataDir, key)); err != nil && !os.IsNotExist(err) {
+			log.Printf("[logo-evict] delete file key=%s: %v", key, err)
+		}
+
+		total -= size
+		evicted++
+	}
+
+	if evicted > 0 {
+		log.Printf("[logo-evict] evicted %d logos, now %d bytes (cap %d)", evicted, total, capBytes)
+	}
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// GetLogoVariant returns a previously cached resize of key at w x h /
+// fit / format, or found=false if none has been generated yet.
+func GetLogoVariant(ctx context.Context, db *sql.DB, key string, w, h int, fit, format string) (contentType, etag string, data []byte, found bool, err error) {
+	err = db.QueryRowContext(ctx, `
+SELECT content_type, etag, data FROM logo_variants
+WHERE key = ? AND w = ? AND h = ? AND fit = ? AND format = ?
+LIMIT 1;
+`, key, w, h, fit, format).Scan(&contentType, &etag, &data)
+	if err == sql.ErrNoRows {
+		return "", "", nil, false, nil
+	}
+	if err != nil {
+		return "", "", nil, false, err
+	}
+	return contentType, etag, data, true, nil
+}
+
+// PutLogoVariant caches a resize of key at w x h / fit / format, so
+// the next request for the same combination (the job list UI only
+// ever asks for a handful of sizes) skips imaging.Resize entirely.
+func PutLogoVariant(ctx context.Context, db *sql.DB, key string, w, h int, fit, format, contentType, etag string, data []byte) error {
+	_, err := db.ExecContext(ctx, `
+INSERT INTO logo_variants(key, w, h, fit, format, content_type, etag, data, size, created_at)
+VALUES(?,?,?,?,?,?,?,?,?,?)
+ON CONFLICT(key, w, h, fit, format) DO UPDATE SET
+  content_type = excluded.content_type,
+  etag = excluded.etag,
+  data = excluded.data,
+  size = excluded.size,
+  created_at = excluded.created_at;
+`, key, w, h, fit, format, contentType, etag, data, len(data), time.Now().UTC().Format(time.RFC3339))
+	return err
+}
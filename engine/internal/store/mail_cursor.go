@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// GetMailCursor returns the persisted (uidValidity, lastUID) high-water
+// mark for account/mailbox, or (0, 0) if none is stored yet - email_scrape's
+// imapSource treats that the same as "first run, search the whole mailbox".
+func GetMailCursor(ctx context.Context, db *sql.DB, account, mailbox string) (uidValidity, lastUID uint32, err error) {
+	err = db.QueryRowContext(ctx,
+		`SELECT uidvalidity, last_uid FROM mail_cursors WHERE account = ? AND mailbox = ? LIMIT 1;`,
+		account, mailbox,
+	).Scan(&uidValidity, &lastUID)
+	if err == sql.ErrNoRows {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return uidValidity, lastUID, nil
+}
+
+// SetMailCursor persists uidValidity/lastUID as account/mailbox's new
+// high-water mark, so the next run's List only SEARCHes UIDs after it.
+func SetMailCursor(ctx context.Context, db *sql.DB, account, mailbox string, uidValidity, lastUID uint32) error {
+	_, err := db.ExecContext(ctx, `
+INSERT INTO mail_cursors(account, mailbox, uidvalidity, last_uid, updated_at)
+VALUES(?,?,?,?,?)
+ON CONFLICT(account, mailbox) DO UPDATE SET
+  uidvalidity = excluded.uidvalidity,
+  last_uid = excluded.last_uid,
+  updated_at = excluded.updated_at;
+`, account, mailbox, uidValidity, lastUID, time.Now().UTC().Format(time.RFC3339))
+	return err
+}
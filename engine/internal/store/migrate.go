@@ -0,0 +1,352 @@
+package store
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migration is one schema_migrations-tracked step. Up and Down come
+// from migrations/NNNN_name.up.sql and migrations/NNNN_name.down.sql,
+// embedded at build time so the binary never depends on a migrations
+// directory existing on disk at runtime.
+type migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// loadMigrations reads every migrations/NNNN_name.{up,down}.sql pair
+// from migrationFiles and returns them sorted by Version ascending.
+func loadMigrations() ([]migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations dir: %w", err)
+	}
+
+	byVersion := map[int]*migration{}
+	for _, e := range entries {
+		name := e.Name()
+		var isUp bool
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			isUp = true
+		case strings.HasSuffix(name, ".down.sql"):
+			isUp = false
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(strings.TrimSuffix(name, ".up.sql"), ".down.sql")
+		versionStr, migName, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migrations/%s: expected NNNN_name.{up,down}.sql", name)
+		}
+		version, err := strconv.Atoi(versionStr)
+		if err != nil {
+			return nil, fmt.Errorf("migrations/%s: bad version %q: %w", name, versionStr, err)
+		}
+
+		b, err := fs.ReadFile(migrationFiles, "migrations/"+name)
+		if err != nil {
+			return nil, fmt.Errorf("read migrations/%s: %w", name, err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{Version: version, Name: migName}
+			byVersion[version] = m
+		}
+		if isUp {
+			m.Up = string(b)
+		} else {
+			m.Down = string(b)
+		}
+	}
+
+	out := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migrations: version %d (%s) is missing its up or down file", m.Version, m.Name)
+		}
+		out = append(out, *m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+func checksum(sqlText string) string {
+	sum := sha256.Sum256([]byte(sqlText))
+	return hex.EncodeToString(sum[:])
+}
+
+// ensureSchemaMigrations creates the tracking table Migrate/MigrateTo/
+// Rollback use, if it doesn't already exist. On a database that
+// already has data in it from before this versioning system existed
+// (PRAGMA user_version set by the old ad-hoc Migrate), it backfills
+// one synthetic row per migration up to that version, so an upgrade
+// doesn't try to re-run CREATE TABLE against tables that are already
+// there.
+func ensureSchemaMigrations(tx *sql.Tx, all []migration) error {
+	var exists int
+	err := tx.QueryRow(`SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations';`).Scan(&exists)
+	if err == nil {
+		return nil
+	}
+	if err != sql.ErrNoRows {
+		return err
+	}
+
+	if _, err := tx.Exec(`
+CREATE TABLE schema_migrations (
+  version INTEGER PRIMARY KEY,
+  name TEXT NOT NULL,
+  checksum TEXT NOT NULL,
+  applied_at TEXT NOT NULL
+);
+`); err != nil {
+		return err
+	}
+
+	var legacyVersion int
+	if err := tx.QueryRow(`PRAGMA user_version;`).Scan(&legacyVersion); err != nil {
+		return err
+	}
+	if legacyVersion == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, m := range all {
+		if m.Version > legacyVersion {
+			break
+		}
+		if _, err := tx.Exec(
+			`INSERT INTO schema_migrations(version, name, checksum, applied_at) VALUES (?,?,?,?);`,
+			m.Version, m.Name, checksum(m.Up), now,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// currentVersion returns the highest version recorded in
+// schema_migrations, or 0 if none has been applied yet.
+func currentVersion(tx *sql.Tx) (int, error) {
+	var v sql.NullInt64
+	if err := tx.QueryRow(`SELECT MAX(version) FROM schema_migrations;`).Scan(&v); err != nil {
+		return 0, err
+	}
+	return int(v.Int64), nil
+}
+
+// verifyAppliedChecksums guards against an already-applied migration's
+// .up.sql being edited after the fact: schema_migrations' checksum
+// column exists specifically to catch that, since silently treating a
+// changed migration as already-applied would leave the database on an
+// unknown schema with no record of it.
+func verifyAppliedChecksums(tx *sql.Tx, all []migration) error {
+	byVersion := make(map[int]migration, len(all))
+	for _, m := range all {
+		byVersion[m.Version] = m
+	}
+
+	rows, err := tx.Query(`SELECT version, checksum FROM schema_migrations;`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int
+		var storedSum string
+		if err := rows.Scan(&version, &storedSum); err != nil {
+			return err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			continue // applied by a newer build than this binary knows about
+		}
+		if got := checksum(m.Up); got != storedSum {
+			return fmt.Errorf("migrations: version %d (%s) has changed since it was applied (checksum mismatch) - migrations must not be edited after release", version, m.Name)
+		}
+	}
+	return rows.Err()
+}
+
+func applyUp(tx *sql.Tx, m migration) error {
+	if _, err := tx.Exec(m.Up); err != nil {
+		return err
+	}
+	_, err := tx.Exec(
+		`INSERT INTO schema_migrations(version, name, checksum, applied_at) VALUES (?,?,?,?);`,
+		m.Version, m.Name, checksum(m.Up), time.Now().UTC().Format(time.RFC3339),
+	)
+	return err
+}
+
+func applyDown(tx *sql.Tx, m migration) error {
+	if _, err := tx.Exec(m.Down); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`DELETE FROM schema_migrations WHERE version = ?;`, m.Version)
+	return err
+}
+
+// Migrate brings db to the newest migration in migrations/. Safe to
+// call on every startup: a database already at the newest version is
+// a no-op.
+func Migrate(db *sql.DB) error {
+	return MigrateTo(db, 0)
+}
+
+// MigrateTo brings db to exactly version target, applying pending
+// migrations' Up in ascending order if target is above the current
+// version, or unwinding already-applied ones' Down in descending order
+// if it's below. target <= 0 means "the newest migration available".
+func MigrateTo(db *sql.DB, target int) error {
+	all, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+	if len(all) == 0 {
+		return nil
+	}
+	latest := all[len(all)-1].Version
+	if target <= 0 || target > latest {
+		target = latest
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := ensureSchemaMigrations(tx, all); err != nil {
+		return err
+	}
+
+	current, err := currentVersion(tx)
+	if err != nil {
+		return err
+	}
+	if err := verifyAppliedChecksums(tx, all); err != nil {
+		return err
+	}
+
+	switch {
+	case current < target:
+		for _, m := range all {
+			if m.Version <= current || m.Version > target {
+				continue
+			}
+			if err := applyUp(tx, m); err != nil {
+				return fmt.Errorf("migrate up to %d (%s): %w", m.Version, m.Name, err)
+			}
+		}
+	case current > target:
+		for i := len(all) - 1; i >= 0; i-- {
+			m := all[i]
+			if m.Version > current || m.Version <= target {
+				continue
+			}
+			if err := applyDown(tx, m); err != nil {
+				return fmt.Errorf("migrate down from %d (%s): %w", m.Version, m.Name, err)
+			}
+		}
+	}
+
+	if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d;`, target)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Rollback reverts the last n applied migrations, in descending
+// version order, running each one's Down. n <= 0 is a no-op.
+func Rollback(db *sql.DB, n int) error {
+	if n <= 0 {
+		return nil
+	}
+
+	all, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := ensureSchemaMigrations(tx, all); err != nil {
+		return err
+	}
+	current, err := currentVersion(tx)
+	if err != nil {
+		return err
+	}
+	if err := verifyAppliedChecksums(tx, all); err != nil {
+		return err
+	}
+
+	target := current - n
+	if target < 0 {
+		target = 0
+	}
+
+	byVersion := make(map[int]migration, len(all))
+	for _, m := range all {
+		byVersion[m.Version] = m
+	}
+
+	rows, err := tx.Query(`SELECT version FROM schema_migrations WHERE version > ? ORDER BY version DESC;`, target)
+	if err != nil {
+		return err
+	}
+	var versions []int
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			rows.Close()
+			return err
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	for _, v := range versions {
+		m, ok := byVersion[v]
+		if !ok {
+			return fmt.Errorf("migrations: applied version %d has no migration file to roll back", v)
+		}
+		if err := applyDown(tx, m); err != nil {
+			return fmt.Errorf("rollback %d (%s): %w", v, m.Name, err)
+		}
+	}
+
+	if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d;`, target)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
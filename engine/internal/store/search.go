@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// JobSearchHit is one ranked match from SearchJobs: the full Job row
+// plus an HTML snippet (<b>...</b> highlighting) FTS5 built around
+// the match.
+type JobSearchHit struct {
+	Job
+	Snippet string `json:"snippet"`
+}
+
+// FindJobExact looks for one job matching q verbatim against
+// source_id or url - the two identifiers a single search box is
+// plausibly handed as-is (pasted from an ATS page or an email link).
+// A company-name match is handled separately by FindCompanyExact
+// since it can span many rows, not one job.
+func FindJobExact(ctx context.Context, db *sql.DB, q string) (*Job, error) {
+	row := db.QueryRowContext(ctx, `
+SELECT id, company, title, location, work_mode, url, score, tags, date, logo_key
+FROM jobs
+WHERE source_id = ? OR url = ?
+ORDER BY date DESC
+LIMIT 1;
+`, q, q)
+
+	var j Job
+	var tagsJSON, dateStr string
+	if err := row.Scan(&j.ID, &j.Company, &j.Title, &j.Location, &j.WorkMode, &j.URL, &j.Score, &tagsJSON, &dateStr, &j.LogoKey); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	_ = json.Unmarshal([]byte(tagsJSON), &j.Tags)
+	if j.LogoKey != "" {
+		j.CompanyLogoURL = "/logo/" + j.LogoKey
+	}
+	if parsed, perr := time.Parse(time.RFC3339, dateStr); perr == nil {
+		j.Date = parsed.Format("2006-01-02 15:04:05")
+	}
+	return &j, nil
+}
+
+// FindCompanyExact reports whether q matches a company name in jobs
+// exactly (case-insensitive), returning the name as stored (for
+// consistent casing in the response) if so.
+func FindCompanyExact(ctx context.Context, db *sql.DB, q string) (string, bool, error) {
+	var company string
+	err := db.QueryRowContext(ctx, `SELECT company FROM jobs WHERE company = ? COLLATE NOCASE LIMIT 1;`, q).Scan(&company)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return company, true, nil
+}
+
+// SearchJobs runs matchExpr (an FTS5 MATCH expression - see
+// httpapi.parseSearchQuery for how typed prefixes like "tag:golang"
+// become jobs_fts column filters) against jobs_fts, ranked by FTS5's
+// bm25 and joined back to jobs for the full row. limit<=0 defaults to
+// 20.
+func SearchJobs(ctx context.Context, db *sql.DB, matchExpr string, limit int) ([]JobSearchHit, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	rows, err := db.QueryContext(ctx, `
+SELECT j.id, j.company, j.title, j.location, j.work_mode, j.url, j.score, j.tags, j.date, j.logo_key,
+       snippet(jobs_fts, -1, '<b>', '</b>', '...', 10)
+FROM jobs_fts
+JOIN jobs j ON j.id = jobs_fts.rowid
+WHERE jobs_fts MATCH ?
+ORDER BY bm25(jobs_fts)
+LIMIT ?;
+`, matchExpr, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []JobSearchHit
+	for rows.Next() {
+		var h JobSearchHit
+		var tagsJSON, dateStr string
+		if err := rows.Scan(&h.ID, &h.Company, &h.Title, &h.Location, &h.WorkMode, &h.URL, &h.Score, &tagsJSON, &dateStr, &h.LogoKey, &h.Snippet); err != nil {
+			return nil, err
+		}
+		_ = json.Unmarshal([]byte(tagsJSON), &h.Tags)
+		if h.LogoKey != "" {
+			h.CompanyLogoURL = "/logo/" + h.LogoKey
+		}
+		if parsed, perr := time.Parse(time.RFC3339, dateStr); perr == nil {
+			h.Date = parsed.Format("2006-01-02 15:04:05")
+		}
+		out = append(out, h)
+	}
+	return out, rows.Err()
+}
@@ -20,6 +20,11 @@ type Job struct {
 	Date           string   `json:"date"`
 	CompanyLogoURL string   `json:"companyLogoURL"`
 	LogoKey        string   `json:"logoKey"`
+
+	// Snippet is an FTS5-highlighted excerpt (see snippet(jobs_fts,
+	// ...)) around the matched terms. Only populated when ListJobs was
+	// called with ListJobsOpts.Query set; empty otherwise.
+	Snippet string `json:"snippet,omitempty"`
 }
 
 type ListJobsOpts struct {
@@ -27,128 +32,42 @@ type ListJobsOpts struct {
 	Order  string // asc | desc
 	Window string // 24h | 7d | all
 	Limit  int
-}
-
-func Migrate(db *sql.DB) error {
 
-	tx, err := db.Begin()
-	if err != nil {
-		return err
-	}
-	defer func() { _ = tx.Rollback() }()
+	// Where, when non-empty, is AND'd onto the window filter as a
+	// parenthesized SQL boolean fragment; Args are its positional "?"
+	// values. Populated from query.Compile by httpapi's search
+	// handler - never built by hand from a request value. Ignored when
+	// Query is set - see Query's doc comment.
+	Where string
+	Args  []any
 
-	var v int
-	if err := tx.QueryRow(`PRAGMA user_version;`).Scan(&v); err != nil {
-		return err
-	}
-
-	if v >= 1 {
-		return tx.Commit()
-	}
-
-	// ---- Schema v1: tables ----
-
-	if _, err := tx.Exec(`
-CREATE TABLE IF NOT EXISTS jobs (
-  id INTEGER PRIMARY KEY AUTOINCREMENT,
-  company TEXT NOT NULL,
-  title TEXT NOT NULL,
-  location TEXT NOT NULL,
-  work_mode TEXT NOT NULL,
-  url TEXT NOT NULL,
-  score INTEGER NOT NULL DEFAULT 0,
-  tags TEXT NOT NULL DEFAULT '[]',
-  date TEXT NOT NULL,
-  source_id TEXT NOT NULL DEFAULT '',
-  logo_key TEXT NOT NULL DEFAULT ''
-);
-`); err != nil {
-		return err
-	}
+	// Query, when non-empty, is an already-compiled FTS5 MATCH
+	// expression (the same kind httpapi.parseSearchQuery builds for
+	// SearchJobs - prefix terms, "phrase queries" and column filters
+	// like company:acme all come from how that expression was put
+	// together, not from anything ListJobs parses itself) run against
+	// jobs_fts instead of the plain column scan above. Sort/Order are
+	// ignored when Query is set: results are ordered by a blend of
+	// FTS5's bm25(jobs_fts) rank and the jobs.score column instead -
+	// see ScoreWeight/TextWeight. Where/Args are also ignored, since
+	// jobs_fts mirrors several jobs column names (title, company, ...)
+	// and AND-ing in an unqualified SQL fragment would risk silently
+	// resolving against the wrong table.
+	Query string
 
-	if _, err := tx.Exec(`
-CREATE TABLE IF NOT EXISTS logos (
-  key TEXT PRIMARY KEY,
-  content_type TEXT NOT NULL,
-  bytes BLOB NOT NULL,
-  fetched_at TEXT NOT NULL
-);
-`); err != nil {
-		return err
-	}
-
-	if _, err := tx.Exec(`
-CREATE TABLE IF NOT EXISTS company_domains (
-  company TEXT PRIMARY KEY,
-  domain TEXT NOT NULL,
-  fetched_at TEXT NOT NULL
-);
-`); err != nil {
-		return err
-	}
-
-	// ---- Schema v1: indexes ----
-
-	if _, err := tx.Exec(`
-CREATE INDEX IF NOT EXISTS idx_company_domains_domain
-ON company_domains(domain);
-`); err != nil {
-		return err
-	}
-
-	if _, err := tx.Exec(`
-CREATE INDEX IF NOT EXISTS idx_jobs_date
-ON jobs(date);
-`); err != nil {
-		return err
-	}
-
-	if _, err := tx.Exec(`
-CREATE UNIQUE INDEX IF NOT EXISTS idx_jobs_source_id
-ON jobs(source_id)
-WHERE source_id != '';
-`); err != nil {
-		return err
-	}
-
-	// Back-compat for dev DBs that might predate these columns.
-	// (Technically unnecessary once you rely on user_version properly,
-	// but it's harmless and useful during development.)
-	if !columnExists(tx, "jobs", "source_id") {
-		if _, err := tx.Exec(`ALTER TABLE jobs ADD COLUMN source_id TEXT NOT NULL DEFAULT '';`); err != nil {
-			return err
-		}
-	}
-	if !columnExists(tx, "jobs", "logo_key") {
-		if _, err := tx.Exec(`ALTER TABLE jobs ADD COLUMN logo_key TEXT NOT NULL DEFAULT '';`); err != nil {
-			return err
-		}
-	}
-
-	// Mark schema v1
-	if _, err := tx.Exec(`PRAGMA user_version = 1;`); err != nil {
-		return err
-	}
-
-	return tx.Commit()
-}
-
-func columnExists(q interface {
-	QueryRow(query string, args ...any) *sql.Row
-}, table, col string) bool {
-	query := fmt.Sprintf(`
-SELECT 1
-FROM pragma_table_info('%s')
-WHERE name = ?
-LIMIT 1;
-`, table)
-
-	var one int
-	err := q.QueryRow(query, col).Scan(&one)
-	return err == nil
+	// ScoreWeight and TextWeight control the Query ranking blend:
+	// ORDER BY (ScoreWeight*jobs.score) - (TextWeight*bm25(jobs_fts))
+	// DESC (bm25 is lower-is-better, hence the subtraction). Zero
+	// values default both to 1.0.
+	ScoreWeight float64
+	TextWeight  float64
 }
 
 func ListJobs(ctx context.Context, db *sql.DB, opts ListJobsOpts) ([]Job, error) {
+	if opts.Query != "" {
+		return listJobsFTS(ctx, db, opts)
+	}
+
 	// defaults
 	if opts.Sort == "" {
 		opts.Sort = "score"
@@ -194,6 +113,17 @@ func ListJobs(ctx context.Context, db *sql.DB, opts ListJobsOpts) ([]Job, error)
 		where = "WHERE date >= datetime('now','-7 days')"
 	}
 
+	args := []any{}
+	if opts.Where != "" {
+		if where == "" {
+			where = "WHERE (" + opts.Where + ")"
+		} else {
+			where += " AND (" + opts.Where + ")"
+		}
+		args = append(args, opts.Args...)
+	}
+	args = append(args, opts.Limit)
+
 	query := fmt.Sprintf(`
 SELECT id, company, title, location, work_mode, url, score, tags, date, logo_key
 FROM jobs
@@ -202,7 +132,7 @@ ORDER BY %s %s
 LIMIT ?;
 `, where, sortCol, opts.Order)
 
-	rows, err := db.QueryContext(ctx, query, opts.Limit)
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -247,6 +177,70 @@ LIMIT ?;
 	return out, nil
 }
 
+// listJobsFTS is ListJobs's path for ListJobsOpts.Query: it MATCHes
+// jobs_fts instead of scanning jobs, and orders by a weighted blend of
+// the match's bm25 rank and jobs.score rather than opts.Sort/Order.
+func listJobsFTS(ctx context.Context, db *sql.DB, opts ListJobsOpts) ([]Job, error) {
+	scoreWeight, textWeight := opts.ScoreWeight, opts.TextWeight
+	if scoreWeight == 0 {
+		scoreWeight = 1.0
+	}
+	if textWeight == 0 {
+		textWeight = 1.0
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 500
+	}
+
+	rows, err := db.QueryContext(ctx, `
+SELECT j.id, j.company, j.title, j.location, j.work_mode, j.url, j.score, j.tags, j.date, j.logo_key,
+       snippet(jobs_fts, -1, '<b>', '</b>', '...', 10)
+FROM jobs_fts
+JOIN jobs j ON j.id = jobs_fts.rowid
+WHERE jobs_fts MATCH ?
+ORDER BY (? * j.score) - (? * bm25(jobs_fts)) DESC
+LIMIT ?;
+`, opts.Query, scoreWeight, textWeight, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Job
+	for rows.Next() {
+		var j Job
+		var tagsJSON, dateStr string
+		if err := rows.Scan(
+			&j.ID,
+			&j.Company,
+			&j.Title,
+			&j.Location,
+			&j.WorkMode,
+			&j.URL,
+			&j.Score,
+			&tagsJSON,
+			&dateStr,
+			&j.LogoKey,
+			&j.Snippet,
+		); err != nil {
+			return nil, err
+		}
+		if j.LogoKey != "" {
+			j.CompanyLogoURL = "/logo/" + j.LogoKey
+		}
+		_ = json.Unmarshal([]byte(tagsJSON), &j.Tags)
+		if parsedDate, perr := time.Parse(time.RFC3339, dateStr); perr == nil {
+			j.Date = parsedDate.Format("2006-01-02 15:04:05")
+		}
+		out = append(out, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func SeedJob(ctx context.Context, db *sql.DB) (Job, error) {
 	j := Job{
 		Company:  "SeedCo",
@@ -270,11 +264,21 @@ VALUES(?,?,?,?,?,?,?,?);`,
 	return j, nil
 }
 
-func CleanupOldJobs(db *sql.DB) (deleted int64, err error) {
+// DefaultJobsRetentionDays is used when CleanupOldJobs is given a
+// retentionDays <= 0 (matches the 3-month window this replaced).
+const DefaultJobsRetentionDays = 90
+
+// CleanupOldJobs deletes jobs rows older than retentionDays
+// (config.Config.Maintenance.JobsRetentionDays - DefaultJobsRetentionDays
+// if <= 0).
+func CleanupOldJobs(db *sql.DB, retentionDays int) (deleted int64, err error) {
+	if retentionDays <= 0 {
+		retentionDays = DefaultJobsRetentionDays
+	}
 	res, err := db.Exec(`
 DELETE FROM jobs
-WHERE date < datetime('now', '-3 months');
-`)
+WHERE date < datetime('now', ?);
+`, fmt.Sprintf("-%d days", retentionDays))
 	if err != nil {
 		return 0, fmt.Errorf("cleanup old jobs: %w", err)
 	}